@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// Exported Object Types
+// -----------------------------------------------------------------------------
+
+// exportType describes an object type which can be exported.  The objects
+// are listed with the index endpoint and read with the ReadContext function
+// of the provider's resource.
+type exportType struct {
+	// Name of the resource in the provider, ie: "foreman_domain"
+	Resource string
+	// Index endpoint listing all objects of the type
+	Endpoint string
+	// Whether the index endpoint is scoped to the organization
+	Organization bool
+	// Whether the type is exported if no types are selected explicitly
+	Default bool
+}
+
+// exportTypes lists all object types supported by the exporter.  The order
+// is used for the generated files and roughly follows the dependencies
+// between the types.
+var exportTypes = []exportType{
+	{Resource: "foreman_architecture", Endpoint: api.ArchitectureEndpointPrefix, Default: true},
+	{Resource: "foreman_domain", Endpoint: api.DomainEndpointPrefix, Default: true},
+	{Resource: "foreman_environment", Endpoint: api.EnvironmentEndpointPrefix, Default: true},
+	{Resource: "foreman_media", Endpoint: api.MediaEndpointPrefix, Default: true},
+	{Resource: "foreman_model", Endpoint: api.ModelEndpointPrefix, Default: true},
+	{Resource: "foreman_smartproxy", Endpoint: api.SmartProxyEndpointPrefix, Default: true},
+	{Resource: "foreman_httpproxy", Endpoint: api.HTTPProxyEndpointPrefix, Default: true},
+	{Resource: "foreman_operatingsystem", Endpoint: api.OperatingSystemEndpointPrefix, Default: true},
+	{Resource: "foreman_partitiontable", Endpoint: api.PartitionTableEndpointPrefix, Default: true},
+	{Resource: "foreman_provisioningtemplate", Endpoint: api.ProvisioningTemplateEndpointPrefix, Default: true},
+	{Resource: "foreman_jobtemplate", Endpoint: api.JobTemplateEndpointPrefix, Default: true},
+	{Resource: "foreman_subnet", Endpoint: api.SubnetEndpointPrefix, Default: true},
+	{Resource: "foreman_computeresource", Endpoint: api.ComputeResourceEndpointPrefix, Default: true},
+	{Resource: "foreman_computeprofile", Endpoint: api.ComputeProfileEndpointPrefix, Default: true},
+	{Resource: "foreman_global_parameter", Endpoint: api.CommonParameterEndpointPrefix, Default: true},
+	{Resource: "foreman_usergroup", Endpoint: api.UsergroupEndpointPrefix, Default: true},
+	{Resource: "foreman_katello_content_credential", Endpoint: api.KatelloContentCredentialEndpointPrefix, Organization: true, Default: true},
+	{Resource: "foreman_katello_sync_plan", Endpoint: api.KatelloSyncPlanIndexEndpoint, Organization: true, Default: true},
+	{Resource: "foreman_katello_product", Endpoint: api.KatelloProductEndpointPrefix, Organization: true, Default: true},
+	{Resource: "foreman_katello_repository", Endpoint: api.KatelloRepositoryEndpointPrefix, Organization: true, Default: true},
+	{Resource: "foreman_katello_lifecycle_environment", Endpoint: api.LifecycleEnvironmentEndpointPrefix, Organization: true, Default: true},
+	{Resource: "foreman_katello_content_view", Endpoint: api.ContentViewEndpointPrefix, Organization: true, Default: true},
+	{Resource: "foreman_hostgroup", Endpoint: api.HostgroupEndpointPrefix, Default: true},
+	{Resource: "foreman_webhooktemplate", Endpoint: api.WebhookTemplateEndpointPrefix, Default: true},
+	{Resource: "foreman_webhook", Endpoint: api.WebhookEndpointPrefix, Default: true},
+	// Users and hosts are usually managed elsewhere and can be numerous,
+	// they are only exported on request
+	{Resource: "foreman_user", Endpoint: api.UserEndpointPrefix},
+	{Resource: "foreman_host", Endpoint: api.HostEndpointPrefix},
+}
+
+// selectExportTypes returns the export types for a comma separated list of
+// resource names.  The "foreman_" prefix of the names is optional.  An empty
+// list selects the default types.
+func selectExportTypes(list string) ([]exportType, error) {
+	if strings.TrimSpace(list) == "" {
+		selected := []exportType{}
+		for _, t := range exportTypes {
+			if t.Default {
+				selected = append(selected, t)
+			}
+		}
+		return selected, nil
+	}
+
+	wanted := map[string]bool{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if !strings.HasPrefix(name, "foreman_") {
+			name = "foreman_" + name
+		}
+		wanted[name] = true
+	}
+
+	selected := []exportType{}
+	for _, t := range exportTypes {
+		if wanted[t.Resource] {
+			selected = append(selected, t)
+			delete(wanted, t.Resource)
+		}
+	}
+	if len(wanted) > 0 {
+		unknown := make([]string, 0, len(wanted))
+		for name := range wanted {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("Unsupported object types: %s", strings.Join(unknown, ", "))
+	}
+	return selected, nil
+}
+
+// -----------------------------------------------------------------------------
+// Export
+// -----------------------------------------------------------------------------
+
+// exportObject is a single object read from Foreman
+type exportObject struct {
+	// Resource name of the object, ie: "foreman_domain"
+	Resource string
+	// Foreman ID of the object
+	Id int
+	// Terraform state of the object, as set by the resource's read function
+	Data *schema.ResourceData
+}
+
+// exportObjects lists all objects of the supplied types and reads each of
+// them with the read function of the provider's resource.  Objects which
+// vanish between listing and reading are skipped.
+func exportObjects(ctx context.Context, client *api.Client, resources map[string]*schema.Resource, types []exportType) ([]exportObject, error) {
+	objects := []exportObject{}
+
+	for _, t := range types {
+		r, ok := resources[t.Resource]
+		if !ok {
+			return nil, fmt.Errorf("Resource [%s] is not supported by the provider", t.Resource)
+		}
+
+		var params url.Values
+		if t.Organization {
+			params = client.OrganizationParams()
+		}
+
+		queryResponse, queryErr := api.SearchAll[api.ForemanObject](ctx, client, t.Endpoint, nil, params)
+		if queryErr != nil {
+			return nil, fmt.Errorf("Listing [%s] failed: %w", t.Resource, queryErr)
+		}
+
+		for _, result := range queryResponse.Results {
+			obj := result.(api.ForemanObject)
+
+			d := r.Data(nil)
+			d.SetId(strconv.Itoa(obj.Id))
+			if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+				return nil, fmt.Errorf("Reading [%s] with ID [%d] failed: %s", t.Resource, obj.Id, diags[0].Summary)
+			}
+			if d.Id() == "" {
+				continue
+			}
+
+			objects = append(objects, exportObject{
+				Resource: t.Resource,
+				Id:       obj.Id,
+				Data:     d,
+			})
+		}
+	}
+
+	return objects, nil
+}
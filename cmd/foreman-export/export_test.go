@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+// ----------------------------------------------------------------------------
+// Type Selection
+// ----------------------------------------------------------------------------
+
+// Ensures object types are selected with and without the provider prefix and
+// unknown types are rejected
+func TestSelectExportTypes(t *testing.T) {
+	selected, err := selectExportTypes("subnet, foreman_domain")
+	if err != nil {
+		t.Fatalf("selectExportTypes returned error [%s]", err)
+	}
+	// the order of exportTypes is kept
+	if len(selected) != 2 || selected[0].Resource != "foreman_domain" || selected[1].Resource != "foreman_subnet" {
+		t.Fatalf("selectExportTypes did not select the correct types, got [%+v]", selected)
+	}
+
+	defaults, _ := selectExportTypes("")
+	for _, exportType := range defaults {
+		if exportType.Resource == "foreman_host" {
+			t.Fatalf("selectExportTypes selected hosts by default")
+		}
+	}
+
+	if _, err := selectExportTypes("domain,nonexistent"); err == nil {
+		t.Fatalf("selectExportTypes did not return an error for an unknown type")
+	}
+}
+
+// Ensures names are converted to valid Terraform identifiers
+func TestSanitizeLabel(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Expected string
+	}{
+		{Name: "example.com", Expected: "example_com"},
+		{Name: "Base/Web Servers", Expected: "base_web_servers"},
+		{Name: "CentOS 7 - x86_64", Expected: "centos_7_-_x86_64"},
+		{Name: "--/..", Expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		output := sanitizeLabel(testCase.Name)
+		if output != testCase.Expected {
+			t.Fatalf(
+				"sanitizeLabel did not return correct value. Expected [%s], got [%s] for input [%s]",
+				testCase.Expected,
+				output,
+				testCase.Name,
+			)
+		}
+	}
+}
+
+// Ensures attributes reference the resource type of the object they hold the
+// ID of, depending on the resource where the type differs
+func TestReferenceType(t *testing.T) {
+	testCases := []struct {
+		Resource string
+		Key      string
+		Expected string
+	}{
+		{Resource: "foreman_hostgroup", Key: "parent_id", Expected: "foreman_hostgroup"},
+		{Resource: "foreman_location", Key: "parent_id", Expected: "foreman_location"},
+		{Resource: "foreman_organization", Key: "parent_id", Expected: "foreman_organization"},
+		{Resource: "foreman_subnet", Key: "template_id", Expected: "foreman_smartproxy"},
+		{Resource: "foreman_subnet", Key: "domain_ids", Expected: "foreman_domain"},
+		{Resource: "foreman_template_input", Key: "template_id", Expected: ""},
+		{Resource: "foreman_domain", Key: "name", Expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		output := referenceType(testCase.Resource, testCase.Key)
+		if output != testCase.Expected {
+			t.Fatalf(
+				"referenceType did not return correct value. Expected [%s], got [%s] for [%s.%s]",
+				testCase.Expected,
+				output,
+				testCase.Resource,
+				testCase.Key,
+			)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Export
+// ----------------------------------------------------------------------------
+
+// Ensures the exported objects are written as resource and import blocks and
+// IDs of exported objects are replaced with references
+func TestExport(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	respond := func(path string, body string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		})
+	}
+	respond("/api/domains", `{"subtotal": 2, "results": [
+		{"id": 1, "name": "example.com"},
+		{"id": 2, "name": "example.com"}
+	]}`)
+	respond("/api/domains/1", `{"id": 1, "name": "example.com", "fullname": "Example"}`)
+	respond("/api/domains/2", `{"id": 2, "name": "example.com"}`)
+	respond("/api/subnets", `{"subtotal": 1, "results": [{"id": 5, "name": "10.0.0.0 DC1"}]}`)
+	respond("/api/subnets/5", `{
+		"id": 5,
+		"name": "10.0.0.0 DC1",
+		"network": "10.0.0.0",
+		"mask": "255.255.255.0",
+		"ipam": "DHCP",
+		"boot_mode": "DHCP",
+		"mtu": 1500,
+		"network_type": "IPv4",
+		"dhcp_id": 3,
+		"domain_ids": [1]
+	}`)
+
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	types, _ := selectExportTypes("domain,subnet")
+
+	files, err := export(context.TODO(), client, types)
+	if err != nil {
+		t.Fatalf("export returned error [%s]", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("export did not return one file per type, got [%d] files", len(files))
+	}
+
+	domains := string(files["foreman_domain.tf"])
+	expected := []string{
+		"resource \"foreman_domain\" \"example_com\" {\n  fullname = \"Example\"\n  name     = \"example.com\"\n}",
+		"import {\n  to = foreman_domain.example_com\n  id = \"1\"\n}",
+		"resource \"foreman_domain\" \"example_com_2\" {\n  name = \"example.com\"\n}",
+		"import {\n  to = foreman_domain.example_com_2\n  id = \"2\"\n}",
+	}
+	for _, block := range expected {
+		if !strings.Contains(domains, block) {
+			t.Fatalf("export did not generate [%s], got:\n%s", block, domains)
+		}
+	}
+
+	// attributes are aligned by hclwrite, compare without the padding
+	subnets := regexp.MustCompile(` +=`).ReplaceAllString(string(files["foreman_subnet.tf"]), " =")
+	expected = []string{
+		"resource \"foreman_subnet\" \"subnet_10_0_0_0_dc1\" {",
+		"  dhcp_id = 3\n",
+		"  domain_ids = [foreman_domain.example_com.id]\n",
+		"  network = \"10.0.0.0\"\n",
+		"import {\n  to = foreman_subnet.subnet_10_0_0_0_dc1\n  id = \"5\"\n}",
+	}
+	for _, line := range expected {
+		if !strings.Contains(subnets, line) {
+			t.Fatalf("export did not generate [%s], got:\n%s", line, subnets)
+		}
+	}
+}
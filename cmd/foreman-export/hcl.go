@@ -0,0 +1,331 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// fileHeader is written at the top of every generated file
+const fileHeader = `# Generated by foreman-export.
+#
+# Sensitive attributes (ie: passwords) are not exported and have to be added
+# before applying the configuration.
+
+`
+
+// referenceTypes maps attributes holding the ID of another object to the
+// resource type of that object.  If the referenced object is part of the
+// export, the ID is replaced with a reference to the generated resource.
+var referenceTypes = map[string]string{
+	"architecture_id":          "foreman_architecture",
+	"bmc_id":                   "foreman_smartproxy",
+	"compute_profile_id":       "foreman_computeprofile",
+	"compute_resource_id":      "foreman_computeresource",
+	"content_source_id":        "foreman_smartproxy",
+	"content_view_id":          "foreman_katello_content_view",
+	"dhcp_id":                  "foreman_smartproxy",
+	"dns_id":                   "foreman_smartproxy",
+	"domain_id":                "foreman_domain",
+	"domain_ids":               "foreman_domain",
+	"environment_id":           "foreman_environment",
+	"gpg_key_id":               "foreman_katello_content_credential",
+	"host_ids":                 "foreman_host",
+	"hostgroup_id":             "foreman_hostgroup",
+	"hostgroup_ids":            "foreman_hostgroup",
+	"http_proxy_id":            "foreman_httpproxy",
+	"httpboot_id":              "foreman_smartproxy",
+	"lifecycle_environment_id": "foreman_katello_lifecycle_environment",
+	"medium_id":                "foreman_media",
+	"model_id":                 "foreman_model",
+	"operatingsystem_id":       "foreman_operatingsystem",
+	"operatingsystem_ids":      "foreman_operatingsystem",
+	"prior_id":                 "foreman_katello_lifecycle_environment",
+	"product_id":               "foreman_katello_product",
+	"provisioningtemplate_id":  "foreman_provisioningtemplate",
+	"ptable_id":                "foreman_partitiontable",
+	"puppet_ca_proxy_id":       "foreman_smartproxy",
+	"puppet_proxy_id":          "foreman_smartproxy",
+	"repository_ids":           "foreman_katello_repository",
+	"ssl_ca_cert_id":           "foreman_katello_content_credential",
+	"ssl_client_cert_id":       "foreman_katello_content_credential",
+	"ssl_client_key_id":        "foreman_katello_content_credential",
+	"subnet_id":                "foreman_subnet",
+	"sync_plan_id":             "foreman_katello_sync_plan",
+	"tftp_id":                  "foreman_smartproxy",
+	"webhook_template_id":      "foreman_webhooktemplate",
+}
+
+// resourceReferenceTypes maps attributes whose referenced resource type
+// depends on the resource, ie: the parent of a location is a location.  They
+// take precedence over referenceTypes.
+var resourceReferenceTypes = map[string]map[string]string{
+	"foreman_hostgroup":    {"parent_id": "foreman_hostgroup"},
+	"foreman_location":     {"parent_id": "foreman_location"},
+	"foreman_organization": {"parent_id": "foreman_organization"},
+	// the template proxy of the subnet
+	"foreman_subnet": {"template_id": "foreman_smartproxy"},
+}
+
+// referenceType returns the resource type referenced by an attribute of a
+// resource, or an empty string if the attribute is no reference
+func referenceType(resource string, key string) string {
+	if referenced, ok := resourceReferenceTypes[resource][key]; ok {
+		return referenced
+	}
+	return referenceTypes[key]
+}
+
+// -----------------------------------------------------------------------------
+// Rendering
+// -----------------------------------------------------------------------------
+
+// exportRenderer renders exported objects as Terraform configuration
+type exportRenderer struct {
+	resources map[string]*schema.Resource
+	// Labels of the generated resource blocks by resource type and ID
+	labels map[string]map[int]string
+}
+
+// newExportRenderer returns a renderer for the supplied objects with unique
+// labels assigned to all of them
+func newExportRenderer(resources map[string]*schema.Resource, objects []exportObject) *exportRenderer {
+	r := &exportRenderer{
+		resources: resources,
+		labels:    map[string]map[int]string{},
+	}
+
+	used := map[string]bool{}
+	for _, obj := range objects {
+		if r.labels[obj.Resource] == nil {
+			r.labels[obj.Resource] = map[int]string{}
+		}
+
+		base := objectLabel(resources[obj.Resource], obj)
+		label := base
+		for idx := 2; used[obj.Resource+"."+label]; idx++ {
+			label = fmt.Sprintf("%s_%d", base, idx)
+		}
+		used[obj.Resource+"."+label] = true
+		r.labels[obj.Resource][obj.Id] = label
+	}
+
+	return r
+}
+
+// render returns the generated configuration by file name.  Each resource
+// type is written to its own file, containing a resource and an import
+// block for every object of the type.
+func (r *exportRenderer) render(objects []exportObject) map[string][]byte {
+	files := map[string]*hclwrite.File{}
+	for _, obj := range objects {
+		f, ok := files[obj.Resource]
+		if !ok {
+			f = hclwrite.NewEmptyFile()
+			files[obj.Resource] = f
+		}
+		body := f.Body()
+
+		label := r.labels[obj.Resource][obj.Id]
+		block := body.AppendNewBlock("resource", []string{obj.Resource, label})
+		r.writeBody(block.Body(), obj.Resource, r.resources[obj.Resource].Schema, func(key string) interface{} {
+			return obj.Data.Get(key)
+		})
+		body.AppendNewline()
+
+		importBlock := body.AppendNewBlock("import", nil)
+		importBlock.Body().SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: obj.Resource},
+			hcl.TraverseAttr{Name: label},
+		})
+		importBlock.Body().SetAttributeValue("id", cty.StringVal(strconv.Itoa(obj.Id)))
+		body.AppendNewline()
+	}
+
+	output := make(map[string][]byte, len(files))
+	for resource, f := range files {
+		output[resource+".tf"] = append([]byte(fileHeader), hclwrite.Format(f.Bytes())...)
+	}
+	return output
+}
+
+// writeBody writes all configurable attributes and nested blocks of a
+// schema of the resource to the body.  get returns the value of an
+// attribute.
+func (r *exportRenderer) writeBody(body *hclwrite.Body, resource string, s map[string]*schema.Schema, get func(key string) interface{}) {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		attr := s[key]
+		if key == autodoc.MetaAttribute || !(attr.Required || attr.Optional) ||
+			attr.Deprecated != "" || attr.Sensitive {
+			continue
+		}
+
+		value := get(key)
+
+		if elem, ok := attr.Elem.(*schema.Resource); ok {
+			for _, item := range listValue(value) {
+				itemMap, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				nested := body.AppendNewBlock(key, nil)
+				r.writeBody(nested.Body(), resource, elem.Schema, func(key string) interface{} {
+					return itemMap[key]
+				})
+			}
+			continue
+		}
+
+		if skipValue(attr, value) {
+			continue
+		}
+		body.SetAttributeRaw(key, r.valueTokens(referenceType(resource, key), value))
+	}
+}
+
+// valueTokens renders a value.  If the value holds IDs of the referenced
+// resource type and the objects are exported, the IDs are rendered as
+// references to the generated resources.
+func (r *exportRenderer) valueTokens(referenced string, value interface{}) hclwrite.Tokens {
+	switch v := value.(type) {
+	case *schema.Set, []interface{}:
+		items := listValue(v)
+		elems := make([]hclwrite.Tokens, len(items))
+		for idx, item := range items {
+			elems[idx] = r.valueTokens(referenced, item)
+		}
+		return hclwrite.TokensForTuple(elems)
+	case map[string]interface{}:
+		mapKeys := make([]string, 0, len(v))
+		for mapKey := range v {
+			mapKeys = append(mapKeys, mapKey)
+		}
+		sort.Strings(mapKeys)
+		attrs := make([]hclwrite.ObjectAttrTokens, len(mapKeys))
+		for idx, mapKey := range mapKeys {
+			attrs[idx] = hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForValue(cty.StringVal(mapKey)),
+				Value: r.valueTokens("", v[mapKey]),
+			}
+		}
+		return hclwrite.TokensForObject(attrs)
+	case int:
+		if label, ok := r.labels[referenced][v]; ok {
+			return hclwrite.TokensForTraversal(hcl.Traversal{
+				hcl.TraverseRoot{Name: referenced},
+				hcl.TraverseAttr{Name: label},
+				hcl.TraverseAttr{Name: "id"},
+			})
+		}
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case string:
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	default:
+		return hclwrite.TokensForValue(cty.StringVal(fmt.Sprint(v)))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Helper Functions
+// -----------------------------------------------------------------------------
+
+// skipValue returns true if the value does not need to be written, because
+// it is the attribute's default or empty.  Empty values are skipped even if
+// the attribute has a different default, since Foreman returns them for
+// unset attributes.  Booleans are only skipped if they match the default.
+func skipValue(attr *schema.Schema, value interface{}) bool {
+	if attr.Required {
+		return false
+	}
+
+	empty := false
+	switch v := value.(type) {
+	case nil:
+		empty = true
+	case *schema.Set:
+		empty = v.Len() == 0
+	case []interface{}:
+		empty = len(v) == 0
+	case map[string]interface{}:
+		empty = len(v) == 0
+	case bool:
+		if attr.Default != nil {
+			return reflect.DeepEqual(attr.Default, value)
+		}
+		empty = !v
+	default:
+		empty = reflect.ValueOf(v).IsZero()
+	}
+
+	return empty || reflect.DeepEqual(attr.Default, value)
+}
+
+// listValue returns the items of a list or set value
+func listValue(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	default:
+		return nil
+	}
+}
+
+// objectLabel returns a label for the resource block of an object, derived
+// from its title, login or name
+func objectLabel(r *schema.Resource, obj exportObject) string {
+	prefix := strings.TrimPrefix(obj.Resource, "foreman_")
+
+	for _, attr := range []string{"title", "login", "name"} {
+		if _, ok := r.Schema[attr]; !ok {
+			continue
+		}
+		if name, ok := obj.Data.Get(attr).(string); ok {
+			if label := sanitizeLabel(name); label != "" {
+				if label[0] >= '0' && label[0] <= '9' {
+					label = prefix + "_" + label
+				}
+				return label
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s_%d", prefix, obj.Id)
+}
+
+// sanitizeLabel converts a name into a valid Terraform identifier, ie:
+// "base/web servers" becomes "base_web_servers"
+func sanitizeLabel(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, c := range strings.ToLower(name) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '-' {
+			b.WriteRune(c)
+			underscore = false
+		} else if !underscore {
+			b.WriteRune('_')
+			underscore = true
+		}
+	}
+	return strings.Trim(b.String(), "_-")
+}
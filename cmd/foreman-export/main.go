@@ -0,0 +1,154 @@
+// Package main contains the main goroutine for the foreman-export
+// command-line application.  This application reads the objects of a live
+// Foreman installation with the resources of the Terraform provider and
+// writes Terraform configuration for them, including import blocks.  IDs of
+// other exported objects are replaced with references between the generated
+// resources, so an existing installation can be adopted in one step.
+//
+// Usage:
+//
+//	$> export FOREMAN_CLIENT_USERNAME='admin'
+//	$> export FOREMAN_CLIENT_PASSWORD='changeme'
+//	$> foreman-export -server-hostname foreman.example.com -out ./foreman
+//	$> cd ./foreman && terraform plan
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	logger "github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+const (
+	// ExitSuccess is returned if all objects were exported
+	ExitSuccess = 0
+	// ExitError is returned if the export failed
+	ExitError = 1
+	// ExitUsage is returned for invalid command-line arguments
+	ExitUsage = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run parses the command-line arguments, exports the selected object types
+// and writes the generated files.  Returns the exit code of the application.
+func run(args []string) int {
+	flags := flag.NewFlagSet("foreman-export", flag.ContinueOnError)
+
+	hostname := flags.String("server-hostname", "", "Hostname of the Foreman server")
+	protocol := flags.String("server-protocol", "https", "Protocol used to communicate with the Foreman server")
+	tlsInsecure := flags.Bool("client-tls-insecure", false, "Skip the verification of the server's certificate")
+	negotiate := flags.Bool("client-auth-negotiate", false, "Authenticate through the HTTP negotiate mechanism")
+	username := flags.String("client-username", os.Getenv("FOREMAN_CLIENT_USERNAME"), "Username, defaults to $FOREMAN_CLIENT_USERNAME. The password is read from $FOREMAN_CLIENT_PASSWORD")
+	organizationID := flags.Int("organization-id", 0, "Organization used for all API calls")
+	locationID := flags.Int("location-id", 0, "Location used for all API calls")
+	types := flags.String("types", "", "Comma separated list of object types to export, ie: domain,subnet,hostgroup. Defaults to all types except users and hosts")
+	outDir := flags.String("out", ".", "Directory the generated files are written to")
+	logLevel := flags.String("loglevel", "NONE", "Log level of the provider's log output on stderr")
+
+	if err := flags.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if *hostname == "" {
+		fmt.Fprintln(os.Stderr, "The -server-hostname argument is required")
+		flags.Usage()
+		return ExitUsage
+	}
+
+	selected, selectErr := selectExportTypes(*types)
+	if selectErr != nil {
+		fmt.Fprintln(os.Stderr, selectErr)
+		return ExitUsage
+	}
+
+	level, levelErr := logger.LogLevelFromString(*logLevel)
+	if levelErr != nil {
+		fmt.Fprintln(os.Stderr, levelErr)
+		return ExitUsage
+	}
+	foreman.InitLogger(foreman.LoggingConfig{
+		LogLevel: level,
+		LogFile:  foreman.LogFileStdLog,
+	})
+
+	config := foreman.Config{
+		Server: api.Server{
+			URL: url.URL{
+				Scheme: *protocol,
+				Host:   *hostname,
+			},
+		},
+		ClientTLSInsecure:    *tlsInsecure,
+		NegotiateAuthEnabled: *negotiate,
+		ClientCredentials: api.ClientCredentials{
+			Username: *username,
+			Password: os.Getenv("FOREMAN_CLIENT_PASSWORD"),
+		},
+		LocationID:     *locationID,
+		OrganizationID: *organizationID,
+	}
+	client, diags := config.Client()
+	if diags.HasError() {
+		fmt.Fprintln(os.Stderr, diags[0].Summary)
+		return ExitError
+	}
+
+	files, exportErr := export(context.Background(), client, selected)
+	if exportErr != nil {
+		fmt.Fprintln(os.Stderr, exportErr)
+		return ExitError
+	}
+
+	if err := writeFiles(*outDir, files); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return ExitError
+	}
+
+	return ExitSuccess
+}
+
+// export reads all objects of the selected types and returns the generated
+// configuration by file name
+func export(ctx context.Context, client *api.Client, types []exportType) (map[string][]byte, error) {
+	resources := foreman.Provider().ResourcesMap
+
+	objects, err := exportObjects(ctx, client, resources, types)
+	if err != nil {
+		return nil, err
+	}
+
+	return newExportRenderer(resources, objects).render(objects), nil
+}
+
+// writeFiles writes the generated files to the output directory
+func writeFiles(outDir string, files map[string][]byte) error {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(outDir, name)
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s (%d objects)\n", path, strings.Count(string(files[name]), "\nimport {"))
+	}
+	return nil
+}
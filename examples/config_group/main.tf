@@ -0,0 +1,50 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_puppetclass" "apache" {
+  name = "apache"
+}
+
+data "foreman_puppetclass" "apache_ssl" {
+  name = "apache::mod::ssl"
+}
+
+resource "foreman_config_group" "webserver" {
+  name = "webserver"
+  puppetclass_ids = [
+    data.foreman_puppetclass.apache.id,
+    data.foreman_puppetclass.apache_ssl.id,
+  ]
+}
+
+# The parameter is imported with the puppet class, the resource manages its
+# settings.  Destroying the resource disables the override.
+resource "foreman_smartclassparameter" "default_mods" {
+  parameter      = "default_mods"
+  puppetclass_id = data.foreman_puppetclass.apache.id
+
+  parameter_type = "array"
+  default_value  = jsonencode(["ssl", "rewrite"])
+
+  validator_type = "list"
+  validator_rule = "ssl, rewrite, headers"
+
+  merge_overrides  = true
+  merge_default    = true
+  avoid_duplicates = true
+}
+
+resource "foreman_hostgroup" "web" {
+  name             = "web"
+  config_group_ids = [foreman_config_group.webserver.id]
+}
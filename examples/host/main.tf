@@ -39,6 +39,18 @@ data "foreman_operatingsystem" "Centos74" {
   title = "CentOS 7.4"
 }
 
+data "foreman_katello_content_view" "rhel7" {
+  name = "RHEL7"
+}
+
+data "foreman_katello_lifecycle_environment" "production" {
+  name = "Production"
+}
+
+data "foreman_smartproxy" "capsule" {
+  name = "capsule01.dev.company.com"
+}
+
 data "foreman_subnet" "app1" {
   name    = "10.228.170.0 app1"
   network = "10.228.170.0"
@@ -97,4 +109,17 @@ EOF
       network = "AppSubnet"
     }
   }
+
+  // Katello content and subscription settings, overriding the hostgroup
+  content_facet_attributes {
+    content_view_id          = data.foreman_katello_content_view.rhel7.id
+    lifecycle_environment_id = data.foreman_katello_lifecycle_environment.production.id
+    content_source_id        = data.foreman_smartproxy.capsule.id
+  }
+
+  subscription_facet_attributes {
+    release_version = "7Server"
+    service_level   = "Premium"
+    purpose_usage   = "Production"
+  }
 }
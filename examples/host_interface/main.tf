@@ -0,0 +1,48 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_host" "db" {
+  name = "db01.example.com"
+}
+
+resource "foreman_host_interface" "bond0" {
+  host_id    = data.foreman_host.db.id
+  type       = "bond"
+  identifier = "bond0"
+
+  mode             = "802.3ad"
+  attached_devices = ["eth1", "eth2"]
+  bond_options     = "miimon=100 lacp_rate=fast"
+  mtu              = 9000
+}
+
+resource "foreman_host_interface" "storage" {
+  host_id     = data.foreman_host.db.id
+  identifier  = "bond0.200"
+  virtual     = true
+  tag         = "200"
+  attached_to = foreman_host_interface.bond0.identifier
+  ip          = "10.0.200.21"
+  execution   = false
+}
+
+resource "foreman_host_interface" "ipmi" {
+  host_id      = data.foreman_host.db.id
+  type         = "bmc"
+  identifier   = "ipmi"
+  mac          = "52:54:00:aa:bb:cc"
+  ip           = "10.0.100.21"
+  bmc_provider = "IPMI"
+  username     = "admin"
+  password     = "changeme"
+}
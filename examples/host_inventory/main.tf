@@ -0,0 +1,31 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_host_status" "db" {
+  fqdn = "db01.dev.company.com"
+}
+
+data "foreman_host_facts" "db" {
+  id          = data.foreman_host_status.db.id
+  name_filter = "os::"
+}
+
+# Only hand the host to the application module once Foreman reports it as
+# healthy
+module "app" {
+  source = "./app"
+  count  = data.foreman_host_status.db.healthy ? 1 : 0
+
+  db_host       = "db01.dev.company.com"
+  db_os_release = data.foreman_host_facts.db.facts["os::release::major"]
+}
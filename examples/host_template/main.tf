@@ -0,0 +1,27 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_templatekind" "provision" {
+  name = "provision"
+}
+
+# Inspect the kickstart before rebuilding the host, ie: with
+# `terraform console` and `data.foreman_host_template.kickstart.rendered`
+data "foreman_host_template" "kickstart" {
+  fqdn          = "db01.dev.company.com"
+  template_kind = data.foreman_templatekind.provision.name
+}
+
+output "kickstart" {
+  value = data.foreman_host_template.kickstart.rendered
+}
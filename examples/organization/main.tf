@@ -0,0 +1,47 @@
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_domain" "example" {
+  name = "example.com"
+}
+
+resource "foreman_organization" "acme" {
+  name        = "ACME"
+  description = "ACME Corporation"
+
+  parameters = {
+    cost_center = "4711"
+  }
+}
+
+resource "foreman_location" "europe" {
+  name             = "Europe"
+  organization_ids = [foreman_organization.acme.id]
+}
+
+# Nested location with the title "Europe/Berlin"
+resource "foreman_location" "berlin" {
+  name      = "Berlin"
+  parent_id = foreman_location.europe.id
+
+  organization_ids = [foreman_organization.acme.id]
+  domain_ids       = [data.foreman_domain.example.id]
+
+  # all provisioning templates and partition tables are available in Berlin
+  ignore_types = ["ProvisioningTemplate", "Ptable"]
+
+  parameters = {
+    ntp_server = "ntp.ber.example.com"
+  }
+}
+
+data "foreman_location" "berlin" {
+  title = foreman_location.berlin.title
+}
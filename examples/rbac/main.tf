@@ -0,0 +1,71 @@
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_role" "viewer" {
+  name = "Viewer"
+}
+
+data "foreman_permission" "power_hosts" {
+  name = "power_hosts"
+}
+
+data "foreman_permission" "console_hosts" {
+  name = "console_hosts"
+}
+
+# Read access to everything, plus power and console access to the web hosts
+resource "foreman_role" "web_operator" {
+  name           = "Web host operator"
+  description    = "Operates the hosts of the web tier"
+  cloned_from_id = data.foreman_role.viewer.id
+}
+
+resource "foreman_filter" "web_hosts" {
+  role_id = foreman_role.web_operator.id
+  permission_ids = [
+    data.foreman_permission.power_hosts.id,
+    data.foreman_permission.console_hosts.id,
+  ]
+  search = "hostgroup_title ~ web/*"
+}
+
+
+# Members of the AD group "web-admins" get the role on login
+resource "foreman_auth_source_ldap" "corp_ad" {
+  name        = "corp-ad"
+  host        = "ad.example.com"
+  port        = 636
+  tls         = true
+  server_type = "active_directory"
+
+  base_dn     = "OU=People,DC=example,DC=com"
+  groups_base = "OU=Groups,DC=example,DC=com"
+
+  account          = "CN=svc-foreman,OU=Services,DC=example,DC=com"
+  account_password = var.ldap_bind_password
+
+  attr_login     = "sAMAccountName"
+  attr_firstname = "givenName"
+  attr_lastname  = "sn"
+  attr_mail      = "mail"
+
+  onthefly_register = true
+  usergroup_sync    = true
+}
+
+resource "foreman_usergroup" "web_operators" {
+  name     = "web-operators"
+  role_ids = [foreman_role.web_operator.id]
+
+  external_usergroups {
+    name           = "web-admins"
+    auth_source_id = foreman_auth_source_ldap.corp_ad.id
+  }
+}
@@ -0,0 +1,36 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_smartproxy" "ipa" {
+  name = "ipa01.example.com"
+}
+
+# The realm proxy enrols the hosts of the realm in FreeIPA
+resource "foreman_realm" "example" {
+  name           = "EXAMPLE.COM"
+  realm_type     = "FreeIPA"
+  realm_proxy_id = data.foreman_smartproxy.ipa.id
+}
+
+# Hosts of the hostgroup inherit the realm
+resource "foreman_hostgroup" "ipa_clients" {
+  name     = "ipa-clients"
+  realm_id = foreman_realm.example.id
+}
+
+# The realm can also be set on a single host
+resource "foreman_host" "web01" {
+  shortname    = "web01"
+  hostgroup_id = foreman_hostgroup.ipa_clients.id
+  realm_id     = foreman_realm.example.id
+}
@@ -0,0 +1,36 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_hostgroup" "web" {
+  title = "Web Servers"
+}
+
+# Registers the machine as host in Foreman on its first boot.  The token of
+# the command expires after 2 hours.
+data "foreman_registration_command" "web" {
+  hostgroup_id    = data.foreman_hostgroup.web.id
+  activation_keys = ["rhel9-web"]
+  jwt_expiration  = 2
+  update_packages = true
+
+  setup_remote_execution = true
+}
+
+output "user_data" {
+  sensitive = true
+  value     = <<-EOT
+    #cloud-config
+    runcmd:
+      - ${jsonencode(data.foreman_registration_command.web.command)}
+  EOT
+}
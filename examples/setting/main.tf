@@ -11,7 +11,7 @@ provider "foreman" {
   client_password = "${var.client_password}"
 }
 
-# Read the data resource (it's read-only at the moment)
+# Read a setting with the data source
 data "foreman_setting" "append_domain" {
     name = "append_domain_name_for_hosts"
 }
@@ -25,7 +25,7 @@ output "setting_append_domain" {
 # setting_append_domain = {
 #   __meta__      = null
 #   category_name = "General"
-#   default       = null
+#   default       = "true"
 #   description   = "Foreman will append domain names when new hosts are provisioned"
 #   id            = "append_domain_name_for_hosts"
 #   name          = "append_domain_name_for_hosts"
@@ -33,3 +33,24 @@ output "setting_append_domain" {
 #   settings_type = "boolean"
 #   value         = "true"
 # }
+
+# Manage settings with the resource, the value is converted to the type of the
+# setting.  The default value is restored when the resource is destroyed.
+resource "foreman_setting" "append_domain" {
+  name  = "append_domain_name_for_hosts"
+  value = "false"
+}
+
+resource "foreman_setting" "token_duration" {
+  name  = "token_duration"
+  value = "60"
+
+  # Keep the value when the resource is removed
+  restore_default_on_destroy = false
+}
+
+# Array and hash settings are written in JSON notation
+resource "foreman_setting" "trusted_hosts" {
+  name  = "trusted_hosts"
+  value = jsonencode(["proxy01.example.com", "proxy02.example.com"])
+}
@@ -213,8 +213,7 @@ func (c *Client) QueryArchitecture(ctx context.Context, a *ForemanArchitecture)
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + a.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", a.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// AuthSourceLdapEndpointPrefix : Prefix appended to API url for LDAP
+	// authentication sources
+	AuthSourceLdapEndpointPrefix = "auth_source_ldaps"
+)
+
+// AuthSourceLdapServerTypes are the supported types of LDAP servers
+var AuthSourceLdapServerTypes = []string{
+	"posix",
+	"free_ipa",
+	"active_directory",
+}
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanAuthSourceLdap API model represents an LDAP authentication
+// source.  Users are authenticated against the LDAP server and optionally
+// created on their first login.
+type ForemanAuthSourceLdap struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Hostname of the LDAP server
+	Host string `json:"host"`
+	// Port of the LDAP server, usually 389 or 636 with TLS
+	Port int `json:"port"`
+	// Whether the connection is encrypted with TLS
+	Tls bool `json:"tls"`
+	// Type of the LDAP server, one of AuthSourceLdapServerTypes
+	ServerType string `json:"server_type"`
+	// Base DN of the users
+	BaseDn string `json:"base_dn"`
+	// Base DN of the groups, used for the usergroup sync
+	GroupsBase string `json:"groups_base"`
+	// LDAP filter limiting the users which can log in
+	LdapFilter string `json:"ldap_filter"`
+	// Whether the groups are netgroups instead of POSIX groups
+	UseNetgroups bool `json:"use_netgroups"`
+
+	// Account used to bind to the LDAP server.  "$login" is replaced with
+	// the login of the user.
+	Account string `json:"account"`
+	// Password of the bind account.  Foreman does not return it.
+	AccountPassword string `json:"account_password,omitempty"`
+
+	// LDAP attributes the user attributes are read from
+	AttrLogin     string `json:"attr_login"`
+	AttrFirstname string `json:"attr_firstname"`
+	AttrLastname  string `json:"attr_lastname"`
+	AttrMail      string `json:"attr_mail"`
+	AttrPhoto     string `json:"attr_photo"`
+
+	// Whether users are created on their first login
+	OntheflyRegister bool `json:"onthefly_register"`
+	// Whether the members of external usergroups are synced on login
+	UsergroupSync bool `json:"usergroup_sync"`
+
+	// IDs of the locations and organizations of the auth source.  Nil
+	// leaves them untouched, an empty list removes all of them.
+	LocationIds     []int `json:"location_ids"`
+	OrganizationIds []int `json:"organization_ids"`
+}
+
+// Implement the Marshaler interface
+func (a ForemanAuthSourceLdap) MarshalJSON() ([]byte, error) {
+	// NOTE(ALL): the alias type has no methods, which prevents the recursion
+	//   into this function.  The taxonomy lists shadow the ones of the alias
+	//   type to omit nil lists, but send empty ones.
+	type foremanAuthSourceLdap ForemanAuthSourceLdap
+	aJSON := struct {
+		foremanAuthSourceLdap
+		LocationIds     *[]int `json:"location_ids,omitempty"`
+		OrganizationIds *[]int `json:"organization_ids,omitempty"`
+	}{
+		foremanAuthSourceLdap: foremanAuthSourceLdap(a),
+	}
+	if a.LocationIds != nil {
+		aJSON.LocationIds = &a.LocationIds
+	}
+	if a.OrganizationIds != nil {
+		aJSON.OrganizationIds = &a.OrganizationIds
+	}
+	return json.Marshal(aJSON)
+}
+
+// Implement the Unmarshaler interface
+func (a *ForemanAuthSourceLdap) UnmarshalJSON(b []byte) error {
+	// NOTE(ALL): the alias type has no methods, which prevents the recursion
+	//   into this function
+	type foremanAuthSourceLdap ForemanAuthSourceLdap
+	var aJSON struct {
+		foremanAuthSourceLdap
+		Locations     []ForemanObject `json:"locations"`
+		Organizations []ForemanObject `json:"organizations"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &aJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*a = ForemanAuthSourceLdap(aJSON.foremanAuthSourceLdap)
+
+	// Foreman returns the taxonomies as nested objects
+	a.LocationIds = foremanObjectArrayToIdIntArray(aJSON.Locations)
+	a.OrganizationIds = foremanObjectArrayToIdIntArray(aJSON.Organizations)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateAuthSourceLdap creates a new ForemanAuthSourceLdap with the
+// attributes of the supplied ForemanAuthSourceLdap reference and returns the
+// created ForemanAuthSourceLdap reference.  The returned reference will have
+// its ID and other API default values set by this function.
+func (c *Client) CreateAuthSourceLdap(ctx context.Context, a *ForemanAuthSourceLdap) (*ForemanAuthSourceLdap, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", AuthSourceLdapEndpointPrefix)
+
+	aJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("auth_source_ldap", a)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("authSourceLdapJSONBytes: [%s]", aJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(aJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdAuthSourceLdap ForemanAuthSourceLdap
+	sendErr := c.SendAndParse(req, &createdAuthSourceLdap)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdAuthSourceLdap: [%+v]", createdAuthSourceLdap)
+
+	return &createdAuthSourceLdap, nil
+}
+
+// ReadAuthSourceLdap reads the attributes of a ForemanAuthSourceLdap
+// identified by the supplied ID and returns a ForemanAuthSourceLdap
+// reference.
+func (c *Client) ReadAuthSourceLdap(ctx context.Context, id int) (*ForemanAuthSourceLdap, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLdapEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readAuthSourceLdap ForemanAuthSourceLdap
+	sendErr := c.SendAndParse(req, &readAuthSourceLdap)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readAuthSourceLdap: [%+v]", readAuthSourceLdap)
+
+	return &readAuthSourceLdap, nil
+}
+
+// UpdateAuthSourceLdap updates a ForemanAuthSourceLdap's attributes.  The
+// auth source with the ID of the supplied ForemanAuthSourceLdap will be
+// updated. A new ForemanAuthSourceLdap reference is returned with the
+// attributes from the result of the update operation.
+func (c *Client) UpdateAuthSourceLdap(ctx context.Context, a *ForemanAuthSourceLdap) (*ForemanAuthSourceLdap, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLdapEndpointPrefix, a.Id)
+
+	aJSONBytes, jsonEncErr := c.WrapJSON("auth_source_ldap", a)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("authSourceLdapJSONBytes: [%s]", aJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(aJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedAuthSourceLdap ForemanAuthSourceLdap
+	sendErr := c.SendAndParse(req, &updatedAuthSourceLdap)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedAuthSourceLdap: [%+v]", updatedAuthSourceLdap)
+
+	return &updatedAuthSourceLdap, nil
+}
+
+// DeleteAuthSourceLdap deletes the ForemanAuthSourceLdap identified by the
+// supplied ID
+func (c *Client) DeleteAuthSourceLdap(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/auth_source_ldap.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLdapEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryAuthSourceLdap queries for a ForemanAuthSourceLdap based on the name
+// of the supplied ForemanAuthSourceLdap reference and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// auth sources.
+func (c *Client) QueryAuthSourceLdap(ctx context.Context, a *ForemanAuthSourceLdap) (QueryResponse, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Search")
+
+	return SearchAll[ForemanAuthSourceLdap](ctx, c, AuthSourceLdapEndpointPrefix, SearchBy("name", a.Name), nil)
+}
@@ -0,0 +1,494 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// RecordDirEnvVar names the environment variable enabling the record
+	// mode of the client.  If set, every request and response is written as
+	// a cassette to the directory.
+	RecordDirEnvVar = "FOREMAN_RECORD_DIR"
+
+	// FixtureDirEnvVar names the environment variable enabling the fixture
+	// mode of the client.  If set to a version directory of the test data,
+	// ie: "foreman/testdata/3.11", every response is also written in the
+	// layout of the fixtures, ie: "domains/read_response.json".
+	FixtureDirEnvVar = "FOREMAN_FIXTURE_DIR"
+
+	// ScrubbedValue replaces credentials, tokens and passwords in cassettes
+	ScrubbedValue = "**SCRUBBED**"
+)
+
+// sensitiveKeyPattern matches the names of JSON attributes and URL parameters
+// holding secrets, ie: "password", "root_pass" or "api_token"
+var sensitiveKeyPattern = regexp.MustCompile(`(?i)(passw|root_pass|passphrase|secret|token|private_key|access_key|api_key|credential$)`)
+
+// sensitiveHeaders are not written to cassettes
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization"}
+
+// ----------------------------------------------------------------------------
+// Cassettes
+// ----------------------------------------------------------------------------
+
+// Cassette is a recorded request/response pair.  Cassettes are stored as one
+// JSON file each, named after the order of the requests, the method and the
+// path, ie: "0003_GET_api_domains_1.json".
+type Cassette struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is the recorded request of a cassette
+type CassetteRequest struct {
+	Method string `json:"method"`
+	// Path of the URL, ie: "/api/domains/1"
+	Path string `json:"path"`
+	// Encoded query of the URL with sorted parameters
+	Query string `json:"query,omitempty"`
+	// JSON body of the request
+	Body json.RawMessage `json:"body,omitempty"`
+	// Body of the request if it is not JSON
+	RawBody string `json:"raw_body,omitempty"`
+}
+
+// CassetteResponse is the recorded response of a cassette
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	// JSON body of the response
+	Body json.RawMessage `json:"body,omitempty"`
+	// Body of the response if it is not JSON
+	RawBody string `json:"raw_body,omitempty"`
+}
+
+// LoadCassettes reads all cassettes of a directory in the order they were
+// recorded
+func LoadCassettes(dir string) ([]Cassette, error) {
+	paths, globErr := filepath.Glob(filepath.Join(dir, "*.json"))
+	if globErr != nil {
+		return nil, globErr
+	}
+	sort.Strings(paths)
+
+	cassettes := make([]Cassette, 0, len(paths))
+	for _, path := range paths {
+		data, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return nil, readErr
+		}
+		var cassette Cassette
+		if jsonDecErr := json.Unmarshal(data, &cassette); jsonDecErr != nil {
+			return nil, fmt.Errorf("Invalid cassette [%s]: %w", path, jsonDecErr)
+		}
+		cassettes = append(cassettes, cassette)
+	}
+	return cassettes, nil
+}
+
+// ----------------------------------------------------------------------------
+// Recording
+// ----------------------------------------------------------------------------
+
+// recordingTransport sends requests through the next transport and writes
+// every request/response pair as a scrubbed cassette
+type recordingTransport struct {
+	next http.RoundTripper
+	// Directory of the cassettes, cassettes are not written if empty
+	dir string
+	// Version directory of the fixtures, fixtures are not written if empty
+	fixtureDir string
+	// Literal secrets (ie: the client's password) replaced anywhere in the
+	// recorded bodies
+	secrets []string
+
+	mu    sync.Mutex
+	count int
+}
+
+// newRecordingTransport returns a transport recording cassettes to dir and
+// fixtures to fixtureDir, either of them may be empty.  The cassette
+// directory is created if it does not exist.
+func newRecordingTransport(next http.RoundTripper, dir string, fixtureDir string, secrets ...string) (*recordingTransport, error) {
+	var existing []string
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		existing, _ = filepath.Glob(filepath.Join(dir, "*.json"))
+	}
+
+	nonEmpty := []string{}
+	for _, secret := range secrets {
+		if secret != "" {
+			nonEmpty = append(nonEmpty, secret)
+		}
+	}
+	return &recordingTransport{
+		next:       next,
+		dir:        dir,
+		fixtureDir: fixtureDir,
+		secrets:    nonEmpty,
+		count:      len(existing),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var readErr error
+		reqBody, readErr = io.ReadAll(req.Body)
+		req.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	resp, respErr := t.next.RoundTrip(req)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	respBody, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		return nil, readErr
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	cassette := Cassette{
+		Request: CassetteRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  scrubQuery(req.URL.Query()),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+		},
+	}
+	cassette.Request.Body, cassette.Request.RawBody = t.scrubBody(reqBody)
+	cassette.Response.Body, cassette.Response.RawBody = t.scrubBody(respBody)
+
+	// a failed recording must not fail the API call
+	if t.dir != "" {
+		if writeErr := t.write(cassette); writeErr != nil {
+			log.Errorf("Failed to record cassette for [%s %s]: %s", req.Method, req.URL.Path, writeErr)
+		}
+	}
+	if t.fixtureDir != "" {
+		if _, writeErr := WriteFixture(t.fixtureDir, cassette); writeErr != nil {
+			log.Errorf("Failed to write fixture for [%s %s]: %s", req.Method, req.URL.Path, writeErr)
+		}
+	}
+	return resp, nil
+}
+
+// write stores a cassette as the next file of the directory
+func (t *recordingTransport) write(cassette Cassette) error {
+	data, jsonEncErr := json.MarshalIndent(cassette, "", "  ")
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+
+	t.mu.Lock()
+	t.count++
+	name := fmt.Sprintf("%04d_%s_%s.json", t.count, cassette.Request.Method, cassetteName(cassette.Request.Path))
+	t.mu.Unlock()
+
+	return os.WriteFile(filepath.Join(t.dir, name), append(data, '\n'), 0644)
+}
+
+// scrubBody scrubs a request or response body.  JSON bodies are returned as
+// JSON with the values of sensitive attributes replaced, other bodies as
+// string.
+func (t *recordingTransport) scrubBody(body []byte) (json.RawMessage, string) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, ""
+	}
+	for _, secret := range t.secrets {
+		body = bytes.ReplaceAll(body, []byte(secret), []byte(ScrubbedValue))
+	}
+
+	var value interface{}
+	if json.Unmarshal(body, &value) != nil {
+		return nil, string(body)
+	}
+	scrubbed, _ := json.Marshal(scrubValue(value))
+	return scrubbed, ""
+}
+
+// scrubValue replaces the values of sensitive attributes in decoded JSON
+func scrubValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if _, isString := item.(string); isString && sensitiveKeyPattern.MatchString(key) {
+				v[key] = ScrubbedValue
+				continue
+			}
+			v[key] = scrubValue(item)
+		}
+	case []interface{}:
+		for idx, item := range v {
+			v[idx] = scrubValue(item)
+		}
+	}
+	return value
+}
+
+// scrubQuery encodes URL parameters with the sensitive values replaced
+func scrubQuery(query url.Values) string {
+	for key := range query {
+		if sensitiveKeyPattern.MatchString(key) {
+			query.Set(key, ScrubbedValue)
+		}
+	}
+	return query.Encode()
+}
+
+// scrubHeader returns the response headers without authentication headers
+func scrubHeader(header http.Header) http.Header {
+	scrubbed := header.Clone()
+	for _, name := range sensitiveHeaders {
+		scrubbed.Del(name)
+	}
+	// the date changes with every recording and is not used by the client
+	scrubbed.Del("Date")
+	return scrubbed
+}
+
+// cassetteName converts a URL path into a file name component, ie:
+// "/api/domains/1" becomes "api_domains_1"
+func cassetteName(path string) string {
+	name := strings.Trim(regexp.MustCompile(`[^A-Za-z0-9]+`).ReplaceAllString(path, "_"), "_")
+	if len(name) > 100 {
+		name = name[:100]
+	}
+	return name
+}
+
+// ----------------------------------------------------------------------------
+// Fixtures
+// ----------------------------------------------------------------------------
+
+// fixtureAPIPrefixes are the URL prefixes of the APIs stripped from the
+// paths of fixtures, longest first
+var fixtureAPIPrefixes = []string{
+	FOREMAN_KATELLO_API_URL_PREFIX + "/v2",
+	FOREMAN_KATELLO_API_URL_PREFIX,
+	FOREMAN_PUPPET_API_URL_PREFIX + "/v2",
+	FOREMAN_PUPPET_API_URL_PREFIX,
+	FOREMAN_TASKS_API_URL_PREFIX,
+	FOREMAN_API_URL_PREFIX + "/v2",
+	FOREMAN_API_URL_PREFIX,
+}
+
+// FixturePath returns the path of the fixture a cassette's response is
+// stored as, relative to the version directory of the test data.  Returns
+// false for cassettes without a successful JSON response and for requests
+// the fixtures do not cover, ie: deletions.
+//
+//	GET    /api/domains?search=...  domains/query_response_{zero,single,multi}.json
+//	POST   /api/domains             domains/create_response.json
+//	GET    /api/domains/1           domains/read_response.json
+//	PUT    /api/domains/1           domains/update_response.json
+//	GET    /api/hosts/1/facts       hosts/facts_response.json
+func FixturePath(c Cassette) (string, bool) {
+	if c.Response.StatusCode < 200 || c.Response.StatusCode > 299 || len(c.Response.Body) == 0 {
+		return "", false
+	}
+
+	path := c.Request.Path
+	for _, prefix := range fixtureAPIPrefixes {
+		if strings.HasPrefix(path, prefix+"/") {
+			path = strings.TrimPrefix(path, prefix)
+			break
+		}
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	last := len(segments) - 1
+	if segments[last] == "" {
+		return "", false
+	}
+	isId := func(segment string) bool {
+		_, err := strconv.Atoi(segment)
+		return err == nil
+	}
+
+	// a single object, ie: /api/domains/1
+	if isId(segments[last]) {
+		if last < 1 {
+			return "", false
+		}
+		switch c.Request.Method {
+		case http.MethodGet:
+			return filepath.Join(segments[last-1], "read_response.json"), true
+		case http.MethodPut:
+			return filepath.Join(segments[last-1], "update_response.json"), true
+		}
+		return "", false
+	}
+
+	var index struct {
+		Subtotal int               `json:"subtotal"`
+		Results  []json.RawMessage `json:"results"`
+	}
+	isIndex := json.Unmarshal(c.Response.Body, &index) == nil && index.Results != nil
+
+	switch {
+	case c.Request.Method == http.MethodGet && isIndex:
+		// a collection, ie: /api/domains or /api/hosts/1/interfaces
+		kind := "multi"
+		if index.Subtotal == 0 {
+			kind = "zero"
+		} else if index.Subtotal == 1 {
+			kind = "single"
+		}
+		return filepath.Join(segments[last], fmt.Sprintf("query_response_%s.json", kind)), true
+	case c.Request.Method == http.MethodPost && !(last > 0 && isId(segments[last-1])):
+		return filepath.Join(segments[last], "create_response.json"), true
+	case c.Request.Method == http.MethodGet && last > 1 && isId(segments[last-1]):
+		// an action on an object, ie: /api/hosts/1/facts
+		return filepath.Join(segments[last-2], segments[last]+"_response.json"), true
+	}
+	return "", false
+}
+
+// WriteFixture writes the response of a cassette to its fixture below the
+// version directory of the test data and returns the path of the fixture.
+// An existing fixture is replaced, so the last response of each kind is
+// kept.  Cassettes without fixture are skipped with an empty path.
+func WriteFixture(dir string, c Cassette) (string, error) {
+	name, ok := FixturePath(c)
+	if !ok {
+		return "", nil
+	}
+
+	var body bytes.Buffer
+	if err := json.Indent(&body, c.Response.Body, "", "  "); err != nil {
+		return "", err
+	}
+	body.WriteByte('\n')
+
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, body.Bytes(), 0644)
+}
+
+// WriteFixtures writes the responses of recorded cassettes to their fixtures
+// below the version directory of the test data, ie: to convert the
+// cassettes of an earlier recording.  Returns the paths of the written
+// fixtures.
+func WriteFixtures(dir string, cassettes []Cassette) ([]string, error) {
+	written := []string{}
+	for _, cassette := range cassettes {
+		path, err := WriteFixture(dir, cassette)
+		if err != nil {
+			return written, err
+		}
+		if path != "" {
+			written = append(written, path)
+		}
+	}
+	return written, nil
+}
+
+// ----------------------------------------------------------------------------
+// Replay
+// ----------------------------------------------------------------------------
+
+// ReplayTransport answers requests with recorded cassettes instead of
+// contacting a server.  It can be set as ClientConfig.Transport in tests.
+//
+// Requests are matched by method, path and query.  Cassettes matching the
+// same request are replayed in the order they were recorded, the last one is
+// repeated once all of them were used.  Requests without a cassette fail.
+type ReplayTransport struct {
+	mu        sync.Mutex
+	cassettes []Cassette
+	used      []bool
+}
+
+// NewReplayTransport returns a transport replaying the cassettes of a
+// directory
+func NewReplayTransport(dir string) (*ReplayTransport, error) {
+	cassettes, err := LoadCassettes(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(cassettes) == 0 {
+		return nil, fmt.Errorf("No cassettes found in [%s]", dir)
+	}
+	return &ReplayTransport{
+		cassettes: cassettes,
+		used:      make([]bool, len(cassettes)),
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	query := scrubQuery(req.URL.Query())
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	last := -1
+	for idx, cassette := range t.cassettes {
+		if cassette.Request.Method != req.Method || cassette.Request.Path != req.URL.Path ||
+			cassette.Request.Query != query {
+			continue
+		}
+		last = idx
+		if !t.used[idx] {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("No cassette recorded for [%s %s?%s]", req.Method, req.URL.Path, query)
+	}
+	t.used[last] = true
+
+	recorded := t.cassettes[last].Response
+	body := []byte(recorded.RawBody)
+	if len(recorded.Body) > 0 {
+		body = recorded.Body
+	}
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
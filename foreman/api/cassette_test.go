@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Scrubbing
+// ----------------------------------------------------------------------------
+
+// Ensures the values of sensitive attributes are replaced at every level of
+// a JSON document
+func TestScrubValue(t *testing.T) {
+	var value interface{}
+	json.Unmarshal([]byte(`{
+		"host": {
+			"name": "host1",
+			"root_pass": "hunter2",
+			"interfaces_attributes": [{"password": "bmcpass", "username": "admin"}]
+		},
+		"api_token": "abc",
+		"password_changed": true
+	}`), &value)
+
+	scrubbed, _ := json.Marshal(scrubValue(value))
+	expected := `{"api_token":"**SCRUBBED**","host":{"interfaces_attributes":[{"password":"**SCRUBBED**","username":"admin"}],"name":"host1","root_pass":"**SCRUBBED**"},"password_changed":true}`
+	if string(scrubbed) != expected {
+		t.Fatalf("scrubValue did not return correct value. Expected [%s], got [%s]", expected, scrubbed)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Record / Replay
+// ----------------------------------------------------------------------------
+
+// Ensures requests are recorded with credentials scrubbed and replayed
+// without a server
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(RecordDirEnvVar, dir)
+
+	mux, server, client := NewForemanAPIAndClient(
+		ClientCredentials{Username: "admin", Password: "s3cr3t"},
+		ClientConfig{},
+	)
+	mux.HandleFunc("/api/users/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "login": "admin", "mail": "admin@example.com", "password": "s3cr3t"}`))
+	})
+	mux.HandleFunc("/api/domains", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": 2, "name": "example.com"}`))
+	})
+
+	ctx := context.TODO()
+	if _, err := client.ReadUser(ctx, 1); err != nil {
+		t.Fatalf("ReadUser returned error [%s]", err)
+	}
+	if _, err := client.CreateDomain(ctx, &ForemanDomain{ForemanObject: ForemanObject{Name: "example.com"}}); err != nil {
+		t.Fatalf("CreateDomain returned error [%s]", err)
+	}
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 2 || filepath.Base(files[0]) != "0001_GET_api_users_1.json" ||
+		filepath.Base(files[1]) != "0002_POST_api_domains.json" {
+		t.Fatalf("Expected one cassette per request, got [%v]", files)
+	}
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		if strings.Contains(string(data), "s3cr3t") || strings.Contains(string(data), "Authorization") {
+			t.Fatalf("Cassette [%s] contains credentials:\n%s", file, data)
+		}
+	}
+
+	// replay without recording and without the server
+	t.Setenv(RecordDirEnvVar, "")
+	replay, err := NewReplayTransport(dir)
+	if err != nil {
+		t.Fatalf("NewReplayTransport returned error [%s]", err)
+	}
+	serverURL, _ := url.Parse("http://foreman.invalid")
+	replayClient := NewClient(Server{URL: *serverURL}, ClientCredentials{}, ClientConfig{Transport: replay})
+
+	user, err := replayClient.ReadUser(ctx, 1)
+	if err != nil {
+		t.Fatalf("ReadUser returned error [%s] on replay", err)
+	}
+	if user.Login != "admin" || user.Mail != "admin@example.com" {
+		t.Fatalf("ReadUser did not return the recorded user, got [%+v]", user)
+	}
+	domain, err := replayClient.CreateDomain(ctx, &ForemanDomain{ForemanObject: ForemanObject{Name: "example.com"}})
+	if err != nil || domain.Id != 2 {
+		t.Fatalf("CreateDomain did not return the recorded domain, got [%+v] and error [%v]", domain, err)
+	}
+	if _, err := replayClient.ReadDomain(ctx, 2); err == nil {
+		t.Fatalf("ReadDomain did not return an error for a request without cassette")
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Fixtures
+// ----------------------------------------------------------------------------
+
+// Ensures cassettes are mapped to the fixture layout of the test data
+func TestFixturePath(t *testing.T) {
+	testCases := []struct {
+		Method   string
+		Path     string
+		Status   int
+		Body     string
+		Expected string
+	}{
+		{"GET", "/api/domains", 200, `{"subtotal": 0, "results": []}`, "domains/query_response_zero.json"},
+		{"GET", "/api/domains", 200, `{"subtotal": 1, "results": [{"id": 1}]}`, "domains/query_response_single.json"},
+		{"GET", "/api/v2/domains", 200, `{"subtotal": 2, "results": [{"id": 1}, {"id": 2}]}`, "domains/query_response_multi.json"},
+		{"POST", "/api/domains", 201, `{"id": 1}`, "domains/create_response.json"},
+		{"GET", "/api/domains/1", 200, `{"id": 1}`, "domains/read_response.json"},
+		{"PUT", "/api/domains/1", 200, `{"id": 1}`, "domains/update_response.json"},
+		{"GET", "/api/hosts/1/interfaces/2", 200, `{"id": 2}`, "interfaces/read_response.json"},
+		{"GET", "/api/hosts/1/facts", 200, `{"subtotal": 1, "results": {"host1": {}}}`, "hosts/facts_response.json"},
+		{"GET", "/katello/api/v2/products/3", 200, `{"id": 3}`, "products/read_response.json"},
+		{"GET", "/foreman_puppet/api/smart_class_parameters/4", 200, `{"id": 4}`, "smart_class_parameters/read_response.json"},
+		{"DELETE", "/api/domains/1", 200, `{"id": 1}`, ""},
+		{"GET", "/api/domains/1", 404, `{"error": {"message": "not found"}}`, ""},
+		{"PUT", "/api/hosts/1/power", 200, `{"power": true}`, ""},
+		{"POST", "/api/hosts/1/rebuild_config", 200, `{"result": {}}`, ""},
+	}
+
+	for _, testCase := range testCases {
+		path, ok := FixturePath(Cassette{
+			Request:  CassetteRequest{Method: testCase.Method, Path: testCase.Path},
+			Response: CassetteResponse{StatusCode: testCase.Status, Body: json.RawMessage(testCase.Body)},
+		})
+		if ok != (testCase.Expected != "") || path != filepath.FromSlash(testCase.Expected) {
+			t.Fatalf(
+				"FixturePath did not return correct value for [%s %s]. Expected [%s], got [%s]",
+				testCase.Method,
+				testCase.Path,
+				testCase.Expected,
+				path,
+			)
+		}
+	}
+}
+
+// Ensures the responses are written as scrubbed fixtures in fixture mode,
+// without cassettes, and recorded cassettes can be converted to fixtures
+func TestRecordFixtures(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(FixtureDirEnvVar, dir)
+
+	mux, server, client := NewForemanAPIAndClient(
+		ClientCredentials{Username: "admin", Password: "s3cr3t"},
+		ClientConfig{},
+	)
+	defer server.Close()
+	mux.HandleFunc("/api/users/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "login": "admin", "password": "s3cr3t"}`))
+	})
+
+	if _, err := client.ReadUser(context.TODO(), 1); err != nil {
+		t.Fatalf("ReadUser returned error [%s]", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	if len(files) != 1 || files[0] != filepath.Join(dir, "users", "read_response.json") {
+		t.Fatalf("Expected the read fixture of the user, got [%v]", files)
+	}
+	data, _ := os.ReadFile(files[0])
+	var user ForemanUser
+	if err := json.Unmarshal(data, &user); err != nil || user.Login != "admin" {
+		t.Fatalf("Fixture [%s] does not contain the user:\n%s", files[0], data)
+	}
+	if strings.Contains(string(data), "s3cr3t") {
+		t.Fatalf("Fixture [%s] contains credentials:\n%s", files[0], data)
+	}
+
+	converted := t.TempDir()
+	written, err := WriteFixtures(converted, []Cassette{{
+		Request:  CassetteRequest{Method: http.MethodPost, Path: "/api/domains"},
+		Response: CassetteResponse{StatusCode: http.StatusCreated, Body: json.RawMessage(`{"id": 2}`)},
+	}})
+	if err != nil || len(written) != 1 || written[0] != filepath.Join(converted, "domains", "create_response.json") {
+		t.Fatalf("WriteFixtures did not write the create fixture, got [%v] and error [%v]", written, err)
+	}
+}
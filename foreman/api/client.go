@@ -9,6 +9,9 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
@@ -68,6 +71,11 @@ type ClientConfig struct {
 	// Information as required by all API calls
 	LocationID     int
 	OrganizationID int
+
+	// Transport replaces the HTTP transport of the client, ie: with a
+	// ReplayTransport in tests.  If nil, the transport is set up from the
+	// TLS and negotiate settings.
+	Transport http.RoundTripper
 }
 
 type Client struct {
@@ -242,6 +250,24 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 		transCfg.TLSClientConfig = tlsClientConfig
 		cleanClient.Transport = transCfg
 	}
+	if cfg.Transport != nil {
+		cleanClient.Transport = cfg.Transport
+	}
+
+	// In record mode, every request and response is saved as a cassette with
+	// credentials and passwords scrubbed.  In fixture mode, the responses
+	// are saved as test fixtures.
+	recordDir := os.Getenv(RecordDirEnvVar)
+	fixtureDir := os.Getenv(FixtureDirEnvVar)
+	if recordDir != "" || fixtureDir != "" {
+		recorder, recErr := newRecordingTransport(cleanClient.Transport, recordDir, fixtureDir, c.Password)
+		if recErr != nil {
+			log.Errorf("Failed to enable record mode: %s", recErr)
+		} else {
+			log.Infof("Recording API requests to [%s] and fixtures to [%s]", recordDir, fixtureDir)
+			cleanClient.Transport = recorder
+		}
+	}
 
 	// Initialize and return the unauthenticated client.
 	client := Client{
@@ -480,9 +506,16 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 
 			case "Actions::Katello::ContentView::Publish":
 				// Used by endpoint POST /katello/api/content_views/:id/publish
-				output := finishedTask.Output.(map[string]interface{})
+				output, ok := finishedTask.Output.(map[string]interface{})
+				if !ok {
+					return fmt.Errorf("unexpected output of task [%s]: %v", finishedTask.Id, finishedTask.Output)
+				}
+				cvId, ok := output["content_view_id"].(float64)
+				if !ok {
+					return fmt.Errorf("task [%s] did not return a content_view_id: %v", finishedTask.Id, output)
+				}
 				cvToRead := ContentView{
-					ForemanObject: ForemanObject{Id: int(output["content_view_id"].(float64))},
+					ForemanObject: ForemanObject{Id: int(cvId)},
 				}
 
 				ctx := context.TODO()
@@ -517,6 +550,17 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 	return nil
 }
 
+// OrganizationParams returns the URL parameters which scope an index request
+// to the organization of the client configuration.  Some Katello endpoints
+// (ie: products) require the organization_id parameter.
+func (client *Client) OrganizationParams() url.Values {
+	params := url.Values{}
+	if client.clientConfig.OrganizationID > 0 {
+		params.Set("organization_id", strconv.Itoa(client.clientConfig.OrganizationID))
+	}
+	return params
+}
+
 // Taken from terraform-openstack-provider
 // CheckDeleted checks the error to see if it's a 404 (Not Found) and, if so,
 // sets the resource ID to the empty string instead of throwing an error.
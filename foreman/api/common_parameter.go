@@ -187,8 +187,7 @@ func (c *Client) QueryCommonParameter(ctx context.Context, d *ForemanCommonParam
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", d.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
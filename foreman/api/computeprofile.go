@@ -155,8 +155,7 @@ func (c *Client) QueryComputeProfile(ctx context.Context, t *ForemanComputeProfi
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + t.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", t.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
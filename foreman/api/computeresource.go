@@ -76,7 +76,7 @@ func (fcr *ForemanComputeResource) UnmarshalJSON(b []byte) error {
 	if fcr.Provider, ok = fcrMap["provider"].(string); !ok {
 		fcr.Provider = ""
 	}
-	if fcr.DisplayType, ok = fcrMap["displaytype"].(string); !ok {
+	if fcr.DisplayType, ok = fcrMap["display_type"].(string); !ok {
 		fcr.DisplayType = ""
 	}
 	if fcr.User, ok = fcrMap["user"].(string); !ok {
@@ -250,8 +250,7 @@ func (c *Client) QueryComputeResource(ctx context.Context, d *ForemanComputeReso
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", d.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
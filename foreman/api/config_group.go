@@ -0,0 +1,219 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// ConfigGroupEndpointPrefix : Prefix appended to API url for puppet
+	// config groups
+	ConfigGroupEndpointPrefix = "puppet/config_groups"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanConfigGroup API model represents a puppet config group, a named
+// set of puppet classes which can be assigned to hosts and hostgroups.
+type ForemanConfigGroup struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// IDs of the puppet classes of the config group
+	PuppetClassIds []int `json:"puppetclass_ids"`
+}
+
+// Implement the Unmarshaler interface
+func (g *ForemanConfigGroup) UnmarshalJSON(b []byte) error {
+	// NOTE(ALL): the alias type has no methods, which prevents the recursion
+	//   into this function
+	type foremanConfigGroup ForemanConfigGroup
+	var gJSON struct {
+		foremanConfigGroup
+		PuppetClasses json.RawMessage `json:"puppetclasses"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &gJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*g = ForemanConfigGroup(gJSON.foremanConfigGroup)
+
+	ids, idsErr := puppetClassesToIds(gJSON.PuppetClasses)
+	if idsErr != nil {
+		return idsErr
+	}
+	g.PuppetClassIds = ids
+
+	return nil
+}
+
+// puppetClassesToIds returns the IDs of nested puppet classes.  Depending on
+// the endpoint, the classes are returned as list or grouped by their module
+// in a map, see QueryPuppetClass.
+func puppetClassesToIds(b json.RawMessage) ([]int, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return []int{}, nil
+	}
+
+	var list []ForemanObject
+	if json.Unmarshal(b, &list) == nil {
+		return foremanObjectArrayToIdIntArray(list), nil
+	}
+
+	var modules map[string][]ForemanObject
+	if jsonDecErr := json.Unmarshal(b, &modules); jsonDecErr != nil {
+		return nil, jsonDecErr
+	}
+	ids := []int{}
+	for _, classes := range modules {
+		ids = append(ids, foremanObjectArrayToIdIntArray(classes)...)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateConfigGroup creates a new ForemanConfigGroup with the attributes of
+// the supplied ForemanConfigGroup reference and returns the created
+// ForemanConfigGroup reference.  The returned reference will have its ID and
+// other API default values set by this function.
+func (c *Client) CreateConfigGroup(ctx context.Context, g *ForemanConfigGroup) (*ForemanConfigGroup, error) {
+	log.Tracef("foreman/api/config_group.go#Create")
+
+	gJSONBytes, jsonEncErr := c.WrapJSON("config_group", g)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("configGroupJSONBytes: [%s]", gJSONBytes)
+
+	// NOTE(ALL): endpoints of the puppet API must not start with a slash,
+	//   see NewRequestWithContext
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		ConfigGroupEndpointPrefix,
+		bytes.NewBuffer(gJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdConfigGroup ForemanConfigGroup
+	sendErr := c.SendAndParse(req, &createdConfigGroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdConfigGroup: [%+v]", createdConfigGroup)
+
+	return &createdConfigGroup, nil
+}
+
+// ReadConfigGroup reads the attributes of a ForemanConfigGroup identified by
+// the supplied ID and returns a ForemanConfigGroup reference.
+func (c *Client) ReadConfigGroup(ctx context.Context, id int) (*ForemanConfigGroup, error) {
+	log.Tracef("foreman/api/config_group.go#Read")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", ConfigGroupEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readConfigGroup ForemanConfigGroup
+	sendErr := c.SendAndParse(req, &readConfigGroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readConfigGroup: [%+v]", readConfigGroup)
+
+	return &readConfigGroup, nil
+}
+
+// UpdateConfigGroup updates a ForemanConfigGroup's attributes.  The config
+// group with the ID of the supplied ForemanConfigGroup will be updated.  A new
+// ForemanConfigGroup reference is returned with the attributes from the result
+// of the update operation.
+func (c *Client) UpdateConfigGroup(ctx context.Context, g *ForemanConfigGroup) (*ForemanConfigGroup, error) {
+	log.Tracef("foreman/api/config_group.go#Update")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", ConfigGroupEndpointPrefix, g.Id)
+
+	gJSONBytes, jsonEncErr := c.WrapJSON("config_group", g)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("configGroupJSONBytes: [%s]", gJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(gJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedConfigGroup ForemanConfigGroup
+	sendErr := c.SendAndParse(req, &updatedConfigGroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedConfigGroup: [%+v]", updatedConfigGroup)
+
+	return &updatedConfigGroup, nil
+}
+
+// DeleteConfigGroup deletes the ForemanConfigGroup identified by the supplied
+// ID
+func (c *Client) DeleteConfigGroup(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/config_group.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", ConfigGroupEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryConfigGroup queries for a ForemanConfigGroup based on the name of the
+// supplied ForemanConfigGroup reference and returns a QueryResponse struct
+// containing query/response metadata and the matching config groups.
+func (c *Client) QueryConfigGroup(ctx context.Context, g *ForemanConfigGroup) (QueryResponse, error) {
+	log.Tracef("foreman/api/config_group.go#Search")
+
+	return SearchAll[ForemanConfigGroup](ctx, c, ConfigGroupEndpointPrefix, SearchBy("name", g.Name), nil)
+}
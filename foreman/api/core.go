@@ -23,6 +23,13 @@ type ForemanObject struct {
 	UpdatedAt string `json:"updated_at"`
 }
 
+// ObjectId returns the unique identifier of the object.  The method is
+// promoted to every API model embedding ForemanObject, which allows handling
+// query results of different types alike.
+func (fo ForemanObject) ObjectId() int {
+	return fo.Id
+}
+
 // ----------------------------------------------------------------------------
 // Foreman API Helper Functions
 // ----------------------------------------------------------------------------
@@ -67,15 +74,17 @@ func foremanObjectArrayToIdIntArray(foa []ForemanObject) []int {
 }
 
 // unmarshalInteger is used to grab a clean copy of the integer from the
-// interface{} inside the JSON map. For some reason a simple integer conversion
-// does not do the trick here.
-// TODO: Investigate the reason for this issue
+// interface{} inside the JSON map.  JSON numbers are decoded as float64, IDs
+// marshalled with intIdToJSONString as string.  Any other value returns 0.
 func unmarshalInteger(mapValue interface{}) (retVal int) {
-	if _, ok := mapValue.(float64); !ok {
-		return 0
-	} else {
-		return int(mapValue.(float64))
+	switch v := mapValue.(type) {
+	case float64:
+		return int(v)
+	case string:
+		retVal, _ = strconv.Atoi(v)
+		return retVal
 	}
+	return 0
 }
 
 // ----------------------------------------------------------------------------
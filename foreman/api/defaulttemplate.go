@@ -179,8 +179,7 @@ func (c *Client) QueryDefaultTemplate(ctx context.Context, d *ForemanDefaultTemp
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", d.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"path"
 	"strconv"
@@ -178,7 +177,7 @@ func (c *Client) QueryDiscoveryRule(ctx context.Context, d *ForemanDiscoveryRule
 	}
 
 	reqQuery := req.URL.Query()
-	reqQuery.Set("search", fmt.Sprintf("name=\"%s\"", d.Name))
+	reqQuery.Set("search", SearchBy("name", d.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	if err := c.SendAndParse(req, &queryResponse); err != nil {
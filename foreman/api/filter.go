@@ -0,0 +1,252 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// FilterEndpointPrefix : Prefix appended to API url for filters
+	FilterEndpointPrefix = "filters"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanFilter API model represents a filter of a role.  A filter
+// grants permissions on a single resource type, optionally limited to the
+// objects matching a search.
+type ForemanFilter struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the role the filter belongs to
+	RoleId int
+	// Scoped search limiting the objects the permissions apply to
+	Search string
+	// Whether the filter uses its own taxonomies instead of the ones of
+	// the role
+	Override bool
+	// Whether the filter applies to all objects, ie: has no search
+	Unlimited bool
+	// Resource type of the permissions, ie: "Host"
+	ResourceType string
+	// IDs of the permissions granted by the filter
+	PermissionIds []int
+	// IDs of the locations the filter is limited to
+	LocationIds []int
+	// IDs of the organizations the filter is limited to
+	OrganizationIds []int
+}
+
+// foremanFilterJSON is the API response of a filter.  Foreman returns the
+// boolean attributes with a trailing question mark.
+type foremanFilterJSON struct {
+	Search        *string             `json:"search"`
+	Override      *bool               `json:"override?"`
+	Unlimited     *bool               `json:"unlimited?"`
+	ResourceType  string              `json:"resource_type"`
+	Role          ForemanObject       `json:"role"`
+	Permissions   []ForemanPermission `json:"permissions"`
+	Locations     []ForemanObject     `json:"locations"`
+	Organizations []ForemanObject     `json:"organizations"`
+}
+
+// Implement the Marshaler interface
+func (f ForemanFilter) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/filter.go#MarshalJSON")
+
+	fMap := map[string]interface{}{
+		"role_id":        f.RoleId,
+		"search":         f.Search,
+		"override":       f.Override,
+		"permission_ids": f.PermissionIds,
+	}
+	// NOTE(ALL): the taxonomies of filters which are not overriding are
+	//   inherited from the role, Foreman ignores the ones sent
+	if f.Override {
+		if f.LocationIds != nil {
+			fMap["location_ids"] = f.LocationIds
+		}
+		if f.OrganizationIds != nil {
+			fMap["organization_ids"] = f.OrganizationIds
+		}
+	}
+
+	log.Debugf("fMap: [%v]", fMap)
+
+	return json.Marshal(fMap)
+}
+
+// Implement the Unmarshaler interface
+func (f *ForemanFilter) UnmarshalJSON(b []byte) error {
+	var fo ForemanObject
+	if jsonDecErr := json.Unmarshal(b, &fo); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	f.ForemanObject = fo
+
+	var fJSON foremanFilterJSON
+	if jsonDecErr := json.Unmarshal(b, &fJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if fJSON.Search != nil {
+		f.Search = *fJSON.Search
+	}
+	if fJSON.Override != nil {
+		f.Override = *fJSON.Override
+	}
+	if fJSON.Unlimited != nil {
+		f.Unlimited = *fJSON.Unlimited
+	} else {
+		f.Unlimited = f.Search == ""
+	}
+	f.RoleId = fJSON.Role.Id
+
+	f.PermissionIds = make([]int, len(fJSON.Permissions))
+	for idx, p := range fJSON.Permissions {
+		f.PermissionIds[idx] = p.Id
+	}
+	// the resource type is derived from the permissions, all of them share
+	// the same type
+	f.ResourceType = fJSON.ResourceType
+	if f.ResourceType == "" && len(fJSON.Permissions) > 0 {
+		f.ResourceType = fJSON.Permissions[0].ResourceType
+	}
+
+	f.LocationIds = foremanObjectArrayToIdIntArray(fJSON.Locations)
+	f.OrganizationIds = foremanObjectArrayToIdIntArray(fJSON.Organizations)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateFilter creates a new ForemanFilter with the attributes of the
+// supplied ForemanFilter reference and returns the created ForemanFilter
+// reference.  The returned reference will have its ID and other API default
+// values set by this function.
+func (c *Client) CreateFilter(ctx context.Context, f *ForemanFilter) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", FilterEndpointPrefix)
+
+	fJSONBytes, jsonEncErr := c.WrapJSON("filter", f)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("filterJSONBytes: [%s]", fJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(fJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdFilter ForemanFilter
+	sendErr := c.SendAndParse(req, &createdFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdFilter: [%+v]", createdFilter)
+
+	return &createdFilter, nil
+}
+
+// ReadFilter reads the attributes of a ForemanFilter identified by the
+// supplied ID and returns a ForemanFilter reference.
+func (c *Client) ReadFilter(ctx context.Context, id int) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readFilter ForemanFilter
+	sendErr := c.SendAndParse(req, &readFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readFilter: [%+v]", readFilter)
+
+	return &readFilter, nil
+}
+
+// UpdateFilter updates a ForemanFilter's attributes.  The filter with the ID
+// of the supplied ForemanFilter will be updated. A new ForemanFilter
+// reference is returned with the attributes from the result of the update
+// operation.
+func (c *Client) UpdateFilter(ctx context.Context, f *ForemanFilter) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, f.Id)
+
+	fJSONBytes, jsonEncErr := c.WrapJSON("filter", f)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("filterJSONBytes: [%s]", fJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(fJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedFilter ForemanFilter
+	sendErr := c.SendAndParse(req, &updatedFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedFilter: [%+v]", updatedFilter)
+
+	return &updatedFilter, nil
+}
+
+// DeleteFilter deletes the ForemanFilter identified by the supplied ID
+func (c *Client) DeleteFilter(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/filter.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 )
@@ -18,6 +21,12 @@ const (
 	PowerSuffix = "power"
 	// ComputeAttributesSuffix : Suffix appended to API url for getting the VM attributes
 	ComputeAttributesSuffix = "vm_compute_attributes"
+	// FactsSuffix : Suffix appended to API url for the facts of a host
+	FactsSuffix = "facts"
+	// StatusSuffix : Suffix appended to API url for the statuses of a host
+	StatusSuffix = "status"
+	// TemplateSuffix : Suffix appended to API url for the rendered templates of a host
+	TemplateSuffix = "template"
 	// PowerOn : Power on operation
 	PowerOn = "on"
 	// PowerOff : Power off operation
@@ -40,6 +49,30 @@ const (
 	PowerBios = "bios"
 )
 
+// Build status of a host as returned in ForemanHost.BuildStatus
+const (
+	HostBuildStatusBuilt        = 0
+	HostBuildStatusPending      = 1
+	HostBuildStatusTokenExpired = 2
+	HostBuildStatusBuildFailed  = 3
+)
+
+// Global severity of a host status as returned in ForemanHostStatus.Status
+const (
+	HostStatusOK      = 0
+	HostStatusWarning = 1
+	HostStatusError   = 2
+)
+
+// HostStatusTypes are the statuses of a host which can be read with
+// ReadHostStatus.  The execution status requires the remote execution plugin.
+var HostStatusTypes = []string{
+	"global",
+	"configuration",
+	"build",
+	"execution",
+}
+
 // -----------------------------------------------------------------------------
 // Struct Definition and Helpers
 // -----------------------------------------------------------------------------
@@ -84,6 +117,8 @@ type ForemanHost struct {
 	// Name of the architecture of this host
 	// ArchitectureName string `json:"architecture_name,omitempty"`
 	SubnetId *int `json:"subnet_id,omitempty"`
+	// ID of the realm the host is enrolled in
+	RealmId *int `json:"realm_id,omitempty"`
 	// ID of the operating system to put on the host
 	OperatingSystemId *int `json:"operatingsystem_id,omitempty"`
 	// ID of the medium that should be mounted
@@ -123,10 +158,43 @@ type ForemanHost struct {
 	PuppetAttributes PuppetAttribute `json:"puppet_attributes"`
 	// Default Root Password for this host (on creation)
 	RootPassword string `json:"root_pass,omitempty"`
+	// Katello content settings of the host, nil for hosts without them
+	ContentFacetAttributes *ForemanHostContentFacet `json:"content_facet_attributes,omitempty"`
+	// Katello subscription settings of the host, nil for hosts without them
+	SubscriptionFacetAttributes *ForemanHostSubscriptionFacet `json:"subscription_facet_attributes,omitempty"`
+}
+
+// ForemanHostContentFacet are the Katello content settings of a host.  The
+// host JSON returns them with the same key they are sent with.  Unset IDs
+// are sent as null, which clears them.
+type ForemanHostContentFacet struct {
+	// ID of the content view
+	ContentViewId *int `json:"content_view_id"`
+	// ID of the lifecycle environment
+	LifecycleEnvironmentId *int `json:"lifecycle_environment_id"`
+	// ID of the smart proxy the content is served from
+	ContentSourceId *int `json:"content_source_id"`
+	// ID of the repository the host is installed from
+	KickstartRepositoryId *int `json:"kickstart_repository_id"`
+}
+
+// ForemanHostSubscriptionFacet are the Katello subscription settings of a
+// host.  Empty values are sent to clear them.
+type ForemanHostSubscriptionFacet struct {
+	ReleaseVersion string `json:"release_version"`
+	ServiceLevel   string `json:"service_level"`
+	PurposeUsage   string `json:"purpose_usage"`
+	PurposeRole    string `json:"purpose_role"`
 }
 
 func (fh *ForemanHost) isBuilt() bool {
-	return fh.BuildStatus == 0
+	return fh.BuildStatus == HostBuildStatusBuilt
+}
+
+// buildFailed returns true if the build of the host can not finish without
+// intervention, ie: the installer reported an error
+func (fh *ForemanHost) buildFailed() bool {
+	return fh.BuildStatus == HostBuildStatusBuildFailed || fh.BuildStatus == HostBuildStatusTokenExpired
 }
 
 // ForemanInterfacesAttribute representing a hosts defined network interfaces
@@ -175,6 +243,21 @@ type foremanHostDecode struct {
 	HostParametersDecode       []ForemanKVParameter         `json:"parameters"`
 }
 
+// ForemanHostStatus is a status of a host.  Status is the global severity
+// of the status, one of HostStatusOK, HostStatusWarning or HostStatusError,
+// the label describes the status itself, ie: "Pending installation".
+type ForemanHostStatus struct {
+	Status      int    `json:"status"`
+	StatusLabel string `json:"status_label"`
+}
+
+// hostFactsPage is a single page of the facts of a host.  The facts are
+// grouped by the name of the host.
+type hostFactsPage struct {
+	QueryResponse
+	Results map[string]map[string]interface{} `json:"results"`
+}
+
 // Power struct for marshal/unmarshal of power state
 // valid states are on, off, soft, cycle, state
 // `omitempty` lets use the same struct for power operations.Command
@@ -200,16 +283,19 @@ type BMCBoot struct {
 //
 // Example: https://<foreman>/api/hosts/<hostname>/boot
 func (c *Client) SendPowerCommand(ctx context.Context, h *ForemanHost, cmd interface{}, retryCount int) error {
-	// Initialize suffix variable,
+	// Initialize suffix and action variables,
 	suffix := ""
+	action := ""
 
 	// Defines the suffix to append to the URL per operation type
 	// Switch-Case against interface type to determine URL suffix
 	switch v := cmd.(type) {
 	case Power:
 		suffix = PowerSuffix
+		action = v.PowerAction
 	case BMCBoot:
 		suffix = BootSuffix
+		action = v.Device
 	default:
 		return fmt.Errorf("Invalid Operation: [%v]", v)
 	}
@@ -222,17 +308,18 @@ func (c *Client) SendPowerCommand(ctx context.Context, h *ForemanHost, cmd inter
 	}
 	log.Debugf("JSONBytes: [%s]", JSONBytes)
 
-	req, reqErr := c.NewRequestWithContext(ctx, http.MethodPut, reqHost, bytes.NewBuffer(JSONBytes))
-	if reqErr != nil {
-		return reqErr
-	}
-
 	retry := 0
 	var sendErr error
 	// retry until the successful Operation
 	// or until # of allowed retries is reached
 	for retry < retryCount {
 		log.Debugf("SendPower: Retry #[%d]", retry)
+		// NOTE(ALL): the body of a request is consumed when it is sent, every
+		//   retry needs a new request
+		req, reqErr := c.NewRequestWithContext(ctx, http.MethodPut, reqHost, bytes.NewBuffer(JSONBytes))
+		if reqErr != nil {
+			return reqErr
+		}
 		sendErr = c.SendAndParse(req, &cmd)
 		if sendErr != nil {
 			retry++
@@ -242,22 +329,122 @@ func (c *Client) SendPowerCommand(ctx context.Context, h *ForemanHost, cmd inter
 	}
 
 	if sendErr != nil {
-		return sendErr
+		return fmt.Errorf("%s operation [%s] on host [%s] failed: %w", suffix, action, h.Name, sendErr)
 	}
 
 	// Type Assertion to access map fields for Power and BMCBoot types
 	powerMap, _ := cmd.(map[string]interface{})
-	bootMap, _ := cmd.(map[string]map[string]interface{})
+	bootMap, _ := powerMap[BootSuffix].(map[string]interface{})
 
 	log.Debugf("Power Response: [%+v]", cmd)
 
 	// Test operation and return an error if result is false
-	if powerMap[PowerSuffix] == false || bootMap[BootSuffix]["result"] == false {
-		return fmt.Errorf("Failed Power Operation")
+	if powerMap[PowerSuffix] == false || bootMap["result"] == false {
+		return fmt.Errorf("%s operation [%s] on host [%s] was not successful", suffix, action, h.Name)
 	}
 	return nil
 }
 
+// RebuildHostConfig rebuilds the configuration of the host identified by the
+// supplied ID, ie: its DHCP, DNS and TFTP records.
+func (c *Client) RebuildHostConfig(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/host.go#RebuildHostConfig")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/rebuild_config", HostEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// DisassociateHost removes the link between the host identified by the
+// supplied ID and its VM, ie: the compute resource and the UUID of the VM.
+// Deleting the host afterwards keeps the VM on the compute resource.
+func (c *Client) DisassociateHost(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/host.go#DisassociateHost")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/disassociate", HostEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// UnmanageHost marks the host identified by the supplied ID as not managed
+// by Foreman.  Only the managed flag is sent, other attributes of the host
+// are kept.
+func (c *Client) UnmanageHost(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/host.go#UnmanageHost")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", HostEndpointPrefix, id)
+
+	hJSONBytes, jsonEncErr := c.WrapJSON("host", map[string]interface{}{"managed": false})
+	if jsonEncErr != nil {
+		return jsonEncErr
+	}
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(hJSONBytes),
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// ReadHostPowerState returns the power state of the supplied host, ie: "on"
+// or "off".  The state is queried from the BMC or the compute resource of
+// the host with the "state" power action.
+func (c *Client) ReadHostPowerState(ctx context.Context, h *ForemanHost) (string, error) {
+	log.Tracef("foreman/api/host.go#ReadHostPowerState")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, h.Id, PowerSuffix)
+
+	JSONBytes, jsonEncErr := json.Marshal(Power{PowerAction: PowerState})
+	if jsonEncErr != nil {
+		return "", jsonEncErr
+	}
+
+	req, reqErr := c.NewRequestWithContext(ctx, http.MethodPut, reqEndpoint, bytes.NewBuffer(JSONBytes))
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	// NOTE(ALL): the state is returned as string in the "power" attribute,
+	//   unlike the result of the other power actions
+	var powerState struct {
+		Power string `json:"power"`
+	}
+	sendErr := c.SendAndParse(req, &powerState)
+	if sendErr != nil {
+		return "", fmt.Errorf("Reading the power state of host [%s] failed: %w", h.Name, sendErr)
+	}
+
+	log.Debugf("Power state of host [%s]: [%s]", h.Name, powerState.Power)
+
+	return strings.ToLower(powerState.Power), nil
+}
+
 // -----------------------------------------------------------------------------
 // CRUD Implementation
 // -----------------------------------------------------------------------------
@@ -327,6 +514,52 @@ func (c *Client) CreateHost(ctx context.Context, h *ForemanHost, retryCount int)
 	return &createdHost.ForemanHost, nil
 }
 
+// WaitForHostBuild reads the ForemanHost identified by the supplied ID every
+// interval until it is built.  An error with the build status label is
+// returned if the build failed or the token expired, and if the context is
+// done before the host is built.  The last read host is returned with the
+// error.
+func (c *Client) WaitForHostBuild(ctx context.Context, id int, interval time.Duration) (*ForemanHost, error) {
+	log.Tracef("foreman/api/host.go#WaitForHostBuild")
+
+	var lastHost *ForemanHost
+	for {
+		readHost, readErr := c.ReadHost(ctx, id)
+		if readErr != nil {
+			if ctx.Err() != nil && lastHost != nil {
+				return lastHost, hostBuildTimeoutError(lastHost)
+			}
+			return nil, readErr
+		}
+		lastHost = readHost
+
+		log.Debugf("Build status of host [%s]: [%d] %s", readHost.Name, readHost.BuildStatus, readHost.BuildStatusLabel)
+
+		if readHost.isBuilt() {
+			return readHost, nil
+		}
+		if readHost.buildFailed() {
+			return readHost, fmt.Errorf("Build of host [%s] failed: %s", readHost.Name, readHost.BuildStatusLabel)
+		}
+
+		select {
+		case <-ctx.Done():
+			return readHost, hostBuildTimeoutError(readHost)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// hostBuildTimeoutError returns the error of a wait for the build of the
+// supplied host which timed out
+func hostBuildTimeoutError(h *ForemanHost) error {
+	return fmt.Errorf(
+		"Timeout while waiting for the build of host [%s], the last build status was: %s",
+		h.Name,
+		h.BuildStatusLabel,
+	)
+}
+
 // ReadHost reads the attributes of a ForemanHost identified by the supplied ID
 // and returns a ForemanHost reference.
 func (c *Client) ReadHost(ctx context.Context, id int) (*ForemanHost, error) {
@@ -446,6 +679,189 @@ func (c *Client) DeleteHost(ctx context.Context, id int) error {
 	return c.SendAndParse(req, nil)
 }
 
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryHost queries for a ForemanHost based on the attributes of the supplied
+// ForemanHost reference and returns a QueryResponse struct containing query
+// results.  Only the attributes returned by the index endpoint are set on
+// the results, use ReadHost to retrieve the full attributes of a host.
+func (c *Client) QueryHost(ctx context.Context, h *ForemanHost) (QueryResponse, error) {
+	log.Tracef("foreman/api/host.go#Query")
+
+	queryResponse := QueryResponse{}
+
+	reqEndpoint := fmt.Sprintf("/%s", HostEndpointPrefix)
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return queryResponse, reqErr
+	}
+
+	// dynamically build the query based on the attributes
+	reqQuery := req.URL.Query()
+	reqQuery.Set("search", SearchBy("name", h.Name).String())
+
+	req.URL.RawQuery = reqQuery.Encode()
+	sendErr := c.SendAndParse(req, &queryResponse)
+	if sendErr != nil {
+		return queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v]", queryResponse)
+
+	// Results will be Unmarshaled into a []map[string]interface{}
+	//
+	// Encode back to JSON, then Unmarshal into []ForemanHost for
+	// the results
+	results := []ForemanHost{}
+	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
+	if jsonEncErr != nil {
+		return queryResponse, jsonEncErr
+	}
+	jsonDecErr := json.Unmarshal(resultsBytes, &results)
+	if jsonDecErr != nil {
+		return queryResponse, jsonDecErr
+	}
+	// convert the search results from []ForemanHost to []interface
+	// and set the search results on the query
+	iArr := make([]interface{}, len(results))
+	for idx, val := range results {
+		iArr[idx] = val
+	}
+	queryResponse.Results = iArr
+
+	return queryResponse, nil
+}
+
+// ReadHostFacts reads the facts of the host identified by the supplied ID and
+// returns them by name.  The facts can be limited with a search on the fact
+// names and values, ie: "name ~ os::".  Values which are not strings, ie:
+// numbers, are converted to their string representation.
+func (c *Client) ReadHostFacts(ctx context.Context, id int, search *SearchQuery) (map[string]string, error) {
+	log.Tracef("foreman/api/host.go#ReadHostFacts")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, id, FactsSuffix)
+
+	facts := map[string]string{}
+	for page := 1; ; page++ {
+		req, reqErr := c.NewRequestWithContext(
+			ctx,
+			http.MethodGet,
+			reqEndpoint,
+			nil,
+		)
+		if reqErr != nil {
+			return nil, reqErr
+		}
+
+		reqQuery := req.URL.Query()
+		if !search.IsEmpty() {
+			reqQuery.Set("search", search.String())
+		}
+		reqQuery.Set("page", strconv.Itoa(page))
+		reqQuery.Set("per_page", strconv.Itoa(SearchPerPage))
+		req.URL.RawQuery = reqQuery.Encode()
+
+		var respPage hostFactsPage
+		sendErr := c.SendAndParse(req, &respPage)
+		if sendErr != nil {
+			return nil, sendErr
+		}
+
+		count := 0
+		for _, hostFacts := range respPage.Results {
+			for name, value := range hostFacts {
+				count++
+				switch v := value.(type) {
+				case string:
+					facts[name] = v
+				case nil:
+					facts[name] = ""
+				default:
+					facts[name] = fmt.Sprint(v)
+				}
+			}
+		}
+
+		// The subtotal counts the matching facts of all pages
+		if count == 0 || len(facts) >= respPage.Subtotal {
+			break
+		}
+	}
+
+	log.Debugf("facts: [%+v]", facts)
+
+	return facts, nil
+}
+
+// ReadHostStatus reads a status of the host identified by the supplied ID.
+// The statusType is one of HostStatusTypes.
+func (c *Client) ReadHostStatus(ctx context.Context, id int, statusType string) (*ForemanHostStatus, error) {
+	log.Tracef("foreman/api/host.go#ReadHostStatus")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/%s", HostEndpointPrefix, id, StatusSuffix, statusType)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readStatus ForemanHostStatus
+	sendErr := c.SendAndParse(req, &readStatus)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readStatus [%s]: [%+v]", statusType, readStatus)
+
+	return &readStatus, nil
+}
+
+// RenderHostTemplate renders the provisioning template of the supplied kind,
+// ie: "provision" or "user_data", for the host identified by the supplied ID.
+// The template is chosen by Foreman the same way as for a build of the host.
+// The rendered template is returned as-is.
+func (c *Client) RenderHostTemplate(ctx context.Context, id int, kind string) (string, error) {
+	log.Tracef("foreman/api/host.go#RenderHostTemplate")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s/%s", HostEndpointPrefix, id, TemplateSuffix, url.PathEscape(kind))
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	// NOTE(ALL): the template is returned as plain text, which can not be
+	//   parsed by SendAndParse
+	statusCode, respBody, sendErr := c.Send(req)
+	if sendErr != nil {
+		return "", sendErr
+	}
+	if statusCode < 200 || statusCode > 299 {
+		return "", HTTPError{req.URL.String(), statusCode, string(respBody)}
+	}
+
+	log.Debugf("rendered template [%s]: [%d] bytes", kind, len(respBody))
+
+	return string(respBody), nil
+}
+
 // Compute Attributes are only available via dedicated API endpoint. readComputeAttributes gets this endpoint.
 func (c *Client) readComputeAttributes(ctx context.Context, id int) (map[string]interface{}, error) {
 	log.Tracef("foreman/api/host.go#readComputeAttributes")
@@ -0,0 +1,226 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// HostInterfaceEndpointPrefix : Prefix appended to API url for the
+	// interfaces of a host
+	HostInterfaceEndpointPrefix = "hosts/%d/interfaces"
+)
+
+// HostInterfaceTypes are the supported types of host interfaces
+var HostInterfaceTypes = []string{
+	"interface",
+	"bmc",
+	"bond",
+	"bridge",
+}
+
+// HostInterfaceBondModes are the supported modes of bond interfaces
+var HostInterfaceBondModes = []string{
+	"balance-rr",
+	"active-backup",
+	"balance-xor",
+	"broadcast",
+	"802.3ad",
+	"balance-tlb",
+	"balance-alb",
+}
+
+// HostInterfaceBMCProviders are the supported providers of BMC interfaces
+var HostInterfaceBMCProviders = []string{
+	"IPMI",
+	"Redfish",
+	"SSH",
+}
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanHostInterface API model represents a single network interface of
+// a host, ie: a NIC, a bond, a VLAN or a BMC.  Unlike the interfaces of
+// ForemanHost, which are sent with the host, each interface is managed on its
+// own.
+type ForemanHostInterface struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the host the interface belongs to.  The ID is part of the
+	// endpoint and not sent with the interface.
+	HostId int `json:"-"`
+
+	// Type of the interface, one of HostInterfaceTypes
+	Type string `json:"type"`
+	// Identifier of the interface local to the host, ie: "eth0"
+	Identifier string `json:"identifier"`
+	MAC        string `json:"mac"`
+	IP         string `json:"ip"`
+	IP6        string `json:"ip6"`
+	SubnetId   int    `json:"subnet_id,omitempty"`
+	Subnet6Id  int    `json:"subnet6_id,omitempty"`
+	DomainId   int    `json:"domain_id,omitempty"`
+	MTU        int    `json:"mtu,omitempty"`
+
+	Managed   bool `json:"managed"`
+	Primary   bool `json:"primary"`
+	Provision bool `json:"provision"`
+	Virtual   bool `json:"virtual"`
+	// Whether the interface is used for remote execution
+	Execution bool `json:"execution"`
+
+	// VLAN tag of virtual interfaces
+	Tag string `json:"tag"`
+	// Identifier of the interface a virtual interface is attached to
+	AttachedTo string `json:"attached_to"`
+
+	// Mode of bond interfaces, one of HostInterfaceBondModes
+	Mode string `json:"mode,omitempty"`
+	// Identifiers of the interfaces of bonds and bridges, comma separated
+	AttachedDevices string `json:"attached_devices"`
+	// Options of bond interfaces, space separated
+	BondOptions string `json:"bond_options"`
+
+	// Provider and credentials of BMC interfaces.  The password is not
+	// returned by the API.
+	Provider string `json:"provider,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateHostInterface creates a new ForemanHostInterface on the host of the
+// supplied ForemanHostInterface reference and returns the created
+// ForemanHostInterface reference.  The returned reference will have its ID
+// and other API default values set by this function.
+func (c *Client) CreateHostInterface(ctx context.Context, fi *ForemanHostInterface) (*ForemanHostInterface, error) {
+	log.Tracef("foreman/api/host_interface.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/"+HostInterfaceEndpointPrefix, fi.HostId)
+
+	fiJSONBytes, jsonEncErr := c.WrapJSON("interface", fi)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("hostInterfaceJSONBytes: [%s]", fiJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(fiJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdHostInterface ForemanHostInterface
+	sendErr := c.SendAndParse(req, &createdHostInterface)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	createdHostInterface.HostId = fi.HostId
+
+	log.Debugf("createdHostInterface: [%+v]", createdHostInterface)
+
+	return &createdHostInterface, nil
+}
+
+// ReadHostInterface reads the attributes of the ForemanHostInterface
+// identified by the supplied host ID and ID and returns a
+// ForemanHostInterface reference.
+func (c *Client) ReadHostInterface(ctx context.Context, hostId int, id int) (*ForemanHostInterface, error) {
+	log.Tracef("foreman/api/host_interface.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/"+HostInterfaceEndpointPrefix+"/%d", hostId, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readHostInterface ForemanHostInterface
+	sendErr := c.SendAndParse(req, &readHostInterface)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	readHostInterface.HostId = hostId
+
+	log.Debugf("readHostInterface: [%+v]", readHostInterface)
+
+	return &readHostInterface, nil
+}
+
+// UpdateHostInterface updates a ForemanHostInterface's attributes.  The
+// interface with the host ID and ID of the supplied ForemanHostInterface will
+// be updated.  A new ForemanHostInterface reference is returned with the
+// attributes from the result of the update operation.
+func (c *Client) UpdateHostInterface(ctx context.Context, fi *ForemanHostInterface) (*ForemanHostInterface, error) {
+	log.Tracef("foreman/api/host_interface.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/"+HostInterfaceEndpointPrefix+"/%d", fi.HostId, fi.Id)
+
+	fiJSONBytes, jsonEncErr := c.WrapJSON("interface", fi)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("hostInterfaceJSONBytes: [%s]", fiJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(fiJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedHostInterface ForemanHostInterface
+	sendErr := c.SendAndParse(req, &updatedHostInterface)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	updatedHostInterface.HostId = fi.HostId
+
+	log.Debugf("updatedHostInterface: [%+v]", updatedHostInterface)
+
+	return &updatedHostInterface, nil
+}
+
+// DeleteHostInterface deletes the ForemanHostInterface identified by the
+// supplied host ID and ID
+func (c *Client) DeleteHostInterface(ctx context.Context, hostId int, id int) error {
+	log.Tracef("foreman/api/host_interface.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/"+HostInterfaceEndpointPrefix+"/%d", hostId, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
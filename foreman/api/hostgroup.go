@@ -175,10 +175,15 @@ func (h *foremanHostGroupDecode) UnmarshalJSON(data []byte) error {
 		Alias: (*Alias)(h),
 	}
 
-	if err := json.Unmarshal(data, &aux); err != nil {
+	if err := json.Unmarshal(data, aux); err != nil {
 		return err
 	}
 
+	// Hostgroups listed by an index request come without parameters
+	if len(aux.Parameters) == 0 {
+		return nil
+	}
+
 	// Handle both slice and map format for parameters
 	var params []ForemanKVParameter
 	if err := json.Unmarshal(aux.Parameters, &params); err == nil {
@@ -281,8 +286,7 @@ func (c *Client) QueryHostgroup(ctx context.Context, h *ForemanHostgroup) (Query
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	title := `"` + h.Title + `"`
-	reqQuery.Set("search", "title="+title)
+	reqQuery.Set("search", SearchBy("title", h.Title).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
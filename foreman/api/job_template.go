@@ -97,8 +97,7 @@ func (c *Client) QueryJobTemplate(ctx context.Context, jt *ForemanJobTemplate) (
 	}
 
 	reqQuery := req.URL.Query()
-	name := `"` + jt.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", jt.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	err = c.SendAndParse(req, &qresp)
@@ -136,8 +136,7 @@ func (c *Client) QueryContentView(ctx context.Context, d *ContentView) (QueryRes
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", d.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	err = c.SendAndParse(req, &queryResponse)
@@ -84,8 +84,7 @@ func (c *Client) QueryLifecycleEnvironment(ctx context.Context, d *LifecycleEnvi
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", d.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	err = c.SendAndParse(req, &queryResponse)
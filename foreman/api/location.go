@@ -0,0 +1,202 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// LocationEndpointPrefix : Prefix appended to API url for locations
+	LocationEndpointPrefix = "locations"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanLocation API model represents a location.  Locations and
+// organizations scope the objects users and hosts have access to.
+type ForemanLocation struct {
+	// Inherits the attributes shared with organizations
+	ForemanTaxonomy
+
+	// IDs of the organizations associated with the location
+	OrganizationIds []int
+}
+
+// Implement the Marshaler interface
+func (l ForemanLocation) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/location.go#MarshalJSON")
+
+	lMap := taxonomyJSONMap(&l.ForemanTaxonomy, "location_parameters_attributes")
+	if l.OrganizationIds != nil {
+		lMap["organization_ids"] = l.OrganizationIds
+	}
+
+	log.Debugf("lMap: [%v]", lMap)
+
+	return json.Marshal(lMap)
+}
+
+// Implement the Unmarshaler interface
+func (l *ForemanLocation) UnmarshalJSON(b []byte) error {
+	if jsonDecErr := unmarshalTaxonomy(b, &l.ForemanTaxonomy); jsonDecErr != nil {
+		return jsonDecErr
+	}
+
+	var jsonDecErr error
+	l.OrganizationIds, jsonDecErr = foremanObjectsToIds(b, "organizations")
+	return jsonDecErr
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateLocation creates a new ForemanLocation with the attributes of
+// the supplied ForemanLocation reference and returns the created
+// ForemanLocation reference.  The returned reference will have its ID and
+// other API default values set by this function.
+func (c *Client) CreateLocation(ctx context.Context, l *ForemanLocation) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/location.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", LocationEndpointPrefix)
+
+	// NOTE(ALL): locations are not scoped by the default taxonomy of the
+	//   provider
+	lJSONBytes, jsonEncErr := c.WrapJSON("location", l)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("locationJSONBytes: [%s]", lJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(lJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdLocation ForemanLocation
+	sendErr := c.SendAndParse(req, &createdLocation)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdLocation: [%+v]", createdLocation)
+
+	return &createdLocation, nil
+}
+
+// ReadLocation reads the attributes of a ForemanLocation identified by
+// the supplied ID and returns a ForemanLocation reference.
+func (c *Client) ReadLocation(ctx context.Context, id int) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/location.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", LocationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readLocation ForemanLocation
+	sendErr := c.SendAndParse(req, &readLocation)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readLocation: [%+v]", readLocation)
+
+	return &readLocation, nil
+}
+
+// UpdateLocation updates a ForemanLocation's attributes.  The
+// location with the ID of the supplied ForemanLocation will be
+// updated. A new ForemanLocation reference is returned with the attributes
+// from the result of the update operation.
+func (c *Client) UpdateLocation(ctx context.Context, l *ForemanLocation) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/location.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", LocationEndpointPrefix, l.Id)
+
+	lJSONBytes, jsonEncErr := c.WrapJSON("location", l)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("locationJSONBytes: [%s]", lJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(lJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedLocation ForemanLocation
+	sendErr := c.SendAndParse(req, &updatedLocation)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedLocation: [%+v]", updatedLocation)
+
+	return &updatedLocation, nil
+}
+
+// DeleteLocation deletes the ForemanLocation identified by the
+// supplied ID
+func (c *Client) DeleteLocation(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/location.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", LocationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryLocation queries for a ForemanLocation based on the title or
+// name of the supplied ForemanLocation reference and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// locations.
+func (c *Client) QueryLocation(ctx context.Context, l *ForemanLocation) (QueryResponse, error) {
+	log.Tracef("foreman/api/location.go#Search")
+
+	search := SearchBy("name", l.Name)
+	if l.Title != "" {
+		search = SearchBy("title", l.Title)
+	}
+
+	return SearchAll[ForemanLocation](ctx, c, LocationEndpointPrefix, search, nil)
+}
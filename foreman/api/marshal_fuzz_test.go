@@ -0,0 +1,506 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"testing/quick"
+)
+
+// ----------------------------------------------------------------------------
+// Fuzz Targets
+// ----------------------------------------------------------------------------
+//
+// Every type with a hand-written MarshalJSON or UnmarshalJSON has a fuzz
+// target.  The targets decode arbitrary input and marshal whatever was
+// decoded again.  Neither may panic and an object which was decoded must
+// always be marshallable.  Run a target with ie:
+//
+//   go test ./foreman/api -run '^$' -fuzz FuzzForemanWebhook -fuzztime 30s
+
+// fuzzSeeds returns the response fixtures of the testdata directories as
+// seed corpus
+func fuzzSeeds(f *testing.F, dirs ...string) [][]byte {
+	seeds := [][]byte{}
+	for _, dir := range dirs {
+		paths, _ := filepath.Glob(filepath.Join("..", "testdata", "*", dir, "*_response.json"))
+		for _, path := range paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				f.Fatalf("Reading the seed [%s] failed: [%s]", path, err)
+			}
+			seeds = append(seeds, data)
+		}
+	}
+	return seeds
+}
+
+// fuzzJSON runs the fuzz target of T with the given seeds.  Common shapes
+// the API may return unexpectedly are always part of the corpus.
+func fuzzJSON[T any](f *testing.F, seeds [][]byte, inline ...string) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	for _, seed := range inline {
+		f.Add([]byte(seed))
+	}
+	for _, seed := range []string{`{}`, `null`, `[]`, `"x"`, `{"id": "1", "name": 1}`} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var decoded T
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return
+		}
+		if _, err := json.Marshal(&decoded); err != nil {
+			t.Fatalf("Marshalling the decoded [%T] returned error [%s] for input [%s]", decoded, err, data)
+		}
+	})
+}
+
+func FuzzForemanKVParameter(f *testing.F) {
+	fuzzJSON[ForemanKVParameter](f, nil,
+		`{"name": "a", "value": "b"}`,
+		`{"name": "a", "value": true}`,
+		`{"name": "a", "value": {"b": [1, 2]}}`,
+		`{"name": "a", "value": 1}`,
+	)
+}
+
+func FuzzForemanArchitecture(f *testing.F) {
+	fuzzJSON[ForemanArchitecture](f, fuzzSeeds(f, "architectures"),
+		`{"id": 1, "operatingsystems": [{"id": 2}, null]}`,
+	)
+}
+
+func FuzzForemanComputeAttribute(f *testing.F) {
+	fuzzJSON[ForemanComputeAttribute](f, nil,
+		`{"id": 1, "name": "small", "compute_resource_id": 2, "vm_attrs": {"cpus": "2", "memory": 1024, "start": true, "volumes": {"0": {"size": 10}}, "nics": [1], "x": null}}`,
+	)
+}
+
+func FuzzForemanComputeResource(f *testing.F) {
+	fuzzJSON[ForemanComputeResource](f, fuzzSeeds(f, "computeresources"))
+}
+
+func FuzzForemanConfigGroup(f *testing.F) {
+	fuzzJSON[ForemanConfigGroup](f, fuzzSeeds(f, "config_groups"),
+		`{"id": 1, "puppetclasses": {"apache": [{"id": 2}]}}`,
+	)
+}
+
+func FuzzForemanHostgroupDecode(f *testing.F) {
+	fuzzJSON[foremanHostGroupDecode](f, fuzzSeeds(f, "hostgroups"),
+		`{"id": 1, "parameters": {"a": "b", "c": 1}}`,
+		`{"id": 1, "parameters": [{"name": "a", "value": false}]}`,
+		`{"id": 1, "parameters": null}`,
+	)
+}
+
+func FuzzForemanImage(f *testing.F) {
+	fuzzJSON[ForemanImage](f, fuzzSeeds(f, "image"))
+}
+
+func FuzzContentViewFilter(f *testing.F) {
+	fuzzJSON[ContentViewFilter](f, nil,
+		`{"id": 1, "name": "f", "type": "rpm", "inclusion": true, "rules": [{"id": 2, "architecture": "x86_64"}]}`,
+	)
+}
+
+func FuzzContentView(f *testing.F) {
+	fuzzJSON[ContentView](f, nil,
+		`{"id": 1, "name": "cv", "organization_id": 1, "repository_ids": [2], "versions": [{"id": 3, "version": "1.0"}], "latest_version": "1.0", "latest_version_id": 3, "errors": {"x": 1}}`,
+	)
+}
+
+func FuzzLifecycleEnvironment(f *testing.F) {
+	fuzzJSON[LifecycleEnvironment](f, nil,
+		`{"id": 2, "name": "dev", "organization_id": 1, "prior": {"id": 1, "name": "Library"}}`,
+		`{"id": 2, "prior": null}`,
+	)
+}
+
+func FuzzForemanMedia(f *testing.F) {
+	fuzzJSON[ForemanMedia](f, fuzzSeeds(f, "media"))
+}
+
+func FuzzForemanOperatingSystem(f *testing.F) {
+	fuzzJSON[ForemanOperatingSystem](f, fuzzSeeds(f, "operatingsystems"),
+		`{"id": 1, "os_parameters_attributes": [{"name": "a", "value": "b"}]}`,
+	)
+}
+
+func FuzzForemanOverrideValue(f *testing.F) {
+	fuzzJSON[ForemanOverrideValue](f, fuzzSeeds(f, "override_values"),
+		`{"id": 1, "match": "fqdn=host.example.com", "value": {"a": [1]}, "omit": true}`,
+		`{"id": 1, "match": "os", "value": "NaN"}`,
+	)
+}
+
+func FuzzForemanParameter(f *testing.F) {
+	fuzzJSON[ForemanParameter](f, nil,
+		`{"id": 1, "name": "a", "value": "b", "parameter_type": "string"}`,
+		`{"id": 1, "name": "a", "value": 1.5}`,
+	)
+}
+
+func FuzzForemanPartitionTable(f *testing.F) {
+	fuzzJSON[ForemanPartitionTable](f, fuzzSeeds(f, "ptables"))
+}
+
+func FuzzForemanProvisioningTemplate(f *testing.F) {
+	fuzzJSON[ForemanProvisioningTemplate](f, fuzzSeeds(f, "provisioning_templates"),
+		`{"id": 1, "template_kind_id": "3", "template_combinations": [{"hostgroup_id": 1}]}`,
+	)
+}
+
+func FuzzForemanSmartClassParameter(f *testing.F) {
+	fuzzJSON[ForemanSmartClassParameter](f, fuzzSeeds(f, "smart_class_parameters"),
+		`{"id": 1, "default_value": {"a": [1]}, "validator_rule": null, "hidden_value?": true}`,
+	)
+}
+
+func FuzzForemanKatelloRepository(f *testing.F) {
+	fuzzJSON[ForemanKatelloRepository](f, nil,
+		`{"id": 1, "name": "base", "product": {"id": 2}, "content_type": "deb", "deb_releases": "stable"}`,
+		`{"id": 1, "content_type": "docker", "docker_upstream_name": "library/alpine"}`,
+	)
+}
+
+func FuzzForemanTemplateInput(f *testing.F) {
+	fuzzJSON[ForemanTemplateInput](f, nil,
+		`{"id": 1, "template_id": 2, "name": "a", "input_type": "user", "required": true}`,
+		`{"id": "1", "template_id": ""}`,
+		`{"id": true}`,
+		`{"name": "no id"}`,
+	)
+}
+
+func FuzzForemanUsergroup(f *testing.F) {
+	fuzzJSON[ForemanUsergroup](f, nil,
+		`{"id": 1, "name": "admins", "admin": true}`,
+	)
+}
+
+func FuzzForemanWebhook(f *testing.F) {
+	fuzzJSON[ForemanWebhook](f, fuzzSeeds(f, "webhooks"),
+		`{"id": 1, "webhook_template_id": "2"}`,
+	)
+}
+
+// ----------------------------------------------------------------------------
+// Round-trip Properties
+// ----------------------------------------------------------------------------
+//
+// The properties marshal a generated object, decode the JSON again and check
+// the attributes sent to and returned by the API are kept.  Attributes which
+// only appear in responses (ie: nested objects) are not part of a property.
+
+// checkProperty fails the test if quick.Check finds a counterexample
+func checkProperty(t *testing.T, property interface{}) {
+	if err := quick.Check(property, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// roundTrip marshals the object and decodes the JSON into the result
+func roundTrip(t *testing.T, obj interface{}, result interface{}) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("Marshalling [%+v] returned error [%s]", obj, err)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		t.Fatalf("Unmarshalling [%s] returned error [%s]", data, err)
+	}
+}
+
+func TestRoundTrip_ForemanKVParameter(t *testing.T) {
+	checkProperty(t, func(name string, str string, b bool, key string) bool {
+		for _, value := range []interface{}{str, b, map[string]interface{}{key: str}} {
+			var result ForemanKVParameter
+			roundTrip(t, ForemanKVParameter{Name: name, Value: value}, &result)
+			if result.Name != name || !reflect.DeepEqual(result.Value, value) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestRoundTrip_ForemanComputeAttribute(t *testing.T) {
+	checkProperty(t, func(id int, name string, crId int, cpus int, memory float64, start bool) bool {
+		attr := ForemanComputeAttribute{
+			ForemanObject:     ForemanObject{Id: id, Name: name},
+			ComputeResourceId: crId,
+			VMAttrs: map[string]interface{}{
+				"cpus":   cpus,
+				"memory": memory,
+				"start":  start,
+				"disk":   strconv.Itoa(cpus),
+				"nics":   []interface{}{"a"},
+			},
+		}
+		var result ForemanComputeAttribute
+		roundTrip(t, &attr, &result)
+
+		// numbers and booleans are sent as strings, strings holding JSON
+		// as the decoded value and nested values as JSON strings
+		expected := map[string]interface{}{
+			"cpus":   strconv.Itoa(cpus),
+			"memory": strconv.FormatFloat(memory, 'f', -1, 64),
+			"start":  strconv.FormatBool(start),
+			"disk":   float64(cpus),
+			"nics":   `["a"]`,
+		}
+		return result.Id == id && result.Name == name && result.ComputeResourceId == crId &&
+			reflect.DeepEqual(result.VMAttrs, expected)
+	})
+}
+
+func TestRoundTrip_ForemanOverrideValue(t *testing.T) {
+	matchTypes := []string{"fqdn", "hostgroup", "domain", "os"}
+	checkProperty(t, func(matchIdx uint8, matchValue string, omit bool, i int, f float64, b bool) bool {
+		matchType := matchTypes[int(matchIdx)%len(matchTypes)]
+		values := []string{
+			strconv.Itoa(i),
+			strconv.FormatFloat(f, 'g', -1, 64),
+			strconv.FormatBool(b),
+			"text " + matchValue,
+			"NaN",
+			`{"a":[1,2]}`,
+		}
+		for _, value := range values {
+			ov := ForemanOverrideValue{MatchType: matchType, MatchValue: matchValue, Omit: omit, Value: value}
+			var result ForemanOverrideValue
+			roundTrip(t, ov, &result)
+
+			expected := value
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil && value != "NaN" {
+				// numbers are returned in their canonical form
+				expected = strconv.FormatFloat(parsed, 'g', -1, 64)
+				if _, err := strconv.Atoi(value); err == nil {
+					expected = value
+				}
+			}
+			if result.MatchType != matchType || result.MatchValue != matchValue ||
+				result.Omit != omit || result.Value != expected {
+				t.Logf("Expected [%+v] with value [%s], got [%+v]", ov, expected, result)
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestRoundTrip_ForemanProvisioningTemplate(t *testing.T) {
+	checkProperty(t, func(name string, template string, snippet bool, locked bool, kindId uint16, description string) bool {
+		ft := ForemanProvisioningTemplate{
+			ForemanObject:  ForemanObject{Name: name},
+			Template:       template,
+			Snippet:        snippet,
+			Locked:         locked,
+			TemplateKindId: int(kindId),
+			Description:    description,
+		}
+		var result ForemanProvisioningTemplate
+		roundTrip(t, ft, &result)
+		return result.Name == name && result.Template == template && result.Snippet == snippet &&
+			result.Locked == locked && result.TemplateKindId == int(kindId) && result.Description == description
+	})
+}
+
+func TestRoundTrip_ForemanWebhook(t *testing.T) {
+	checkProperty(t, func(name string, targetURL string, enabled bool, verifySSL bool, user string, password string, templateId uint16) bool {
+		fw := ForemanWebhook{
+			ForemanObject:     ForemanObject{Name: name},
+			TargetURL:         targetURL,
+			HTTPMethod:        "POST",
+			Event:             "host_created.event.foreman",
+			Enabled:           enabled,
+			VerifySSL:         verifySSL,
+			User:              user,
+			Password:          password,
+			WebhookTemplateID: int(templateId),
+		}
+		var result ForemanWebhook
+		roundTrip(t, fw, &result)
+		return reflect.DeepEqual(result, fw)
+	})
+}
+
+func TestRoundTrip_ForemanUsergroup(t *testing.T) {
+	checkProperty(t, func(name string, admin bool) bool {
+		var result ForemanUsergroup
+		roundTrip(t, ForemanUsergroup{ForemanObject: ForemanObject{Name: name}, Admin: admin}, &result)
+		return result.Name == name && result.Admin == admin
+	})
+}
+
+func TestRoundTrip_ForemanTemplateInput(t *testing.T) {
+	checkProperty(t, func(id uint16, templateId uint16, name string, required bool, def string, hidden bool) bool {
+		fti := ForemanTemplateInput{
+			ForemanObject: ForemanObject{Id: int(id), Name: name},
+			TemplateId:    int(templateId),
+			Required:      required,
+			Default:       def,
+			HiddenValue:   hidden,
+			InputType:     "user",
+			ValueType:     "plain",
+		}
+		var result ForemanTemplateInput
+		roundTrip(t, fti, &result)
+		return reflect.DeepEqual(result, fti)
+	})
+}
+
+func TestRoundTrip_ContentView(t *testing.T) {
+	checkProperty(t, func(id int, name string, orgId int, composite bool, autoPublish bool, repoIds []int, latest string) bool {
+		cv := ContentView{
+			ForemanObject:  ForemanObject{Id: id, Name: name},
+			OrganizationId: orgId,
+			Composite:      composite,
+			AutoPublish:    autoPublish,
+			RepositoryIds:  repoIds,
+			LatestVersion:  latest,
+		}
+		var result ContentView
+		roundTrip(t, &cv, &result)
+		return result.Id == id && result.Name == name && result.OrganizationId == orgId &&
+			result.Composite == composite && result.AutoPublish == autoPublish &&
+			reflect.DeepEqual(result.RepositoryIds, repoIds) && result.LatestVersion == latest
+	})
+}
+
+func TestRoundTrip_ForemanKatelloRepository(t *testing.T) {
+	contentTypes := []string{"yum", "deb", "docker", "ansible_collection", "file"}
+	checkProperty(t, func(id int, name string, productId int, typeIdx uint8, url string, concurrency uint8, upstream string) bool {
+		repo := ForemanKatelloRepository{
+			ForemanObject:       ForemanObject{Id: id, Name: name},
+			ProductId:           productId,
+			ContentType:         contentTypes[int(typeIdx)%len(contentTypes)],
+			Url:                 url,
+			DownloadConcurrency: int(concurrency),
+		}
+		switch repo.ContentType {
+		case "deb":
+			repo.DebReleases = upstream
+		case "docker":
+			repo.DockerUpstreamName = upstream
+		case "ansible_collection":
+			repo.AnsibleCollectionRequirements = upstream
+		}
+		var result ForemanKatelloRepository
+		roundTrip(t, &repo, &result)
+		return reflect.DeepEqual(result, repo)
+	})
+}
+
+func TestRoundTrip_LifecycleEnvironment(t *testing.T) {
+	checkProperty(t, func(id int, name string, orgId int, label string) bool {
+		lce := LifecycleEnvironment{ForemanObject: ForemanObject{Id: id, Name: name}, OrganizationId: orgId, Label: label}
+		var result LifecycleEnvironment
+		roundTrip(t, &lce, &result)
+		return reflect.DeepEqual(result, lce)
+	})
+}
+
+func TestRoundTrip_ForemanImage(t *testing.T) {
+	checkProperty(t, func(uuid string, name string, osId int, archId int, userData bool) bool {
+		fi := ForemanImage{
+			ForemanObject:     ForemanObject{Name: name},
+			UUID:              uuid,
+			OperatingSystemID: osId,
+			ArchitectureID:    archId,
+			UserData:          userData,
+		}
+		var result ForemanImage
+		roundTrip(t, &fi, &result)
+		return reflect.DeepEqual(result, fi)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Unexpected API Shapes
+// ----------------------------------------------------------------------------
+
+// Ensures malformed values are returned as errors instead of terminating
+// the provider
+func TestUnmarshal_UnexpectedShapes(t *testing.T) {
+	testCases := []struct {
+		Input  string
+		Target interface{}
+	}{
+		{Input: `{"id": true}`, Target: &ForemanTemplateInput{}},
+		{Input: `{"id": 1, "template_id": [1]}`, Target: &ForemanTemplateInput{}},
+		{Input: `{"name": "no id"}`, Target: &ForemanTemplateInput{}},
+		{Input: `{"name": "a", "value": 1}`, Target: &ForemanKVParameter{}},
+		{Input: `{"id": 1, "parameters": 1}`, Target: &foremanHostGroupDecode{}},
+	}
+
+	for _, testCase := range testCases {
+		if err := json.Unmarshal([]byte(testCase.Input), testCase.Target); err == nil {
+			t.Fatalf("Unmarshalling [%s] into [%T] did not return an error", testCase.Input, testCase.Target)
+		}
+	}
+
+	var hg foremanHostGroupDecode
+	if err := json.Unmarshal([]byte(`{"id": 1, "name": "no parameters"}`), &hg); err != nil {
+		t.Fatalf("Unmarshalling a hostgroup without parameters returned error [%s]", err)
+	}
+}
+
+// Ensures a publish task returns an error instead of panicking or skipping
+// the content view when its output holds no numeric content_view_id
+func FuzzSendAndParse_PublishTaskOutput(f *testing.F) {
+	for _, seed := range []string{
+		`null`,
+		`"done"`,
+		`{}`,
+		`{"content_view_id": "1"}`,
+		`{"content_view_id": null}`,
+		`{"content_view_id": [1]}`,
+		`{"content_view_id": 1}`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, output []byte) {
+		var decoded interface{}
+		if err := json.Unmarshal(output, &decoded); err != nil {
+			// the task response would not be valid JSON
+			return
+		}
+		object, _ := decoded.(map[string]interface{})
+		_, numeric := object["content_view_id"].(float64)
+
+		mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+		defer server.Close()
+		mux.HandleFunc("/katello/api/content_views/", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"id": "abc", "pending": true}`))
+				return
+			}
+			w.Write([]byte(`{"id": 1, "name": "cv"}`))
+		})
+		mux.HandleFunc("/foreman_tasks/api/tasks/abc", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"id": "abc", "pending": false, "label": "Actions::Katello::ContentView::Publish", "output": %s}`, output)
+		})
+
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodPost, "/katello/api/content_views/1/publish", nil)
+		err := client.SendAndParse(req, nil)
+		if numeric && err != nil {
+			t.Fatalf("SendAndParse returned error [%s] for task output [%s]", err, output)
+		}
+		if !numeric && err == nil {
+			t.Fatalf("SendAndParse did not return an error for task output [%s]", output)
+		}
+	})
+}
@@ -277,8 +277,7 @@ func (c *Client) QueryOperatingSystem(ctx context.Context, o *ForemanOperatingSy
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	title := `"` + o.Title + `"`
-	reqQuery.Set("search", "title="+title)
+	reqQuery.Set("search", SearchBy("title", o.Title).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// OrganizationEndpointPrefix : Prefix appended to API url for organizations
+	OrganizationEndpointPrefix = "organizations"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanOrganization API model represents an organization.  Organizations
+// and locations scope the objects users and hosts have access to.
+type ForemanOrganization struct {
+	// Inherits the attributes shared with locations
+	ForemanTaxonomy
+
+	// Unique label of the organization, only set by Katello
+	Label string
+	// IDs of the locations associated with the organization
+	LocationIds []int
+}
+
+// Implement the Marshaler interface
+func (o ForemanOrganization) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/organization.go#MarshalJSON")
+
+	oMap := taxonomyJSONMap(&o.ForemanTaxonomy, "organization_parameters_attributes")
+	if o.Label != "" {
+		oMap["label"] = o.Label
+	}
+	if o.LocationIds != nil {
+		oMap["location_ids"] = o.LocationIds
+	}
+
+	log.Debugf("oMap: [%v]", oMap)
+
+	return json.Marshal(oMap)
+}
+
+// Implement the Unmarshaler interface
+func (o *ForemanOrganization) UnmarshalJSON(b []byte) error {
+	if jsonDecErr := unmarshalTaxonomy(b, &o.ForemanTaxonomy); jsonDecErr != nil {
+		return jsonDecErr
+	}
+
+	var oJSON struct {
+		Label string `json:"label"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &oJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	o.Label = oJSON.Label
+
+	var jsonDecErr error
+	o.LocationIds, jsonDecErr = foremanObjectsToIds(b, "locations")
+	return jsonDecErr
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateOrganization creates a new ForemanOrganization with the attributes of
+// the supplied ForemanOrganization reference and returns the created
+// ForemanOrganization reference.  The returned reference will have its ID and
+// other API default values set by this function.
+func (c *Client) CreateOrganization(ctx context.Context, o *ForemanOrganization) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/organization.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", OrganizationEndpointPrefix)
+
+	// NOTE(ALL): organizations are not scoped by the default taxonomy of the
+	//   provider
+	oJSONBytes, jsonEncErr := c.WrapJSON("organization", o)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("organizationJSONBytes: [%s]", oJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(oJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdOrganization ForemanOrganization
+	sendErr := c.SendAndParse(req, &createdOrganization)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdOrganization: [%+v]", createdOrganization)
+
+	return &createdOrganization, nil
+}
+
+// ReadOrganization reads the attributes of a ForemanOrganization identified by
+// the supplied ID and returns a ForemanOrganization reference.
+func (c *Client) ReadOrganization(ctx context.Context, id int) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/organization.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", OrganizationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readOrganization ForemanOrganization
+	sendErr := c.SendAndParse(req, &readOrganization)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readOrganization: [%+v]", readOrganization)
+
+	return &readOrganization, nil
+}
+
+// UpdateOrganization updates a ForemanOrganization's attributes.  The
+// organization with the ID of the supplied ForemanOrganization will be
+// updated. A new ForemanOrganization reference is returned with the attributes
+// from the result of the update operation.
+func (c *Client) UpdateOrganization(ctx context.Context, o *ForemanOrganization) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/organization.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", OrganizationEndpointPrefix, o.Id)
+
+	oJSONBytes, jsonEncErr := c.WrapJSON("organization", o)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("organizationJSONBytes: [%s]", oJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(oJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedOrganization ForemanOrganization
+	sendErr := c.SendAndParse(req, &updatedOrganization)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedOrganization: [%+v]", updatedOrganization)
+
+	return &updatedOrganization, nil
+}
+
+// DeleteOrganization deletes the ForemanOrganization identified by the
+// supplied ID
+func (c *Client) DeleteOrganization(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/organization.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", OrganizationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryOrganization queries for a ForemanOrganization based on the title or
+// name of the supplied ForemanOrganization reference and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// organizations.
+func (c *Client) QueryOrganization(ctx context.Context, o *ForemanOrganization) (QueryResponse, error) {
+	log.Tracef("foreman/api/organization.go#Search")
+
+	search := SearchBy("name", o.Name)
+	if o.Title != "" {
+		search = SearchBy("title", o.Title)
+	}
+
+	return SearchAll[ForemanOrganization](ctx, c, OrganizationEndpointPrefix, search, nil)
+}
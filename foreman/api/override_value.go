@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -50,7 +51,13 @@ func (ov ForemanOverrideValue) MarshalJSON() ([]byte, error) {
 	var err error
 	ovMap["value"], err = strconv.Atoi(ov.Value)
 	if err != nil {
-		ovMap["value"], err = strconv.ParseFloat(ov.Value, 32)
+		var f float64
+		f, err = strconv.ParseFloat(ov.Value, 64)
+		// "NaN" and "Inf" are parsed as floats, but cannot be encoded as JSON
+		if err == nil && (math.IsNaN(f) || math.IsInf(f, 0)) {
+			err = fmt.Errorf("value [%s] is not a finite number", ov.Value)
+		}
+		ovMap["value"] = f
 	}
 	if err != nil {
 		ovMap["value"], err = strconv.ParseBool(ov.Value)
@@ -79,7 +86,10 @@ func (ov *ForemanOverrideValue) UnmarshalJSON(b []byte) error {
 	ov.ForemanObject = fo
 
 	var tmpMap map[string]interface{}
-	jsonDecErr = json.Unmarshal(b, &tmpMap)
+	// decode numbers as json.Number to return large integer values unchanged
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	jsonDecErr = decoder.Decode(&tmpMap)
 	if jsonDecErr != nil {
 		return jsonDecErr
 	}
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// PermissionEndpointPrefix : Prefix appended to API url for permissions
+	PermissionEndpointPrefix = "permissions"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanPermission API model represents a permission, ie: "view_hosts".
+// Permissions are defined by Foreman and its plugins and are read-only.
+type ForemanPermission struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Resource type the permission applies to, ie: "Host"
+	ResourceType string `json:"resource_type"`
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryPermission queries for a ForemanPermission based on the name of the
+// supplied ForemanPermission reference and returns a QueryResponse struct
+// containing query/response metadata and the matching permissions.
+func (c *Client) QueryPermission(ctx context.Context, p *ForemanPermission) (QueryResponse, error) {
+	log.Tracef("foreman/api/permission.go#Search")
+
+	return SearchAll[ForemanPermission](ctx, c, PermissionEndpointPrefix, SearchBy("name", p.Name), nil)
+}
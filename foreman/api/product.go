@@ -189,8 +189,7 @@ func (c *Client) QueryKatelloProduct(ctx context.Context, p *ForemanKatelloProdu
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + p.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", p.Name).String())
 
 	// organization_id is a required parameter
 	orgId := strconv.Itoa(c.clientConfig.OrganizationID)
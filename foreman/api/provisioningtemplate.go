@@ -171,13 +171,7 @@ func (ft *ForemanProvisioningTemplate) UnmarshalJSON(b []byte) error {
 	if ft.Locked, ok = ftMap["locked"].(bool); !ok {
 		ft.Locked = false
 	}
-	// NOTE(ALL): Properties unmarshalled are of type float64 as opposed to int, hence the below testing
-	// Without this, properties will define as default values in state file.
-	if _, ok = ftMap["template_kind_id"].(float64); !ok {
-		ft.TemplateKindId = 0
-	} else {
-		ft.TemplateKindId = int(ftMap["template_kind_id"].(float64))
-	}
+	ft.TemplateKindId = unmarshalInteger(ftMap["template_kind_id"])
 	if ft.Description, ok = ftMap["description"].(string); !ok {
 		ft.Description = ""
 	}
@@ -339,8 +333,7 @@ func (c *Client) QueryProvisioningTemplate(ctx context.Context, t *ForemanProvis
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := "\"" + t.Name + "\""
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", t.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// RealmEndpointPrefix : Prefix appended to API url for realms
+	RealmEndpointPrefix = "realms"
+)
+
+// RealmTypes are the supported types of realms
+var RealmTypes = []string{
+	"FreeIPA",
+	"Active Directory",
+}
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanRealm API model represents a realm, ie: a FreeIPA domain.  Hosts
+// in a realm are enrolled through the realm proxy when they are provisioned.
+type ForemanRealm struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Type of the realm, one of RealmTypes
+	RealmType string `json:"realm_type"`
+	// ID of the smart proxy with the realm feature managing the realm
+	RealmProxyId int `json:"realm_proxy_id"`
+
+	// IDs of the locations and organizations of the realm.  Nil leaves them
+	// untouched, an empty list removes all of them.
+	LocationIds     []int `json:"location_ids"`
+	OrganizationIds []int `json:"organization_ids"`
+}
+
+// Implement the Marshaler interface
+func (r ForemanRealm) MarshalJSON() ([]byte, error) {
+	// NOTE(ALL): the alias type has no methods, which prevents the recursion
+	//   into this function.  The taxonomy lists shadow the ones of the alias
+	//   type to omit nil lists, but send empty ones.
+	type foremanRealm ForemanRealm
+	rJSON := struct {
+		foremanRealm
+		LocationIds     *[]int `json:"location_ids,omitempty"`
+		OrganizationIds *[]int `json:"organization_ids,omitempty"`
+	}{
+		foremanRealm: foremanRealm(r),
+	}
+	if r.LocationIds != nil {
+		rJSON.LocationIds = &r.LocationIds
+	}
+	if r.OrganizationIds != nil {
+		rJSON.OrganizationIds = &r.OrganizationIds
+	}
+	return json.Marshal(rJSON)
+}
+
+// Implement the Unmarshaler interface
+func (r *ForemanRealm) UnmarshalJSON(b []byte) error {
+	// NOTE(ALL): the alias type has no methods, which prevents the recursion
+	//   into this function
+	type foremanRealm ForemanRealm
+	var rJSON struct {
+		foremanRealm
+		Locations     []ForemanObject `json:"locations"`
+		Organizations []ForemanObject `json:"organizations"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &rJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*r = ForemanRealm(rJSON.foremanRealm)
+
+	// Foreman returns the taxonomies as nested objects
+	r.LocationIds = foremanObjectArrayToIdIntArray(rJSON.Locations)
+	r.OrganizationIds = foremanObjectArrayToIdIntArray(rJSON.Organizations)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateRealm creates a new ForemanRealm with the attributes of the supplied
+// ForemanRealm reference and returns the created ForemanRealm reference.  The
+// returned reference will have its ID and other API default values set by this
+// function.
+func (c *Client) CreateRealm(ctx context.Context, r *ForemanRealm) (*ForemanRealm, error) {
+	log.Tracef("foreman/api/realm.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", RealmEndpointPrefix)
+
+	rJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("realm", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("realmJSONBytes: [%s]", rJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(rJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdRealm ForemanRealm
+	sendErr := c.SendAndParse(req, &createdRealm)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdRealm: [%+v]", createdRealm)
+
+	return &createdRealm, nil
+}
+
+// ReadRealm reads the attributes of a ForemanRealm identified by the supplied
+// ID and returns a ForemanRealm reference.
+func (c *Client) ReadRealm(ctx context.Context, id int) (*ForemanRealm, error) {
+	log.Tracef("foreman/api/realm.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RealmEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readRealm ForemanRealm
+	sendErr := c.SendAndParse(req, &readRealm)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readRealm: [%+v]", readRealm)
+
+	return &readRealm, nil
+}
+
+// UpdateRealm updates a ForemanRealm's attributes.  The realm with the
+// ID of the supplied ForemanRealm will be updated.  A new ForemanRealm
+// reference is returned with the attributes from the result of the update
+// operation.
+func (c *Client) UpdateRealm(ctx context.Context, r *ForemanRealm) (*ForemanRealm, error) {
+	log.Tracef("foreman/api/realm.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RealmEndpointPrefix, r.Id)
+
+	rJSONBytes, jsonEncErr := c.WrapJSON("realm", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("realmJSONBytes: [%s]", rJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(rJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedRealm ForemanRealm
+	sendErr := c.SendAndParse(req, &updatedRealm)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedRealm: [%+v]", updatedRealm)
+
+	return &updatedRealm, nil
+}
+
+// DeleteRealm deletes the ForemanRealm identified by the supplied ID
+func (c *Client) DeleteRealm(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/realm.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RealmEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryRealm queries for a ForemanRealm based on the name of the supplied
+// ForemanRealm reference and returns a QueryResponse struct containing
+// query/response metadata and the matching realms.
+func (c *Client) QueryRealm(ctx context.Context, r *ForemanRealm) (QueryResponse, error) {
+	log.Tracef("foreman/api/realm.go#Search")
+
+	return SearchAll[ForemanRealm](ctx, c, RealmEndpointPrefix, SearchBy("name", r.Name), nil)
+}
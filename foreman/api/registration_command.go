@@ -0,0 +1,92 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// RegistrationCommandEndpointPrefix : Prefix appended to API url for the
+	// global registration command
+	RegistrationCommandEndpointPrefix = "registration_commands"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanRegistrationCommand API model represents the options of the
+// global registration command, which registers an existing machine as host.
+// The command is generated by Foreman and not stored.
+type ForemanRegistrationCommand struct {
+	OrganizationId    int `json:"organization_id,omitempty"`
+	LocationId        int `json:"location_id,omitempty"`
+	HostgroupId       int `json:"hostgroup_id,omitempty"`
+	OperatingSystemId int `json:"operatingsystem_id,omitempty"`
+	// ID of the smart proxy the host registers through
+	SmartProxyId int `json:"smart_proxy_id,omitempty"`
+	// Katello activation keys of the host
+	ActivationKeys []string `json:"activation_keys,omitempty"`
+
+	// Whether the command skips the verification of the server certificate
+	Insecure bool `json:"insecure"`
+	// Hours the token of the command is valid, 0 for no expiry
+	JWTExpiration int `json:"jwt_expiration"`
+	// Whether the packages of the host are updated after the registration
+	UpdatePackages bool `json:"update_packages"`
+
+	// Whether Insights and remote execution are set up.  Nil inherits the
+	// host parameters "host_registration_insights" and
+	// "host_registration_remote_execution".
+	SetupInsights        *bool `json:"setup_insights,omitempty"`
+	SetupRemoteExecution *bool `json:"setup_remote_execution,omitempty"`
+}
+
+// foremanRegistrationCommandResponse is the response of the registration
+// command endpoint
+type foremanRegistrationCommandResponse struct {
+	RegistrationCommand string `json:"registration_command"`
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateRegistrationCommand generates the global registration command with
+// the options of the supplied ForemanRegistrationCommand reference and
+// returns the command.  The command contains a token granting the
+// registration of hosts.
+func (c *Client) CreateRegistrationCommand(ctx context.Context, rc *ForemanRegistrationCommand) (string, error) {
+	log.Tracef("foreman/api/registration_command.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", RegistrationCommandEndpointPrefix)
+
+	rcJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("registration_command", rc)
+	if jsonEncErr != nil {
+		return "", jsonEncErr
+	}
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(rcJSONBytes),
+	)
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	var createdCommand foremanRegistrationCommandResponse
+	sendErr := c.SendAndParse(req, &createdCommand)
+	if sendErr != nil {
+		return "", sendErr
+	}
+
+	log.Debugf("createdRegistrationCommand: [%d] bytes", len(createdCommand.RegistrationCommand))
+
+	return createdCommand.RegistrationCommand, nil
+}
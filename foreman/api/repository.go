@@ -6,6 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 )
@@ -273,8 +276,12 @@ func (c *Client) QueryKatelloRepository(ctx context.Context, p *ForemanKatelloRe
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + p.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", p.Name).String())
+
+	// repository names are only unique within a product
+	if p.ProductId > 0 {
+		reqQuery.Set("product_id", strconv.Itoa(p.ProductId))
+	}
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
@@ -305,3 +312,55 @@ func (c *Client) QueryKatelloRepository(ctx context.Context, p *ForemanKatelloRe
 
 	return queryResponse, nil
 }
+
+// QueryKatelloRepositoryByPath queries for the ForemanKatelloRepository
+// identified by a path of the form "<organization>/<product>/<repository>".
+// The organization is matched by name or label, the product and repository
+// by name.  Names containing a slash are not supported.
+func (c *Client) QueryKatelloRepositoryByPath(ctx context.Context, path string) (QueryResponse, error) {
+	log.Tracef("foreman/api/repository.go#QueryByPath")
+
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 {
+		return QueryResponse{}, fmt.Errorf(
+			"Repository path [%s] is not of the form <organization>/<product>/<repository>",
+			path,
+		)
+	}
+
+	orgSearch := SearchBy("name", parts[0]).Or("label", SearchEqual, parts[0])
+	orgResponse, orgErr := SearchAll[ForemanObject](ctx, c, OrganizationEndpointPrefix, orgSearch, nil)
+	if orgErr != nil {
+		return QueryResponse{}, orgErr
+	}
+	if orgResponse.Subtotal != 1 {
+		return QueryResponse{}, fmt.Errorf(
+			"Repository path [%s] matched [%d] organizations, expected exactly 1",
+			path,
+			orgResponse.Subtotal,
+		)
+	}
+	org := orgResponse.Results[0].(ForemanObject)
+
+	productParams := url.Values{}
+	productParams.Set("organization_id", strconv.Itoa(org.Id))
+	productResponse, productErr := SearchAll[ForemanKatelloProduct](
+		ctx, c, KatelloProductEndpointPrefix, SearchBy("name", parts[1]), productParams,
+	)
+	if productErr != nil {
+		return QueryResponse{}, productErr
+	}
+	if productResponse.Subtotal != 1 {
+		return QueryResponse{}, fmt.Errorf(
+			"Repository path [%s] matched [%d] products, expected exactly 1",
+			path,
+			productResponse.Subtotal,
+		)
+	}
+	product := productResponse.Results[0].(ForemanKatelloProduct)
+
+	return c.QueryKatelloRepository(ctx, &ForemanKatelloRepository{
+		ForemanObject: ForemanObject{Name: parts[2]},
+		ProductId:     product.Id,
+	})
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// QueryKatelloRepositoryByPath
+// ----------------------------------------------------------------------------
+
+// Ensures the repository path is resolved through the organization and the
+// product of the repository
+func TestQueryKatelloRepositoryByPath(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	requests := map[string]url.Values{}
+	mux.HandleFunc("/api/organizations", func(w http.ResponseWriter, r *http.Request) {
+		requests["organizations"] = r.URL.Query()
+		w.Write([]byte(`{"subtotal": 1, "results": [{"id": 3, "name": "ACME"}]}`))
+	})
+	mux.HandleFunc("/katello/api/products", func(w http.ResponseWriter, r *http.Request) {
+		requests["products"] = r.URL.Query()
+		w.Write([]byte(`{"subtotal": 1, "results": [{"id": 12, "name": "CentOS 7"}]}`))
+	})
+	mux.HandleFunc("/katello/api/repositories", func(w http.ResponseWriter, r *http.Request) {
+		requests["repositories"] = r.URL.Query()
+		w.Write([]byte(`{"subtotal": 1, "results": [{"id": 42, "name": "os", "product_id": 12}]}`))
+	})
+
+	queryResponse, err := client.QueryKatelloRepositoryByPath(context.TODO(), "ACME/CentOS 7/os")
+	if err != nil {
+		t.Fatalf("QueryKatelloRepositoryByPath returned error [%s]", err)
+	}
+
+	expected := map[string]map[string]string{
+		"organizations": {"search": `name = "ACME" or label = "ACME"`},
+		"products":      {"search": `name = "CentOS 7"`, "organization_id": "3"},
+		"repositories":  {"search": `name = "os"`, "product_id": "12"},
+	}
+	for endpoint, params := range expected {
+		for key, value := range params {
+			if requests[endpoint].Get(key) != value {
+				t.Fatalf(
+					"QueryKatelloRepositoryByPath sent wrong parameter [%s] to [%s]. "+
+						"Expected [%s], got [%s]",
+					key,
+					endpoint,
+					value,
+					requests[endpoint].Get(key),
+				)
+			}
+		}
+	}
+
+	if len(queryResponse.Results) != 1 || queryResponse.Results[0].(ForemanKatelloRepository).Id != 42 {
+		t.Fatalf("QueryKatelloRepositoryByPath did not return the repository, got [%+v]", queryResponse.Results)
+	}
+}
+
+// Ensures paths which are not of the form org/product/repo are rejected
+// without querying Foreman
+func TestQueryKatelloRepositoryByPath_InvalidPath(t *testing.T) {
+	client := &Client{}
+
+	for _, path := range []string{"os", "CentOS 7/os", "ACME/CentOS/7/os"} {
+		_, err := client.QueryKatelloRepositoryByPath(context.TODO(), path)
+		if err == nil {
+			t.Fatalf("QueryKatelloRepositoryByPath did not return an error for path [%s]", path)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// RoleEndpointPrefix : Prefix appended to API url for roles
+	RoleEndpointPrefix = "roles"
+
+	// DefaultRoleName is the name of the role implicitly assigned to all
+	// users and usergroups
+	DefaultRoleName = "Default role"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanRole API model represents a role.  A role is a set of filters
+// granting permissions, which is assigned to users and usergroups.
+type ForemanRole struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Description of the role
+	Description string
+	// Whether the role is built into Foreman: 0 for roles created by users,
+	// 1 for the "Default role" and 2 for the roles shipped with Foreman and
+	// its plugins
+	Builtin int
+	// Plugin the role originates from, ie: "foreman_remote_execution"
+	Origin string
+	// ID of the role this role was cloned from
+	ClonedFromId int
+	// IDs of the locations the role is limited to
+	LocationIds []int
+	// IDs of the organizations the role is limited to
+	OrganizationIds []int
+}
+
+// foremanRoleJSON is the API response of a role
+type foremanRoleJSON struct {
+	Description   string          `json:"description"`
+	Builtin       int             `json:"builtin"`
+	Origin        string          `json:"origin"`
+	ClonedFromId  int             `json:"cloned_from_id"`
+	Locations     []ForemanObject `json:"locations"`
+	Organizations []ForemanObject `json:"organizations"`
+}
+
+// Implement the Marshaler interface
+func (r ForemanRole) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/role.go#MarshalJSON")
+
+	rMap := map[string]interface{}{
+		"name":        r.Name,
+		"description": r.Description,
+	}
+	if r.LocationIds != nil {
+		rMap["location_ids"] = r.LocationIds
+	}
+	if r.OrganizationIds != nil {
+		rMap["organization_ids"] = r.OrganizationIds
+	}
+
+	log.Debugf("rMap: [%v]", rMap)
+
+	return json.Marshal(rMap)
+}
+
+// Implement the Unmarshaler interface
+func (r *ForemanRole) UnmarshalJSON(b []byte) error {
+	var fo ForemanObject
+	if jsonDecErr := json.Unmarshal(b, &fo); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	r.ForemanObject = fo
+
+	var rJSON foremanRoleJSON
+	if jsonDecErr := json.Unmarshal(b, &rJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	r.Description = rJSON.Description
+	r.Builtin = rJSON.Builtin
+	r.Origin = rJSON.Origin
+	r.ClonedFromId = rJSON.ClonedFromId
+	r.LocationIds = foremanObjectArrayToIdIntArray(rJSON.Locations)
+	r.OrganizationIds = foremanObjectArrayToIdIntArray(rJSON.Organizations)
+
+	return nil
+}
+
+// roleIds returns the IDs of the roles assigned to a user or usergroup.  The
+// default role can not be assigned explicitly and is skipped.
+func roleIds(roles []ForemanObject) []int {
+	ids := make([]int, 0, len(roles))
+	for _, r := range roles {
+		if r.Name != DefaultRoleName {
+			ids = append(ids, r.Id)
+		}
+	}
+	return ids
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateRole creates a new ForemanRole with the attributes of the supplied
+// ForemanRole reference and returns the created ForemanRole reference.  If
+// ClonedFromId is set, the role is created as a clone of that role including
+// its filters.  The returned reference will have its ID and other API
+// default values set by this function.
+func (c *Client) CreateRole(ctx context.Context, r *ForemanRole) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", RoleEndpointPrefix)
+	if r.ClonedFromId > 0 {
+		reqEndpoint = fmt.Sprintf("/%s/%d/clone", RoleEndpointPrefix, r.ClonedFromId)
+	}
+
+	rJSONBytes, jsonEncErr := c.WrapJSON("role", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("roleJSONBytes: [%s]", rJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(rJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdRole ForemanRole
+	sendErr := c.SendAndParse(req, &createdRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdRole: [%+v]", createdRole)
+
+	return &createdRole, nil
+}
+
+// ReadRole reads the attributes of a ForemanRole identified by the supplied
+// ID and returns a ForemanRole reference.
+func (c *Client) ReadRole(ctx context.Context, id int) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readRole ForemanRole
+	sendErr := c.SendAndParse(req, &readRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readRole: [%+v]", readRole)
+
+	return &readRole, nil
+}
+
+// UpdateRole updates a ForemanRole's attributes.  The role with the ID of
+// the supplied ForemanRole will be updated. A new ForemanRole reference is
+// returned with the attributes from the result of the update operation.
+func (c *Client) UpdateRole(ctx context.Context, r *ForemanRole) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, r.Id)
+
+	rJSONBytes, jsonEncErr := c.WrapJSON("role", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("roleJSONBytes: [%s]", rJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(rJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedRole ForemanRole
+	sendErr := c.SendAndParse(req, &updatedRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedRole: [%+v]", updatedRole)
+
+	return &updatedRole, nil
+}
+
+// DeleteRole deletes the ForemanRole identified by the supplied ID.  Foreman
+// deletes the filters of the role with it.
+func (c *Client) DeleteRole(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/role.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryRole queries for a ForemanRole based on the name of the supplied
+// ForemanRole reference and returns a QueryResponse struct containing
+// query/response metadata and the matching roles.
+func (c *Client) QueryRole(ctx context.Context, r *ForemanRole) (QueryResponse, error) {
+	log.Tracef("foreman/api/role.go#Search")
+
+	return SearchAll[ForemanRole](ctx, c, RoleEndpointPrefix, SearchBy("name", r.Name), nil)
+}
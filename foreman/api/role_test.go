@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// Ensures roles are decoded from the API response with the taxonomies
+// converted to ID lists
+func TestForemanRole_UnmarshalJSON(t *testing.T) {
+	data, err := os.ReadFile("../testdata/3.11/roles/read_response.json")
+	if err != nil {
+		t.Fatalf("Reading the fixture failed: [%s]", err)
+	}
+
+	var r ForemanRole
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+
+	if r.Id != 21 || r.Name != "Web host operator" || r.ClonedFromId != 3 || r.Builtin != 0 || r.Origin != "" {
+		t.Fatalf("UnmarshalJSON did not decode the role, got [%+v]", r)
+	}
+	if !reflect.DeepEqual(r.LocationIds, []int{5}) || !reflect.DeepEqual(r.OrganizationIds, []int{7}) {
+		t.Fatalf("UnmarshalJSON did not decode the taxonomies, got [%+v]", r)
+	}
+}
+
+// Ensures filters are decoded from the API response, including the boolean
+// attributes with a trailing question mark and the resource type of the
+// permissions
+func TestForemanFilter_UnmarshalJSON(t *testing.T) {
+	data, err := os.ReadFile("../testdata/3.11/filters/read_response.json")
+	if err != nil {
+		t.Fatalf("Reading the fixture failed: [%s]", err)
+	}
+
+	var f ForemanFilter
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+
+	expected := ForemanFilter{
+		ForemanObject: ForemanObject{
+			Id:        310,
+			CreatedAt: "2024-05-13 09:12:41 UTC",
+			UpdatedAt: "2024-05-13 09:12:41 UTC",
+		},
+		RoleId:          21,
+		Search:          "hostgroup_title ~ web/*",
+		Override:        true,
+		Unlimited:       false,
+		ResourceType:    "Host",
+		PermissionIds:   []int{74, 80},
+		LocationIds:     []int{5},
+		OrganizationIds: []int{},
+	}
+	if !reflect.DeepEqual(f, expected) {
+		t.Fatalf("Expected [%+v], got [%+v]", expected, f)
+	}
+
+	var unlimited ForemanFilter
+	if err := json.Unmarshal([]byte(`{"id": 1, "search": null, "role": {"id": 2}}`), &unlimited); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+	if unlimited.Search != "" || !unlimited.Unlimited || unlimited.RoleId != 2 {
+		t.Fatalf("UnmarshalJSON did not decode the unlimited filter, got [%+v]", unlimited)
+	}
+}
+
+// Ensures the taxonomies of a filter are only sent if it overrides the ones
+// of its role
+func TestForemanFilter_MarshalJSON(t *testing.T) {
+	f := ForemanFilter{
+		RoleId:        21,
+		PermissionIds: []int{74},
+		LocationIds:   []int{5},
+	}
+
+	data, _ := json.Marshal(f)
+	var m map[string]interface{}
+	json.Unmarshal(data, &m)
+	if _, ok := m["location_ids"]; ok {
+		t.Fatalf("Expected no location_ids without override, got [%s]", data)
+	}
+
+	f.Override = true
+	data, _ = json.Marshal(f)
+	m = nil
+	json.Unmarshal(data, &m)
+	if !reflect.DeepEqual(m["location_ids"], []interface{}{float64(5)}) {
+		t.Fatalf("Expected location_ids with override, got [%s]", data)
+	}
+}
+
+// Ensures the roles of users and usergroups are decoded into ID lists
+// without the implicitly assigned default role
+func TestForemanUser_UnmarshalJSON_Roles(t *testing.T) {
+	data := []byte(`{
+		"id": 4,
+		"login": "jdoe",
+		"auth_source_id": 1,
+		"roles": [
+			{"id": 1, "name": "Default role"},
+			{"id": 21, "name": "Web host operator"}
+		]
+	}`)
+
+	var u ForemanUser
+	if err := json.Unmarshal(data, &u); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+	if u.Id != 4 || u.Login != "jdoe" || u.AuthSourceId != 1 {
+		t.Fatalf("UnmarshalJSON did not decode the user, got [%+v]", u)
+	}
+	if !reflect.DeepEqual(u.RoleIds, []int{21}) {
+		t.Fatalf("Expected [%v], got [%v]", []int{21}, u.RoleIds)
+	}
+
+	var ug ForemanUsergroup
+	if err := json.Unmarshal(data, &ug); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+	if !reflect.DeepEqual(ug.RoleIds, []int{21}) {
+		t.Fatalf("Expected [%v], got [%v]", []int{21}, ug.RoleIds)
+	}
+}
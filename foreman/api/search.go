@@ -0,0 +1,271 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// Number of results requested per page by SearchAll
+	SearchPerPage = 100
+)
+
+// ----------------------------------------------------------------------------
+// Scoped Search Query Builder
+// ----------------------------------------------------------------------------
+
+// SearchOperator is a comparison operator of the Foreman scoped search
+// syntax, which is used in the "search" parameter of all index endpoints.
+type SearchOperator string
+
+const (
+	// The field's value is exactly the supplied value
+	SearchEqual SearchOperator = "="
+	// The field's value contains the supplied value (SQL LIKE)
+	SearchLike SearchOperator = "~"
+	// The field's value is one of the supplied values
+	SearchIn SearchOperator = "^"
+	// The field's value is greater than the supplied value
+	SearchGreater SearchOperator = ">"
+)
+
+// Logical operators used to join the terms of a SearchQuery
+const (
+	searchAnd = "and"
+	searchOr  = "or"
+)
+
+// SearchQuery builds a Foreman scoped search expression from typed terms.
+// All values are quoted and escaped, so user input containing quotes,
+// whitespace or scoped search keywords cannot alter the expression.
+//
+// The zero value is an empty query and ready to use.  Terms are joined in
+// the order they are added, without implicit grouping.  Use AndQuery and
+// OrQuery to group sub-expressions in parentheses.
+type SearchQuery struct {
+	terms []string
+}
+
+// NewSearchQuery returns an empty SearchQuery
+func NewSearchQuery() *SearchQuery {
+	return &SearchQuery{}
+}
+
+// SearchBy is a shorthand for a query matching a single field exactly.  It is
+// the most common lookup performed by the Query* functions.
+func SearchBy(field string, value string) *SearchQuery {
+	return NewSearchQuery().And(field, SearchEqual, value)
+}
+
+// And joins a comparison term to the query with a logical AND.  The SearchIn
+// operator accepts any number of values, all other operators only use the
+// first value.
+func (q *SearchQuery) And(field string, op SearchOperator, values ...string) *SearchQuery {
+	return q.join(searchAnd, searchTerm(field, op, values))
+}
+
+// Or joins a comparison term to the query with a logical OR.  See And for
+// the handling of the values.
+func (q *SearchQuery) Or(field string, op SearchOperator, values ...string) *SearchQuery {
+	return q.join(searchOr, searchTerm(field, op, values))
+}
+
+// AndQuery joins the supplied query, enclosed in parentheses, with a logical
+// AND.  Empty sub-queries are ignored.
+func (q *SearchQuery) AndQuery(sub *SearchQuery) *SearchQuery {
+	if sub.IsEmpty() {
+		return q
+	}
+	return q.join(searchAnd, "("+sub.String()+")")
+}
+
+// OrQuery joins the supplied query, enclosed in parentheses, with a logical
+// OR.  Empty sub-queries are ignored.
+func (q *SearchQuery) OrQuery(sub *SearchQuery) *SearchQuery {
+	if sub.IsEmpty() {
+		return q
+	}
+	return q.join(searchOr, "("+sub.String()+")")
+}
+
+// Raw joins a user supplied scoped search expression, enclosed in
+// parentheses, with a logical AND.  The expression is sent to Foreman as-is.
+// Empty expressions are ignored.
+func (q *SearchQuery) Raw(expr string) *SearchQuery {
+	if strings.TrimSpace(expr) == "" {
+		return q
+	}
+	return q.join(searchAnd, "("+expr+")")
+}
+
+// IsEmpty returns true if no terms have been added to the query
+func (q *SearchQuery) IsEmpty() bool {
+	return q == nil || len(q.terms) == 0
+}
+
+// String renders the query in the scoped search syntax, ready to be used as
+// the value of the "search" URL parameter.
+func (q *SearchQuery) String() string {
+	if q.IsEmpty() {
+		return ""
+	}
+	return strings.Join(q.terms, " ")
+}
+
+// join appends a term to the query, preceded by the logical operator unless
+// it is the first term.
+func (q *SearchQuery) join(logicalOp string, term string) *SearchQuery {
+	if len(q.terms) > 0 {
+		q.terms = append(q.terms, logicalOp)
+	}
+	q.terms = append(q.terms, term)
+	return q
+}
+
+// searchTerm renders a single "field op value" comparison
+func searchTerm(field string, op SearchOperator, values []string) string {
+	if op == SearchIn {
+		quoted := make([]string, len(values))
+		for idx, val := range values {
+			quoted[idx] = quoteSearchValue(val)
+		}
+		return field + " " + string(op) + " (" + strings.Join(quoted, ", ") + ")"
+	}
+
+	value := ""
+	if len(values) > 0 {
+		value = values[0]
+	}
+	return field + " " + string(op) + " " + quoteSearchValue(value)
+}
+
+// quoteSearchValue encloses a value in double quotes.  Backslashes and double
+// quotes inside the value are escaped with a backslash, which the scoped
+// search tokenizer removes again.
+func quoteSearchValue(value string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+	return `"` + escaped + `"`
+}
+
+// ----------------------------------------------------------------------------
+// Paginated Search
+// ----------------------------------------------------------------------------
+
+// searchPage is a single page of an index endpoint response.  The results
+// are kept raw, since some endpoints (ie: puppet classes) return a map of
+// arrays instead of a plain array.
+type searchPage struct {
+	QueryResponse
+	Results json.RawMessage `json:"results"`
+}
+
+// items returns the raw JSON objects of the page's results
+func (p searchPage) items() ([]json.RawMessage, error) {
+	var items []json.RawMessage
+	trimmed := strings.TrimSpace(string(p.Results))
+	if trimmed == "" || trimmed == "null" {
+		return items, nil
+	}
+
+	if !strings.HasPrefix(trimmed, "{") {
+		err := json.Unmarshal(p.Results, &items)
+		return items, err
+	}
+
+	// Results wrapped in a hash of arrays, see QueryResponsePuppet
+	var grouped map[string][]json.RawMessage
+	if err := json.Unmarshal(p.Results, &grouped); err != nil {
+		return nil, err
+	}
+	for _, group := range grouped {
+		items = append(items, group...)
+	}
+	return items, nil
+}
+
+// SearchAll queries the index endpoint with the supplied search and follows
+// the pagination until all matching objects are retrieved.  The results are
+// decoded into T and returned as the Results of the QueryResponse, the
+// Subtotal is set to the number of results.
+//
+// An empty or nil search returns all objects of the endpoint.  Additional
+// URL parameters (ie: organization_id for Katello endpoints) can be supplied
+// with params.
+func SearchAll[T any](ctx context.Context, c *Client, endpoint string, search *SearchQuery, params url.Values) (QueryResponse, error) {
+	log.Tracef("foreman/api/search.go#SearchAll")
+
+	queryResponse := QueryResponse{}
+	results := []interface{}{}
+
+	for page := 1; ; page++ {
+		req, reqErr := c.NewRequestWithContext(
+			ctx,
+			http.MethodGet,
+			endpoint,
+			nil,
+		)
+		if reqErr != nil {
+			return queryResponse, reqErr
+		}
+
+		reqQuery := req.URL.Query()
+		for key, values := range params {
+			for _, value := range values {
+				reqQuery.Add(key, value)
+			}
+		}
+		if !search.IsEmpty() {
+			reqQuery.Set("search", search.String())
+		}
+		reqQuery.Set("page", strconv.Itoa(page))
+		reqQuery.Set("per_page", strconv.Itoa(SearchPerPage))
+		req.URL.RawQuery = reqQuery.Encode()
+
+		var respPage searchPage
+		sendErr := c.SendAndParse(req, &respPage)
+		if sendErr != nil {
+			return queryResponse, sendErr
+		}
+
+		log.Debugf("respPage: [%+v]", respPage.QueryResponse)
+
+		items, itemsErr := respPage.items()
+		if itemsErr != nil {
+			return queryResponse, itemsErr
+		}
+		for _, item := range items {
+			var result T
+			if jsonDecErr := json.Unmarshal(item, &result); jsonDecErr != nil {
+				return queryResponse, jsonDecErr
+			}
+			results = append(results, result)
+		}
+
+		if page == 1 {
+			queryResponse = respPage.QueryResponse
+		}
+
+		// Endpoints that do not report the number of matching objects are
+		// read until a page comes back short
+		if len(items) == 0 {
+			break
+		} else if respPage.Subtotal > 0 && len(results) >= respPage.Subtotal {
+			break
+		} else if respPage.Subtotal == 0 && len(items) < SearchPerPage {
+			break
+		}
+	}
+
+	queryResponse.Page = 0
+	queryResponse.PerPage = 0
+	queryResponse.Subtotal = len(results)
+	queryResponse.Results = results
+
+	return queryResponse, nil
+}
@@ -0,0 +1,320 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// quoteSearchValue
+// ----------------------------------------------------------------------------
+
+// Ensures values are quoted and embedded quotes/backslashes are escaped
+func TestQuoteSearchValue(t *testing.T) {
+	testCases := []struct {
+		Value    string
+		Expected string
+	}{
+		{Value: "", Expected: `""`},
+		{Value: "example.com", Expected: `"example.com"`},
+		{Value: "base/web servers", Expected: `"base/web servers"`},
+		{Value: `say "hi"`, Expected: `"say \"hi\""`},
+		{Value: `C:\temp`, Expected: `"C:\\temp"`},
+		{Value: `\"`, Expected: `"\\\""`},
+		{Value: "a and b or c", Expected: `"a and b or c"`},
+	}
+
+	for _, testCase := range testCases {
+		output := quoteSearchValue(testCase.Value)
+		if output != testCase.Expected {
+			t.Fatalf(
+				"quoteSearchValue did not return correct value. "+
+					"Expected [%s], got [%s] for input [%s]",
+				testCase.Expected,
+				output,
+				testCase.Value,
+			)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// SearchQuery
+// ----------------------------------------------------------------------------
+
+// Ensures the SearchQuery renders terms, operators and groups correctly
+func TestSearchQuery_String(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Query    *SearchQuery
+		Expected string
+	}{
+		{
+			Name:     "empty",
+			Query:    NewSearchQuery(),
+			Expected: "",
+		},
+		{
+			Name:     "nil",
+			Query:    nil,
+			Expected: "",
+		},
+		{
+			Name:     "search by",
+			Query:    SearchBy("name", "example.com"),
+			Expected: `name = "example.com"`,
+		},
+		{
+			Name:     "like",
+			Query:    NewSearchQuery().And("name", SearchLike, "web"),
+			Expected: `name ~ "web"`,
+		},
+		{
+			Name:     "greater",
+			Query:    NewSearchQuery().And("id", SearchGreater, "10"),
+			Expected: `id > "10"`,
+		},
+		{
+			Name:     "in",
+			Query:    NewSearchQuery().And("name", SearchIn, "a", "b c", `d"`),
+			Expected: `name ^ ("a", "b c", "d\"")`,
+		},
+		{
+			Name:     "in without values",
+			Query:    NewSearchQuery().And("name", SearchIn),
+			Expected: `name ^ ()`,
+		},
+		{
+			Name:     "missing value",
+			Query:    NewSearchQuery().And("name", SearchEqual),
+			Expected: `name = ""`,
+		},
+		{
+			Name: "and",
+			Query: NewSearchQuery().
+				And("firstname", SearchEqual, "Louis").
+				And("lastname", SearchEqual, "Jansens"),
+			Expected: `firstname = "Louis" and lastname = "Jansens"`,
+		},
+		{
+			Name: "or",
+			Query: NewSearchQuery().
+				Or("name", SearchEqual, "a").
+				Or("name", SearchEqual, "b"),
+			Expected: `name = "a" or name = "b"`,
+		},
+		{
+			Name: "grouped",
+			Query: SearchBy("organization", "ACME").AndQuery(
+				SearchBy("name", "a").Or("name", SearchLike, "b"),
+			),
+			Expected: `organization = "ACME" and (name = "a" or name ~ "b")`,
+		},
+		{
+			Name: "or grouped",
+			Query: SearchBy("name", "a").OrQuery(
+				SearchBy("name", "b").And("id", SearchGreater, "3"),
+			),
+			Expected: `name = "a" or (name = "b" and id > "3")`,
+		},
+		{
+			Name:     "empty group",
+			Query:    SearchBy("name", "a").AndQuery(NewSearchQuery()).OrQuery(nil),
+			Expected: `name = "a"`,
+		},
+		{
+			Name:     "injection",
+			Query:    SearchBy("name", `x" or name ~ "`),
+			Expected: `name = "x\" or name ~ \""`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		output := testCase.Query.String()
+		if output != testCase.Expected {
+			t.Fatalf(
+				"SearchQuery.String did not return correct value for test case "+
+					"[%s]. Expected [%s], got [%s]",
+				testCase.Name,
+				testCase.Expected,
+				output,
+			)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Query* functions
+// ----------------------------------------------------------------------------
+
+// Ensures the Query* functions send the search built from the supplied
+// object's attributes and no search without attributes
+func TestQuery_SearchParameter(t *testing.T) {
+	testCases := []struct {
+		Endpoint string
+		Query    func(c *Client) error
+		Expected string
+	}{
+		{
+			Endpoint: "/api/domains",
+			Query: func(c *Client) error {
+				_, err := c.QueryDomain(context.TODO(), &ForemanDomain{
+					ForemanObject: ForemanObject{Name: `dev "dc1".com`},
+				})
+				return err
+			},
+			Expected: `name = "dev \"dc1\".com"`,
+		},
+		{
+			Endpoint: "/api/hostgroups",
+			Query: func(c *Client) error {
+				_, err := c.QueryHostgroup(context.TODO(), &ForemanHostgroup{
+					Title: "base/web servers",
+				})
+				return err
+			},
+			Expected: `title = "base/web servers"`,
+		},
+		{
+			Endpoint: "/api/subnets",
+			Query: func(c *Client) error {
+				_, err := c.QuerySubnet(context.TODO(), &ForemanSubnet{
+					Network: "10.0.0.0",
+				})
+				return err
+			},
+			Expected: `network = "10.0.0.0"`,
+		},
+		{
+			Endpoint: "/api/users",
+			Query: func(c *Client) error {
+				_, err := c.QueryUser(context.TODO(), &ForemanUser{
+					Lastname: "Jansens",
+					Mail:     "test@example.com",
+				})
+				return err
+			},
+			Expected: `lastname = "Jansens" and mail = "test@example.com"`,
+		},
+		{
+			Endpoint: "/api/users",
+			Query: func(c *Client) error {
+				_, err := c.QueryUser(context.TODO(), &ForemanUser{})
+				return err
+			},
+			Expected: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+
+		var search string
+		var hasSearch bool
+		mux.HandleFunc(testCase.Endpoint, func(w http.ResponseWriter, r *http.Request) {
+			search = r.URL.Query().Get("search")
+			hasSearch = r.URL.Query().Has("search")
+			w.Write([]byte(`{"total": 0, "subtotal": 0, "results": []}`))
+		})
+
+		err := testCase.Query(client)
+		server.Close()
+		if err != nil {
+			t.Fatalf("Query for [%s] returned error [%s]", testCase.Endpoint, err)
+		}
+		if search != testCase.Expected || hasSearch != (testCase.Expected != "") {
+			t.Fatalf(
+				"Query for [%s] did not send the correct search. "+
+					"Expected [%s], got [%s]",
+				testCase.Endpoint,
+				testCase.Expected,
+				search,
+			)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// SearchAll
+// ----------------------------------------------------------------------------
+
+// Ensures SearchAll follows the pagination until all results are retrieved
+// and decodes them into the requested type
+func TestSearchAll_Pagination(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	total := SearchPerPage + 5
+	var pages []string
+	mux.HandleFunc("/api/domains", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pages = append(pages, r.URL.Query().Get("page"))
+
+		results := []string{}
+		for id := (page-1)*SearchPerPage + 1; id <= page*SearchPerPage && id <= total; id++ {
+			results = append(results, fmt.Sprintf(`{"id": %d, "name": "domain%d"}`, id, id))
+		}
+		fmt.Fprintf(
+			w,
+			`{"total": %d, "subtotal": %d, "page": %d, "per_page": %d, "results": [%s]}`,
+			total, total, page, SearchPerPage, strings.Join(results, ","),
+		)
+	})
+
+	queryResponse, err := SearchAll[ForemanDomain](context.TODO(), client, DomainEndpointPrefix, nil, nil)
+	if err != nil {
+		t.Fatalf("SearchAll returned error [%s]", err)
+	}
+
+	if len(pages) != 2 {
+		t.Fatalf("SearchAll did not request all pages. Expected [2], got [%v]", pages)
+	}
+	if queryResponse.Subtotal != total || len(queryResponse.Results) != total {
+		t.Fatalf(
+			"SearchAll did not return all results. Expected [%d], got subtotal [%d] and [%d] results",
+			total,
+			queryResponse.Subtotal,
+			len(queryResponse.Results),
+		)
+	}
+	last, ok := queryResponse.Results[total-1].(ForemanDomain)
+	if !ok || last.Id != total || last.Name != fmt.Sprintf("domain%d", total) {
+		t.Fatalf("SearchAll did not decode the results, got [%+v]", queryResponse.Results[total-1])
+	}
+}
+
+// Ensures SearchAll flattens results which are grouped in a hash, as returned
+// by the puppet classes endpoint
+func TestSearchAll_GroupedResults(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	var params url.Values
+	mux.HandleFunc("/foreman_puppet/api/puppetclasses", func(w http.ResponseWriter, r *http.Request) {
+		params = r.URL.Query()
+		w.Write([]byte(`{"total": 3, "subtotal": 3, "results": {
+			"apache": [{"id": 1, "name": "apache"}, {"id": 2, "name": "apache::mod"}],
+			"ntp": [{"id": 3, "name": "ntp"}]
+		}}`))
+	})
+
+	search := SearchBy("environment", "production")
+	queryResponse, err := SearchAll[ForemanPuppetClass](
+		context.TODO(), client, PuppetClassEndpointPrefix, search, url.Values{"organization_id": {"4"}},
+	)
+	if err != nil {
+		t.Fatalf("SearchAll returned error [%s]", err)
+	}
+
+	if len(queryResponse.Results) != 3 {
+		t.Fatalf("SearchAll did not flatten the results. Expected [3], got [%d]", len(queryResponse.Results))
+	}
+	if params.Get("search") != search.String() || params.Get("organization_id") != "4" {
+		t.Fatalf("SearchAll did not send the search and parameters, got [%s]", params.Encode())
+	}
+}
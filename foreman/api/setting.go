@@ -1,10 +1,13 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 )
@@ -54,6 +57,63 @@ type ForemanSetting struct {
 	SettingsType string `json:"settings_type"`
 }
 
+// ParseSettingValue converts the string representation of a setting's value
+// into the type of the setting, as expected by the settings API.  Booleans and
+// integers are parsed, arrays and hashes are expected in JSON notation.  All
+// other types are passed as string.
+func ParseSettingValue(settingsType string, value string) (interface{}, error) {
+	switch settingsType {
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("Value [%s] of a boolean setting must be true or false", value)
+		}
+		return b, nil
+	case "integer":
+		i, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("Value [%s] of an integer setting is not an integer", value)
+		}
+		return i, nil
+	case "array":
+		var a []interface{}
+		if err := json.Unmarshal([]byte(value), &a); err != nil {
+			return nil, fmt.Errorf("Value [%s] of an array setting must be a JSON array: %w", value, err)
+		}
+		return a, nil
+	case "hash":
+		var h map[string]interface{}
+		if err := json.Unmarshal([]byte(value), &h); err != nil {
+			return nil, fmt.Errorf("Value [%s] of a hash setting must be a JSON object: %w", value, err)
+		}
+		return h, nil
+	}
+	return value, nil
+}
+
+// FormatSettingValue returns the string representation of a setting's value
+// as decoded from the API.  Strings are returned as is, arrays and hashes in
+// JSON notation and a missing value as empty string.
+func FormatSettingValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
 // ReadSetting reads the attributes of a ForemanSetting identified by the supplied
 // ID and returns a ForemanSetting reference.
 func (c *Client) ReadSetting(ctx context.Context, id string) (*ForemanSetting, error) {
@@ -104,8 +164,7 @@ func (c *Client) QuerySetting(ctx context.Context, d *ForemanSetting) (QueryResp
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", d.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
@@ -138,3 +197,40 @@ func (c *Client) QuerySetting(ctx context.Context, d *ForemanSetting) (QueryResp
 
 	return queryResponse, nil
 }
+
+// UpdateSetting sets the value of the ForemanSetting identified by the
+// supplied ID.  The value must already have the type of the setting, see
+// ParseSettingValue.  A new ForemanSetting reference is returned with the
+// attributes from the result of the update operation.
+func (c *Client) UpdateSetting(ctx context.Context, id string, value interface{}) (*ForemanSetting, error) {
+	log.Tracef("foreman/api/setting.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%s", SettingEndpointPrefix, id)
+
+	sJSONBytes, jsonEncErr := c.WrapJSON("setting", map[string]interface{}{"value": value})
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("settingJSONBytes: [%s]", sJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(sJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedSetting ForemanSetting
+	sendErr := c.SendAndParse(req, &updatedSetting)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedSetting: [%+v]", updatedSetting)
+
+	return &updatedSetting, nil
+}
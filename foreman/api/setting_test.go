@@ -0,0 +1,42 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Ensures setting values are converted to the type of the setting and back
+// to their string representation
+func TestSettingValue_ParseAndFormat(t *testing.T) {
+	testCases := []struct {
+		SettingsType string
+		Value        string
+		Expected     interface{}
+	}{
+		{"boolean", "true", true},
+		{"integer", "360", 360},
+		{"array", `["a","b"]`, []interface{}{"a", "b"}},
+		{"hash", `{"a":"b"}`, map[string]interface{}{"a": "b"}},
+		{"string", "https://foreman.example.com", "https://foreman.example.com"},
+	}
+
+	for _, testCase := range testCases {
+		value, err := ParseSettingValue(testCase.SettingsType, testCase.Value)
+		if err != nil {
+			t.Fatalf("ParseSettingValue returned error [%s]", err)
+		}
+		if !reflect.DeepEqual(value, testCase.Expected) {
+			t.Fatalf("Expected [%#v], got [%#v]", testCase.Expected, value)
+		}
+		if formatted := FormatSettingValue(value); formatted != testCase.Value {
+			t.Fatalf("Expected [%s], got [%s]", testCase.Value, formatted)
+		}
+	}
+
+	if _, err := ParseSettingValue("integer", "one"); err == nil {
+		t.Fatalf("ParseSettingValue accepted a string for an integer setting")
+	}
+	if formatted := FormatSettingValue(float64(360)); formatted != "360" {
+		t.Fatalf("Expected [360], got [%s]", formatted)
+	}
+}
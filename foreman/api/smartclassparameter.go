@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,7 +11,7 @@ import (
 )
 
 const (
-	SmartClassParameterEndpointPrefix      = "puppet/smarts_class_paramaters"
+	SmartClassParameterEndpointPrefix      = "puppet/smart_class_parameters"
 	SmartClassParameterQueryEndpointPrefix = "puppet/puppetclasses/%d/smart_class_parameters"
 )
 
@@ -18,7 +19,21 @@ const (
 // Struct Definition and Helpers
 // -----------------------------------------------------------------------------
 
-// The ForemanSmartClassParameter API model represents a smart class parameter
+// SmartClassParameterTypes are the supported types of smart class parameters
+var SmartClassParameterTypes = []string{
+	"string",
+	"boolean",
+	"integer",
+	"real",
+	"array",
+	"hash",
+	"yaml",
+	"json",
+}
+
+// The ForemanSmartClassParameter API model represents a smart class parameter.
+// Smart class parameters are imported with their puppet class, they can only
+// be read and updated.
 type ForemanSmartClassParameter struct {
 	// Inherits the base object's attributes
 	ForemanObject
@@ -27,6 +42,104 @@ type ForemanSmartClassParameter struct {
 	Parameter string `json:"parameter"`
 	// ID of the owning puppet class
 	PuppetClassId int `json:"puppetclass_id"`
+
+	// Description of the parameter, usually taken from the puppet class
+	Description string `json:"description"`
+	// Whether the value is managed by Foreman instead of the puppet class
+	Override bool `json:"override"`
+	// Type of the parameter, one of SmartClassParameterTypes
+	ParameterType string `json:"parameter_type"`
+	// Default value of the parameter.  Values of other types than string are
+	// kept in their JSON notation.
+	DefaultValue string `json:"default_value"`
+	// Whether the value is hidden in the UI
+	HiddenValue bool `json:"hidden_value"`
+	// Whether the parameter is omitted from the classification output
+	Omit bool `json:"omit"`
+	// Whether a value is required
+	Required bool `json:"required"`
+	// Type of the validator, either "regexp" or "list"
+	ValidatorType string `json:"validator_type"`
+	// Rule of the validator, ie: the regular expression or comma separated
+	// list of valid values
+	ValidatorRule string `json:"validator_rule"`
+	// Order of the matchers the override values are looked up with, one
+	// matcher per line
+	OverrideValueOrder string `json:"override_value_order"`
+	// Whether the values of all matching overrides are merged, only
+	// supported for arrays and hashes
+	MergeOverrides bool `json:"merge_overrides"`
+	// Whether the default value is merged as well
+	MergeDefault bool `json:"merge_default"`
+	// Whether duplicates are removed from merged arrays
+	AvoidDuplicates bool `json:"avoid_duplicates"`
+}
+
+// Implement the Marshaler interface.  The parameter and its puppet class are
+// defined by the puppet class import, they are not sent.
+func (p ForemanSmartClassParameter) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/smartclassparameter.go#MarshalJSON")
+
+	pMap := map[string]interface{}{
+		"override":         p.Override,
+		"parameter_type":   p.ParameterType,
+		"default_value":    p.DefaultValue,
+		"hidden_value":     p.HiddenValue,
+		"omit":             p.Omit,
+		"required":         p.Required,
+		"validator_type":   p.ValidatorType,
+		"validator_rule":   p.ValidatorRule,
+		"merge_overrides":  p.MergeOverrides,
+		"merge_default":    p.MergeDefault,
+		"avoid_duplicates": p.AvoidDuplicates,
+	}
+	// The description is taken from the puppet class and the default order
+	// is set by Foreman, they are kept unless set explicitly
+	if p.Description != "" {
+		pMap["description"] = p.Description
+	}
+	if p.OverrideValueOrder != "" {
+		pMap["override_value_order"] = p.OverrideValueOrder
+	}
+
+	return json.Marshal(pMap)
+}
+
+// Implement the Unmarshaler interface
+func (p *ForemanSmartClassParameter) UnmarshalJSON(b []byte) error {
+	// NOTE(ALL): the alias type has no methods, which prevents the recursion
+	//   into this function
+	type foremanSmartClassParameter ForemanSmartClassParameter
+	var pJSON struct {
+		foremanSmartClassParameter
+		DefaultValue  json.RawMessage `json:"default_value"`
+		HiddenValue   bool            `json:"hidden_value?"`
+		ValidatorRule *string         `json:"validator_rule"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &pJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*p = ForemanSmartClassParameter(pJSON.foremanSmartClassParameter)
+
+	p.HiddenValue = pJSON.HiddenValue
+	if pJSON.ValidatorRule != nil {
+		p.ValidatorRule = *pJSON.ValidatorRule
+	}
+
+	// Default values of other types than string are returned as JSON, ie:
+	// a hash as object
+	p.DefaultValue = ""
+	if len(pJSON.DefaultValue) > 0 && string(pJSON.DefaultValue) != "null" {
+		if json.Unmarshal(pJSON.DefaultValue, &p.DefaultValue) != nil {
+			var compacted bytes.Buffer
+			if compactErr := json.Compact(&compacted, pJSON.DefaultValue); compactErr != nil {
+				return compactErr
+			}
+			p.DefaultValue = compacted.String()
+		}
+	}
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -38,10 +151,12 @@ type ForemanSmartClassParameter struct {
 func (c *Client) ReadSmartClassParameter(ctx context.Context, id int) (*ForemanSmartClassParameter, error) {
 	log.Tracef("foreman/api/smartsclassparameter.go#Read")
 
+	reqEndpoint := fmt.Sprintf("%s/%d", SmartClassParameterEndpointPrefix, id)
+
 	req, reqErr := c.NewRequestWithContext(
 		ctx,
 		http.MethodGet,
-		SmartClassParameterEndpointPrefix,
+		reqEndpoint,
 		nil,
 	)
 	if reqErr != nil {
@@ -59,6 +174,43 @@ func (c *Client) ReadSmartClassParameter(ctx context.Context, id int) (*ForemanS
 	return &readSmartClassParameter, nil
 }
 
+// UpdateSmartClassParameter updates a ForemanSmartClassParameter's attributes.
+// The parameter with the ID of the supplied ForemanSmartClassParameter will be
+// updated.  A new ForemanSmartClassParameter reference is returned with the
+// attributes from the result of the update operation.
+func (c *Client) UpdateSmartClassParameter(ctx context.Context, p *ForemanSmartClassParameter) (*ForemanSmartClassParameter, error) {
+	log.Tracef("foreman/api/smartclassparameter.go#Update")
+
+	reqEndpoint := fmt.Sprintf("%s/%d", SmartClassParameterEndpointPrefix, p.Id)
+
+	pJSONBytes, jsonEncErr := c.WrapJSON("smart_class_parameter", p)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("smartClassParameterJSONBytes: [%s]", pJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(pJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedSmartClassParameter ForemanSmartClassParameter
+	sendErr := c.SendAndParse(req, &updatedSmartClassParameter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedSmartClassParameter: [%+v]", updatedSmartClassParameter)
+
+	return &updatedSmartClassParameter, nil
+}
+
 // -----------------------------------------------------------------------------
 // Query Implementation
 // -----------------------------------------------------------------------------
@@ -85,8 +237,7 @@ func (c *Client) QuerySmartClassParameter(ctx context.Context, t *ForemanSmartCl
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	param := `"` + t.Parameter + `"`
-	reqQuery.Set("search", "parameter="+param)
+	reqQuery.Set("search", SearchBy("parameter", t.Parameter).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
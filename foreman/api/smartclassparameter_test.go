@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// Ensures smart class parameters are decoded with the default value in JSON
+// notation and the hidden flag with a trailing question mark
+func TestForemanSmartClassParameter_UnmarshalJSON(t *testing.T) {
+	data, err := os.ReadFile("../testdata/3.11/smart_class_parameters/read_response.json")
+	if err != nil {
+		t.Fatalf("Reading the fixture failed: [%s]", err)
+	}
+
+	var p ForemanSmartClassParameter
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+
+	if p.Id != 41 || p.Parameter != "default_mods" || p.PuppetClassId != 12 || p.ParameterType != "array" {
+		t.Fatalf("UnmarshalJSON did not decode the parameter, got [%+v]", p)
+	}
+	if p.DefaultValue != `["ssl","rewrite"]` {
+		t.Fatalf("Expected [%s], got [%s]", `["ssl","rewrite"]`, p.DefaultValue)
+	}
+	if !p.MergeOverrides || !p.MergeDefault || !p.AvoidDuplicates || p.ValidatorRule != "ssl, rewrite, headers" {
+		t.Fatalf("UnmarshalJSON did not decode the merge and validator attributes, got [%+v]", p)
+	}
+
+	var s ForemanSmartClassParameter
+	if err := json.Unmarshal([]byte(`{"id": 4, "default_value": "80", "validator_rule": null, "hidden_value?": true}`), &s); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+	if s.DefaultValue != "80" || s.ValidatorRule != "" || !s.HiddenValue {
+		t.Fatalf("UnmarshalJSON did not decode the parameter, got [%+v]", s)
+	}
+}
+
+// Ensures the puppet classes of config groups are decoded both as list and
+// grouped by module
+func TestForemanConfigGroup_UnmarshalJSON(t *testing.T) {
+	data, err := os.ReadFile("../testdata/3.11/config_groups/read_response.json")
+	if err != nil {
+		t.Fatalf("Reading the fixture failed: [%s]", err)
+	}
+
+	var g ForemanConfigGroup
+	if err := json.Unmarshal(data, &g); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+	if g.Name != "webserver" || !reflect.DeepEqual(g.PuppetClassIds, []int{12, 15}) {
+		t.Fatalf("UnmarshalJSON did not decode the config group, got [%+v]", g)
+	}
+
+	grouped := `{"id": 3, "puppetclasses": {"apache": [{"id": 15}, {"id": 12}], "ntp": [{"id": 7}]}}`
+	if err := json.Unmarshal([]byte(grouped), &g); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+	if !reflect.DeepEqual(g.PuppetClassIds, []int{7, 12, 15}) {
+		t.Fatalf("Expected [%v], got [%v]", []int{7, 12, 15}, g.PuppetClassIds)
+	}
+}
@@ -231,11 +231,9 @@ func (c *Client) QuerySubnet(ctx context.Context, s *ForemanSubnet) (QueryRespon
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
 	if s.Name != "" {
-		name := `"` + s.Name + `"`
-		reqQuery.Set("search", "name="+name)
+		reqQuery.Set("search", SearchBy("name", s.Name).String())
 	} else if s.Network != "" {
-		network := `"` + s.Network + `"`
-		reqQuery.Set("search", "network="+network)
+		reqQuery.Set("search", SearchBy("network", s.Network).String())
 	}
 
 	req.URL.RawQuery = reqQuery.Encode()
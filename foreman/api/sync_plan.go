@@ -15,6 +15,9 @@ const (
 	// 'katello/ will be removed, it's a marker to detect talking with katello api
 	// %d will be replaced with organization_id
 	KatelloSyncPlanEndpointPrefix = "katello/organizations/%d/sync_plans"
+	// KatelloSyncPlanIndexEndpoint lists the sync plans of all organizations,
+	// use the organization_id parameter to scope the results
+	KatelloSyncPlanIndexEndpoint = "katello/sync_plans"
 )
 
 // -----------------------------------------------------------------------------
@@ -194,8 +197,7 @@ func (c *Client) QueryKatelloSyncPlan(ctx context.Context, sp *ForemanKatelloSyn
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + sp.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", sp.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
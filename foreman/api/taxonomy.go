@@ -0,0 +1,208 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// TaxonomyIgnoreTypes are the object types a taxonomy can be set to ignore.
+// An ignored type is treated as if all objects of that type were associated
+// with the taxonomy.
+var TaxonomyIgnoreTypes = []string{
+	"User",
+	"SmartProxy",
+	"ComputeResource",
+	"Medium",
+	"ProvisioningTemplate",
+	"Ptable",
+	"Domain",
+	"Realm",
+	"Environment",
+	"Hostgroup",
+	"Subnet",
+	"Location",
+	"Organization",
+}
+
+// ForemanTaxonomy holds the attributes shared by organizations and
+// locations.  It is embedded in ForemanOrganization and ForemanLocation, which
+// implement the marshalling for their API representation.
+type ForemanTaxonomy struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// The title is the path-like name of the taxonomy, ie: "Europe/Berlin"
+	Title string
+	// Description of the taxonomy
+	Description string
+	// ID of the parent taxonomy
+	ParentId int
+	// Object types associated with the taxonomy as a whole
+	IgnoreTypes []string
+	// Parameters of the taxonomy
+	Parameters []ForemanKVParameter
+
+	// IDs of the objects associated with the taxonomy
+	UserIds                 []int
+	SmartProxyIds           []int
+	ComputeResourceIds      []int
+	MediumIds               []int
+	ProvisioningTemplateIds []int
+	PtableIds               []int
+	DomainIds               []int
+	RealmIds                []int
+	EnvironmentIds          []int
+	HostgroupIds            []int
+	SubnetIds               []int
+}
+
+// foremanTaxonomyJSON is the API response of an organization or location.
+// Associated objects are returned as nested objects instead of ID lists.
+type foremanTaxonomyJSON struct {
+	Title                 string          `json:"title"`
+	Description           string          `json:"description"`
+	ParentId              int             `json:"parent_id"`
+	IgnoreTypes           []string        `json:"ignore_types"`
+	Parameters            json.RawMessage `json:"parameters"`
+	Users                 []ForemanObject `json:"users"`
+	SmartProxies          []ForemanObject `json:"smart_proxies"`
+	ComputeResources      []ForemanObject `json:"compute_resources"`
+	Media                 []ForemanObject `json:"media"`
+	ProvisioningTemplates []ForemanObject `json:"provisioning_templates"`
+	Ptables               []ForemanObject `json:"ptables"`
+	Domains               []ForemanObject `json:"domains"`
+	Realms                []ForemanObject `json:"realms"`
+	Environments          []ForemanObject `json:"environments"`
+	Hostgroups            []ForemanObject `json:"hostgroups"`
+	Subnets               []ForemanObject `json:"subnets"`
+}
+
+// taxonomyJSONMap returns the request attributes shared by organizations and
+// locations.  paramsKey is the name of the nested parameters attribute, ie:
+// "location_parameters_attributes".
+//
+// Parameters and association lists are only sent if they are not nil, an
+// omitted list leaves them untouched and an empty list removes all of them.
+func taxonomyJSONMap(t *ForemanTaxonomy, paramsKey string) map[string]interface{} {
+	m := map[string]interface{}{
+		"name":        t.Name,
+		"description": t.Description,
+		// null removes the parent
+		"parent_id":    intIdToJSONString(t.ParentId),
+		"ignore_types": t.IgnoreTypes,
+	}
+	if t.IgnoreTypes == nil {
+		m["ignore_types"] = []string{}
+	}
+	if t.Parameters != nil {
+		m[paramsKey] = t.Parameters
+	}
+
+	ids := map[string][]int{
+		"user_ids":                  t.UserIds,
+		"smart_proxy_ids":           t.SmartProxyIds,
+		"compute_resource_ids":      t.ComputeResourceIds,
+		"medium_ids":                t.MediumIds,
+		"provisioning_template_ids": t.ProvisioningTemplateIds,
+		"ptable_ids":                t.PtableIds,
+		"domain_ids":                t.DomainIds,
+		"realm_ids":                 t.RealmIds,
+		"environment_ids":           t.EnvironmentIds,
+		"hostgroup_ids":             t.HostgroupIds,
+		"subnet_ids":                t.SubnetIds,
+	}
+	for key, value := range ids {
+		if value != nil {
+			m[key] = value
+		}
+	}
+	return m
+}
+
+// unmarshalTaxonomy decodes the attributes shared by organizations and
+// locations from an API response
+func unmarshalTaxonomy(b []byte, t *ForemanTaxonomy) error {
+	var fo ForemanObject
+	if jsonDecErr := json.Unmarshal(b, &fo); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	t.ForemanObject = fo
+
+	var tJSON foremanTaxonomyJSON
+	if jsonDecErr := json.Unmarshal(b, &tJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	t.Title = tJSON.Title
+	t.Description = tJSON.Description
+	t.ParentId = tJSON.ParentId
+	t.IgnoreTypes = tJSON.IgnoreTypes
+
+	params, paramsErr := unmarshalTaxonomyParameters(tJSON.Parameters)
+	if paramsErr != nil {
+		return paramsErr
+	}
+	t.Parameters = params
+
+	t.UserIds = foremanObjectArrayToIdIntArray(tJSON.Users)
+	t.SmartProxyIds = foremanObjectArrayToIdIntArray(tJSON.SmartProxies)
+	t.ComputeResourceIds = foremanObjectArrayToIdIntArray(tJSON.ComputeResources)
+	t.MediumIds = foremanObjectArrayToIdIntArray(tJSON.Media)
+	t.ProvisioningTemplateIds = foremanObjectArrayToIdIntArray(tJSON.ProvisioningTemplates)
+	t.PtableIds = foremanObjectArrayToIdIntArray(tJSON.Ptables)
+	t.DomainIds = foremanObjectArrayToIdIntArray(tJSON.Domains)
+	t.RealmIds = foremanObjectArrayToIdIntArray(tJSON.Realms)
+	t.EnvironmentIds = foremanObjectArrayToIdIntArray(tJSON.Environments)
+	t.HostgroupIds = foremanObjectArrayToIdIntArray(tJSON.Hostgroups)
+	t.SubnetIds = foremanObjectArrayToIdIntArray(tJSON.Subnets)
+
+	return nil
+}
+
+// unmarshalTaxonomyParameters decodes the "parameters" of a taxonomy.
+// Parameters with a type other than string (ie: boolean, integer or hash)
+// are returned with their JSON representation as value, since the
+// parameters are a map of strings in the resources.
+func unmarshalTaxonomyParameters(b json.RawMessage) ([]ForemanKVParameter, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	var raw []struct {
+		Name  string          `json:"name"`
+		Value json.RawMessage `json:"value"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &raw); jsonDecErr != nil {
+		return nil, jsonDecErr
+	}
+
+	params := make([]ForemanKVParameter, len(raw))
+	for idx, p := range raw {
+		params[idx].Name = p.Name
+		var value string
+		if json.Unmarshal(p.Value, &value) != nil {
+			value = string(p.Value)
+		}
+		params[idx].Value = value
+	}
+	return params, nil
+}
+
+// foremanObjectsToIds returns the IDs of nested objects of an API response,
+// ie: the "locations" of an organization
+func foremanObjectsToIds(b []byte, key string) ([]int, error) {
+	var m map[string]json.RawMessage
+	if jsonDecErr := json.Unmarshal(b, &m); jsonDecErr != nil {
+		return nil, jsonDecErr
+	}
+	if len(m[key]) == 0 {
+		return nil, nil
+	}
+	var objs []ForemanObject
+	if jsonDecErr := json.Unmarshal(m[key], &objs); jsonDecErr != nil {
+		return nil, jsonDecErr
+	}
+	return foremanObjectArrayToIdIntArray(objs), nil
+}
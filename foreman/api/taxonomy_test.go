@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// Ensures organizations are decoded from the API response with the nested
+// associations converted to ID lists
+func TestForemanOrganization_UnmarshalJSON(t *testing.T) {
+	data, err := os.ReadFile("../testdata/3.11/organizations/read_response.json")
+	if err != nil {
+		t.Fatalf("Reading the fixture failed: [%s]", err)
+	}
+
+	var o ForemanOrganization
+	if err := json.Unmarshal(data, &o); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+
+	if o.Id != 7 || o.Title != "ACME/Sales" || o.ParentId != 1 || o.Label != "ACME_Sales" {
+		t.Fatalf("UnmarshalJSON did not decode the organization, got [%+v]", o)
+	}
+	if !reflect.DeepEqual(o.LocationIds, []int{5, 6}) {
+		t.Fatalf("Expected [%v], got [%v]", []int{5, 6}, o.LocationIds)
+	}
+	if !reflect.DeepEqual(o.HostgroupIds, []int{11}) || !reflect.DeepEqual(o.ComputeResourceIds, []int{}) {
+		t.Fatalf("UnmarshalJSON did not decode the associations, got [%+v]", o.ForemanTaxonomy)
+	}
+	expectedParams := []ForemanKVParameter{{Name: "cost_center", Value: "4711"}, {Name: "monitoring", Value: "true"}}
+	if !reflect.DeepEqual(o.Parameters, expectedParams) {
+		t.Fatalf("Expected [%v], got [%v]", expectedParams, o.Parameters)
+	}
+}
+
+// Ensures parameters of other types than string are decoded with their JSON
+// representation
+func TestForemanLocation_UnmarshalJSON(t *testing.T) {
+	data, err := os.ReadFile("../testdata/3.11/locations/read_response.json")
+	if err != nil {
+		t.Fatalf("Reading the fixture failed: [%s]", err)
+	}
+
+	var l ForemanLocation
+	if err := json.Unmarshal(data, &l); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+
+	if l.Description != "" || !reflect.DeepEqual(l.OrganizationIds, []int{7}) {
+		t.Fatalf("UnmarshalJSON did not decode the location, got [%+v]", l)
+	}
+	expectedParams := []ForemanKVParameter{{Name: "ntp_server", Value: "ntp.ber.example.com"}, {Name: "rack_count", Value: "12"}}
+	if !reflect.DeepEqual(l.Parameters, expectedParams) {
+		t.Fatalf("Expected [%v], got [%v]", expectedParams, l.Parameters)
+	}
+}
+
+// Ensures taxonomies are sent with the parameters attribute of their type,
+// unset parameters and association lists are omitted, empty ones are sent
+// and a missing parent is sent as null
+func TestForemanTaxonomy_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		Obj      interface{}
+		Expected string
+	}{
+		{
+			Obj: ForemanOrganization{
+				ForemanTaxonomy: ForemanTaxonomy{
+					ForemanObject: ForemanObject{Name: "ACME"},
+					Parameters:    []ForemanKVParameter{{Name: "a", Value: "b"}},
+					DomainIds:     []int{2},
+				},
+				LocationIds: []int{},
+			},
+			Expected: `{"description":"","domain_ids":[2],"ignore_types":[],"location_ids":[],"name":"ACME","organization_parameters_attributes":[{"name":"a","value":"b"}],"parent_id":null}`,
+		},
+		{
+			Obj: ForemanLocation{
+				ForemanTaxonomy: ForemanTaxonomy{
+					ForemanObject: ForemanObject{Name: "Berlin"},
+					ParentId:      4,
+					IgnoreTypes:   []string{"Domain"},
+				},
+				OrganizationIds: []int{7},
+			},
+			Expected: `{"description":"","ignore_types":["Domain"],"name":"Berlin","organization_ids":[7],"parent_id":"4"}`,
+		},
+		{
+			Obj: ForemanLocation{
+				ForemanTaxonomy: ForemanTaxonomy{
+					ForemanObject: ForemanObject{Name: "Berlin"},
+					Parameters:    []ForemanKVParameter{},
+					DomainIds:     []int{},
+				},
+				OrganizationIds: []int{},
+			},
+			Expected: `{"description":"","domain_ids":[],"ignore_types":[],"location_parameters_attributes":[],"name":"Berlin","organization_ids":[],"parent_id":null}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := json.Marshal(testCase.Obj)
+		if err != nil {
+			t.Fatalf("MarshalJSON returned error [%s]", err)
+		}
+		if string(data) != testCase.Expected {
+			t.Fatalf("Expected [%s], got [%s]", testCase.Expected, data)
+		}
+	}
+}
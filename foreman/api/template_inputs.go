@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 
@@ -83,10 +82,10 @@ func (fti *ForemanTemplateInput) UnmarshalJSON(b []byte) error {
 			// And set in struct
 			fti.Id = id
 		default:
-			log.Fatalf("Unhandled 'id' type %T", v)
+			return fmt.Errorf("unhandled 'id' type %T in ForemanTemplateInput JSON", v)
 		}
 	} else {
-		log.Fatalf("id not in ForemanTemplateInput JSON!")
+		return fmt.Errorf("id not in ForemanTemplateInput JSON")
 	}
 
 	// Same for TemplateId
@@ -109,7 +108,7 @@ func (fti *ForemanTemplateInput) UnmarshalJSON(b []byte) error {
 			// And set in struct
 			fti.TemplateId = id
 		default:
-			log.Fatalf("Unhandled 'template_id' type %T", v)
+			return fmt.Errorf("unhandled 'template_id' type %T in ForemanTemplateInput JSON", v)
 		}
 	}
 
@@ -251,8 +250,7 @@ func (c *Client) QueryTemplateInput(ctx context.Context, tiObj *ForemanTemplateI
 	}
 
 	reqQuery := req.URL.Query()
-	name := `"` + tiObj.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", tiObj.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	err = c.SendAndParse(req, &qresp)
@@ -60,6 +60,31 @@ type ForemanUser struct {
 
 	// list of all organisation for user
 	OrganizationIds []int `json:"organization_ids,omitempty"`
+
+	// list of all roles assigned to the user
+	RoleIds []int `json:"role_ids"`
+}
+
+// Implement the Unmarshaler interface
+func (u *ForemanUser) UnmarshalJSON(b []byte) error {
+	// NOTE(ALL): the alias type has no methods, which prevents the recursion
+	//   into this function
+	type foremanUser ForemanUser
+	var uJSON struct {
+		foremanUser
+		Roles []ForemanObject `json:"roles"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &uJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*u = ForemanUser(uJSON.foremanUser)
+
+	// Foreman returns the assigned roles as nested objects
+	if uJSON.Roles != nil {
+		u.RoleIds = roleIds(uJSON.Roles)
+	}
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -206,24 +231,27 @@ func (c *Client) QueryUser(ctx context.Context, s *ForemanUser) (QueryResponse,
 		return queryResponse, reqErr
 	}
 
-	// dynamically build the query based on the attributes
-	// not all api search fields supported
+	// dynamically build the query based on the attributes, all set
+	// attributes must match. not all api search fields supported
 	reqQuery := req.URL.Query()
+	search := NewSearchQuery()
 	if s.Description != "" {
-		description := `"` + s.Description + `"`
-		reqQuery.Set("search", "description="+description)
-	} else if s.Firstname != "" {
-		firstname := `"` + s.Firstname + `"`
-		reqQuery.Set("search", "firstname="+firstname)
-	} else if s.Lastname != "" {
-		lastname := `"` + s.Lastname + `"`
-		reqQuery.Set("search", "firstname="+lastname)
-	} else if s.Mail != "" {
-		mail := `"` + s.Mail + `"`
-		reqQuery.Set("search", "firstname="+mail)
-	} else if s.Login != "" {
-		login := `"` + s.Login + `"`
-		reqQuery.Set("search", "login="+login)
+		search.And("description", SearchEqual, s.Description)
+	}
+	if s.Firstname != "" {
+		search.And("firstname", SearchEqual, s.Firstname)
+	}
+	if s.Lastname != "" {
+		search.And("lastname", SearchEqual, s.Lastname)
+	}
+	if s.Mail != "" {
+		search.And("mail", SearchEqual, s.Mail)
+	}
+	if s.Login != "" {
+		search.And("login", SearchEqual, s.Login)
+	}
+	if !search.IsEmpty() {
+		reqQuery.Set("search", search.String())
 	}
 
 	req.URL.RawQuery = reqQuery.Encode()
@@ -25,6 +25,61 @@ type ForemanUsergroup struct {
 
 	// enables or disables admin access for group members, Must be one of: true, false, 1, 0.
 	Admin bool `json:"admin"`
+
+	// IDs of the roles assigned to the group members
+	RoleIds []int `json:"role_ids"`
+
+	// Groups of an external auth source whose members are synced into the
+	// usergroup, ie: LDAP groups
+	ExternalUsergroups []ForemanExternalUsergroup `json:"external_usergroups"`
+}
+
+// The ForemanExternalUsergroup API model represents a group of an external
+// auth source mapped to a usergroup
+type ForemanExternalUsergroup struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the auth source of the group
+	AuthSourceId int
+}
+
+// Implement the Marshaler interface
+func (e ForemanExternalUsergroup) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"name":           e.Name,
+		"auth_source_id": e.AuthSourceId,
+	})
+}
+
+// Implement the Unmarshaler interface
+func (e *ForemanExternalUsergroup) UnmarshalJSON(b []byte) error {
+	var fo ForemanObject
+	if jsonDecErr := json.Unmarshal(b, &fo); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	e.ForemanObject = fo
+
+	// NOTE(ALL): depending on the version, Foreman returns the ID of the
+	//   auth source or the nested auth source
+	var eJSON struct {
+		AuthSourceId   int            `json:"auth_source_id"`
+		AuthSourceLdap *ForemanObject `json:"auth_source_ldap"`
+		AuthSource     *ForemanObject `json:"auth_source"`
+	}
+	if jsonDecErr := json.Unmarshal(b, &eJSON); jsonDecErr != nil {
+		return jsonDecErr
+	}
+	switch {
+	case eJSON.AuthSourceId > 0:
+		e.AuthSourceId = eJSON.AuthSourceId
+	case eJSON.AuthSourceLdap != nil:
+		e.AuthSourceId = eJSON.AuthSourceLdap.Id
+	case eJSON.AuthSource != nil:
+		e.AuthSourceId = eJSON.AuthSource.Id
+	}
+
+	return nil
 }
 
 // Implement the Marshaler interface
@@ -38,6 +93,7 @@ func (fh ForemanUsergroup) MarshalJSON() ([]byte, error) {
 
 	fhMap["name"] = fh.Name
 	fhMap["admin"] = fh.Admin
+	fhMap["role_ids"] = fh.RoleIds
 
 	log.Debugf("fhMap: [%v]", fhMap)
 
@@ -67,6 +123,20 @@ func (fh *ForemanUsergroup) UnmarshalJSON(b []byte) error {
 		fh.Admin = false
 	}
 
+	// Foreman returns the assigned roles as nested objects
+	var fhJSON struct {
+		Roles              []ForemanObject            `json:"roles"`
+		ExternalUsergroups []ForemanExternalUsergroup `json:"external_usergroups"`
+	}
+	jsonDecErr = json.Unmarshal(b, &fhJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if fhJSON.Roles != nil {
+		fh.RoleIds = roleIds(fhJSON.Roles)
+	}
+	fh.ExternalUsergroups = fhJSON.ExternalUsergroups
+
 	return nil
 }
 
@@ -220,8 +290,7 @@ func (c *Client) QueryUsergroup(ctx context.Context, u *ForemanUsergroup) (Query
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := `"` + u.Name + `"`
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", u.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
@@ -254,3 +323,83 @@ func (c *Client) QueryUsergroup(ctx context.Context, u *ForemanUsergroup) (Query
 
 	return queryResponse, nil
 }
+
+// -----------------------------------------------------------------------------
+// External Usergroups
+// -----------------------------------------------------------------------------
+
+// CreateExternalUsergroup maps the group of an external auth source to the
+// usergroup identified by the supplied ID and returns the created
+// ForemanExternalUsergroup reference.
+func (c *Client) CreateExternalUsergroup(ctx context.Context, usergroupId int, e *ForemanExternalUsergroup) (*ForemanExternalUsergroup, error) {
+	log.Tracef("foreman/api/usergroup.go#CreateExternalUsergroup")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/external_usergroups", UsergroupEndpointPrefix, usergroupId)
+
+	eJSONBytes, jsonEncErr := c.WrapJSON("external_usergroup", e)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("externalUsergroupJSONBytes: [%s]", eJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(eJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdExternalUsergroup ForemanExternalUsergroup
+	sendErr := c.SendAndParse(req, &createdExternalUsergroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdExternalUsergroup: [%+v]", createdExternalUsergroup)
+
+	return &createdExternalUsergroup, nil
+}
+
+// DeleteExternalUsergroup removes the external usergroup identified by the
+// supplied ID from a usergroup
+func (c *Client) DeleteExternalUsergroup(ctx context.Context, usergroupId int, id int) error {
+	log.Tracef("foreman/api/usergroup.go#DeleteExternalUsergroup")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/external_usergroups/%d", UsergroupEndpointPrefix, usergroupId, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// RefreshExternalUsergroup syncs the members of the external usergroup
+// identified by the supplied ID into the usergroup
+func (c *Client) RefreshExternalUsergroup(ctx context.Context, usergroupId int, id int) error {
+	log.Tracef("foreman/api/usergroup.go#RefreshExternalUsergroup")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/external_usergroups/%d/refresh", UsergroupEndpointPrefix, usergroupId, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// Ensures the external usergroups are decoded with the ID of their auth
+// source, which is either returned as attribute or as nested object
+func TestForemanUsergroup_UnmarshalJSON_ExternalUsergroups(t *testing.T) {
+	data, err := os.ReadFile("../testdata/3.11/usergroups/read_response.json")
+	if err != nil {
+		t.Fatalf("Reading the fixture failed: [%s]", err)
+	}
+
+	var ug ForemanUsergroup
+	if err := json.Unmarshal(data, &ug); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+
+	expected := []ForemanExternalUsergroup{
+		{ForemanObject: ForemanObject{Id: 2, Name: "web-admins"}, AuthSourceId: 3},
+	}
+	if !reflect.DeepEqual(ug.ExternalUsergroups, expected) {
+		t.Fatalf("Expected [%+v], got [%+v]", expected, ug.ExternalUsergroups)
+	}
+
+	var e ForemanExternalUsergroup
+	if err := json.Unmarshal([]byte(`{"id": 2, "name": "web-admins", "auth_source_id": 3}`), &e); err != nil {
+		t.Fatalf("UnmarshalJSON returned error [%s]", err)
+	}
+	if !reflect.DeepEqual(e, expected[0]) {
+		t.Fatalf("Expected [%+v], got [%+v]", expected[0], e)
+	}
+}
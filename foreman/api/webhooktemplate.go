@@ -197,8 +197,7 @@ func (c *Client) QueryWebhookTemplate(ctx context.Context, t *ForemanWebhookTemp
 
 	// dynamically build the query based on the attributes
 	reqQuery := req.URL.Query()
-	name := "\"" + t.Name + "\""
-	reqQuery.Set("search", "name="+name)
+	reqQuery.Set("search", SearchBy("name", t.Name).String())
 
 	req.URL.RawQuery = reqQuery.Encode()
 	sendErr := c.SendAndParse(req, &queryResponse)
@@ -29,6 +29,8 @@ func dataSourceForemanArchitecture() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanArchitectureRead,
@@ -46,7 +48,13 @@ func dataSourceForemanArchitectureRead(ctx context.Context, d *schema.ResourceDa
 
 	log.Debugf("ForemanArchitecture: [%+v]", arch)
 
-	queryResponse, queryErr := client.QueryArchitecture(ctx, arch)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanArchitecture](ctx, client, api.ArchitectureEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryArchitecture(ctx, arch)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanArchitectureRead(ctx context.Context, d *schema.ResourceDa
 
 	return nil
 }
+
+// dataSourceForemanArchitectures returns all architectures matching a search
+func dataSourceForemanArchitectures() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanArchitecture(),
+		"List of architectures matching a Foreman scoped search.",
+		staticEndpoint(api.ArchitectureEndpointPrefix),
+		setResourceDataFromForemanArchitecture,
+	)
+}
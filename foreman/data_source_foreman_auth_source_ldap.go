@@ -0,0 +1,96 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanAuthSourceLdap() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanAuthSourceLdap()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// the password of the bind account is not returned by Foreman
+	delete(ds, "account_password")
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"Name of the LDAP auth source. %s \"corp-ad\"",
+			autodoc.MetaExample,
+		),
+	}
+	addDataSourceSearch(ds, "name")
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanAuthSourceLdapRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanAuthSourceLdapRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_auth_source_ldap.go#Read")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLdap(d)
+
+	log.Debugf("ForemanAuthSourceLdap: [%+v]", a)
+
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanAuthSourceLdap](ctx, client, api.AuthSourceLdapEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryAuthSourceLdap(ctx, a)
+	}
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source LDAP auth source returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source LDAP auth source returned more than 1 result")
+	}
+
+	var queryAuthSourceLdap api.ForemanAuthSourceLdap
+	var ok bool
+	if queryAuthSourceLdap, ok = queryResponse.Results[0].(api.ForemanAuthSourceLdap); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanAuthSourceLdap], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	a = &queryAuthSourceLdap
+
+	log.Debugf("ForemanAuthSourceLdap: [%+v]", a)
+
+	setResourceDataFromForemanAuthSourceLdap(d, a)
+
+	return nil
+}
+
+// dataSourceForemanAuthSourceLdaps returns all LDAP auth sources matching a
+// search
+func dataSourceForemanAuthSourceLdaps() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanAuthSourceLdap(),
+		"List of LDAP auth sources matching a Foreman scoped search.",
+		staticEndpoint(api.AuthSourceLdapEndpointPrefix),
+		setResourceDataFromForemanAuthSourceLdap,
+	)
+}
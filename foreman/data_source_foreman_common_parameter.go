@@ -29,6 +29,8 @@ func dataSourceForemanCommonParameter() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanCommonParameterRead,
@@ -46,7 +48,13 @@ func dataSourceForemanCommonParameterRead(ctx context.Context, d *schema.Resourc
 
 	log.Debugf("ForemanCommonParameter: [%+v]", commonParameter)
 
-	queryResponse, queryErr := client.QueryCommonParameter(ctx, commonParameter)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanCommonParameter](ctx, client, api.CommonParameterEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryCommonParameter(ctx, commonParameter)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanCommonParameterRead(ctx context.Context, d *schema.Resourc
 
 	return nil
 }
+
+// dataSourceForemanCommonParameters returns all global parameters matching a search
+func dataSourceForemanCommonParameters() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanCommonParameter(),
+		"List of global parameters matching a Foreman scoped search.",
+		staticEndpoint(api.CommonParameterEndpointPrefix),
+		setResourceDataFromForemanCommonParameter,
+	)
+}
@@ -27,6 +27,8 @@ func dataSourceForemanComputeProfile() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanComputeProfileRead,
 		Schema:      ds,
@@ -45,7 +47,13 @@ func dataSourceForemanComputeProfileRead(ctx context.Context, d *schema.Resource
 
 	log.Debugf("ForemanComputeProfile: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryComputeProfile(ctx, t)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanComputeProfile](ctx, client, api.ComputeProfileEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryComputeProfile(ctx, t)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -73,3 +81,13 @@ func dataSourceForemanComputeProfileRead(ctx context.Context, d *schema.Resource
 
 	return nil
 }
+
+// dataSourceForemanComputeProfiles returns all compute profiles matching a search
+func dataSourceForemanComputeProfiles() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanComputeProfile(),
+		"List of compute profiles matching a Foreman scoped search.",
+		staticEndpoint(api.ComputeProfileEndpointPrefix),
+		setResourceDataFromForemanComputeProfile,
+	)
+}
@@ -25,6 +25,8 @@ func dataSourceForemanComputeResource() *schema.Resource {
 		Description: fmt.Sprintf("The name of the compute resource. %s", autodoc.MetaExample),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanComputeResourceRead,
@@ -42,7 +44,13 @@ func dataSourceForemanComputeResourceRead(ctx context.Context, d *schema.Resourc
 
 	log.Debugf("ForemanComputeResource: [%+v]", computeresource)
 
-	queryResponse, queryErr := client.QueryComputeResource(ctx, computeresource)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanComputeResource](ctx, client, api.ComputeResourceEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryComputeResource(ctx, computeresource)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -70,3 +78,13 @@ func dataSourceForemanComputeResourceRead(ctx context.Context, d *schema.Resourc
 
 	return nil
 }
+
+// dataSourceForemanComputeResources returns all compute resources matching a search
+func dataSourceForemanComputeResources() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanComputeResource(),
+		"List of compute resources matching a Foreman scoped search.",
+		staticEndpoint(api.ComputeResourceEndpointPrefix),
+		setResourceDataFromForemanComputeResource,
+	)
+}
@@ -29,6 +29,8 @@ func dataSourceForemanDomain() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanDomainRead,
@@ -46,7 +48,13 @@ func dataSourceForemanDomainRead(ctx context.Context, d *schema.ResourceData, me
 
 	log.Debugf("ForemanDomain: [%+v]", domain)
 
-	queryResponse, queryErr := client.QueryDomain(ctx, domain)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanDomain](ctx, client, api.DomainEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryDomain(ctx, domain)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanDomainRead(ctx context.Context, d *schema.ResourceData, me
 
 	return nil
 }
+
+// dataSourceForemanDomains returns all domains matching a search
+func dataSourceForemanDomains() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanDomain(),
+		"List of domains matching a Foreman scoped search.",
+		staticEndpoint(api.DomainEndpointPrefix),
+		setResourceDataFromForemanDomain,
+	)
+}
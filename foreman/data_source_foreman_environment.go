@@ -29,6 +29,8 @@ func dataSourceForemanEnvironment() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanEnvironmentRead,
@@ -46,7 +48,13 @@ func dataSourceForemanEnvironmentRead(ctx context.Context, d *schema.ResourceDat
 
 	log.Debugf("ForemanEnvironment: [%+v]", e)
 
-	queryResponse, queryErr := client.QueryEnvironment(ctx, e)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanEnvironment](ctx, client, api.EnvironmentEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryEnvironment(ctx, e)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanEnvironmentRead(ctx context.Context, d *schema.ResourceDat
 
 	return nil
 }
+
+// dataSourceForemanEnvironments returns all Puppet environments matching a search
+func dataSourceForemanEnvironments() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanEnvironment(),
+		"List of Puppet environments matching a Foreman scoped search.",
+		staticEndpoint(api.EnvironmentEndpointPrefix),
+		setResourceDataFromForemanEnvironment,
+	)
+}
@@ -0,0 +1,203 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanHost() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanHost()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// remove attributes which only control the behaviour of the resource
+	for _, attr := range []string{
+		"root_password",
+		"enable_bmc",
+		"set_build_flag",
+		"manage_power_operations",
+		"retry_count",
+		"bmc_success",
+		"wait_for_build",
+		"rebuild_on_change",
+		"rebuild_trigger",
+		"rebuild_config",
+		"destroy_mode",
+	} {
+		delete(ds, attr)
+	}
+
+	ds["interfaces_attributes"].Elem.(*schema.Resource).Schema["password"].Sensitive = true
+
+	// define searchable attributes for the data source
+	addDataSourceForemanHostLookup(ds)
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanHostRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_host.go#Read")
+
+	client := meta.(*api.Client)
+
+	hostId, lookupErr := dataSourceForemanHostLookup(ctx, d, client)
+	if lookupErr != nil {
+		return diag.FromErr(lookupErr)
+	}
+
+	// The index endpoint only returns a subset of the host's attributes,
+	// read the host to populate interfaces, parameters and the like
+	readHost, readErr := client.ReadHost(ctx, hostId)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	log.Debugf("Read ForemanHost: [%+v]", readHost)
+
+	if err := setResourceDataFromForemanHost(d, readHost); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// addDataSourceForemanHostLookup adds the attributes a host is looked up by
+// to a data source schema, ie: for data sources reading details of a host.
+// Exactly one of them or the search has to be set, see
+// dataSourceForemanHostLookup.
+func addDataSourceForemanHostLookup(ds map[string]*schema.Schema) {
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"Name of the host as stored in Foreman. Can be short name or "+
+				"FQDN, depending on the Foreman setting "+
+				"'append_domain_name_for_hosts'. "+
+				"%s \"compute01.dc1.company.com\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	ds["fqdn"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: "Fully qualified domain name of the host. Matches hosts " +
+			"stored with their FQDN as well as hosts stored with their " +
+			"short name in the domain of the FQDN.",
+	}
+
+	ds["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "ID of the host in Foreman.",
+	}
+
+	addDataSourceSearch(ds, "name", "fqdn", "id")
+}
+
+// dataSourceForemanHostLookup returns the ID of the host matching the lookup
+// attributes of the data source
+func dataSourceForemanHostLookup(ctx context.Context, d *schema.ResourceData, client *api.Client) (int, error) {
+	if attr, ok := d.GetOk("id"); ok {
+		hostId, err := strconv.Atoi(attr.(string))
+		if err != nil {
+			return 0, fmt.Errorf("Data source host ID [%s] is not a number", attr)
+		}
+		return hostId, nil
+	}
+
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanHost](ctx, client, api.HostEndpointPrefix, search, nil)
+	} else if fqdn, ok := d.GetOk("fqdn"); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanHost](ctx, client, api.HostEndpointPrefix, hostFQDNSearch(fqdn.(string)), nil)
+	} else {
+		queryResponse, queryErr = client.QueryHost(ctx, &api.ForemanHost{
+			ForemanObject: api.ForemanObject{Name: d.Get("name").(string)},
+		})
+	}
+	if queryErr != nil {
+		return 0, queryErr
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return 0, fmt.Errorf("Data source host returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return 0, fmt.Errorf("Data source host returned more than 1 result")
+	}
+
+	queryHost, ok := queryResponse.Results[0].(api.ForemanHost)
+	if !ok {
+		return 0, fmt.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanHost], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	return queryHost.Id, nil
+}
+
+// hostFQDNSearch returns a search matching a host by its FQDN.  Depending on
+// the setting "append_domain_name_for_hosts", Foreman stores the name of a
+// host either as FQDN or as short name together with the domain.
+func hostFQDNSearch(fqdn string) *api.SearchQuery {
+	search := api.SearchBy("name", fqdn)
+	if shortname, domain, found := strings.Cut(fqdn, "."); found {
+		search.OrQuery(
+			api.SearchBy("name", shortname).And("domain", api.SearchEqual, domain),
+		)
+	}
+	return search
+}
+
+// dataSourceForemanHosts returns all hosts matching a search.  The attributes
+// of the results are limited to the ones returned by the hosts index, which
+// does not include interfaces, parameters or compute attributes.
+func dataSourceForemanHosts() *schema.Resource {
+	singular := dataSourceForemanHost()
+	for _, attr := range []string{
+		"interfaces_attributes",
+		"parameters",
+		"compute_attributes",
+		"puppet_class_ids",
+		"config_group_ids",
+	} {
+		delete(singular.Schema, attr)
+	}
+
+	return dataSourceForemanListWithError(
+		singular,
+		"List of hosts matching a Foreman scoped search, ie: "+
+			"`hostgroup_title = web`.",
+		staticEndpoint(api.HostEndpointPrefix),
+		setResourceDataFromForemanHostIndex,
+	)
+}
+
+// setResourceDataFromForemanHostIndex sets a ResourceData's attributes from a
+// ForemanHost returned by the hosts index.  The short name is not part of the
+// index response and is derived from the name.
+func setResourceDataFromForemanHostIndex(d *schema.ResourceData, fh *api.ForemanHost) error {
+	if fh.Shortname == "" {
+		fh.Shortname, _, _ = strings.Cut(fh.Name, ".")
+	}
+	return setResourceDataFromForemanHost(d, fh)
+}
@@ -0,0 +1,87 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanHostFacts() *schema.Resource {
+	ds := map[string]*schema.Schema{
+
+		autodoc.MetaAttribute: {
+			Type:     schema.TypeBool,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"%s The facts reported for a host, ie: by Puppet, Ansible or "+
+					"subscription-manager.",
+				autodoc.MetaSummary,
+			),
+		},
+
+		"name_filter": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: fmt.Sprintf(
+				"Only facts whose name contains the value are read. If "+
+					"omitted, all facts of the host are read. "+
+					"%s \"os::\"",
+				autodoc.MetaExample,
+			),
+		},
+
+		"facts": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "Facts of the host by name. Structured facts are " +
+				"flattened, ie: \"os::release::major\".",
+		},
+	}
+
+	addDataSourceForemanHostLookup(ds)
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanHostFactsRead,
+
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanHostFactsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_host_facts.go#Read")
+
+	client := meta.(*api.Client)
+
+	hostId, lookupErr := dataSourceForemanHostLookup(ctx, d, client)
+	if lookupErr != nil {
+		return diag.FromErr(lookupErr)
+	}
+
+	var search *api.SearchQuery
+	if nameFilter, ok := d.GetOk("name_filter"); ok {
+		search = api.NewSearchQuery().And("name", api.SearchLike, nameFilter.(string))
+	}
+
+	facts, readErr := client.ReadHostFacts(ctx, hostId, search)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	log.Debugf("Read facts of host [%d]: [%d]", hostId, len(facts))
+
+	d.SetId(strconv.Itoa(hostId))
+	d.Set("facts", facts)
+
+	return nil
+}
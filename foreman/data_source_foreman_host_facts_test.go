@@ -0,0 +1,45 @@
+package foreman
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+// Ensures the facts of the looked up host are read with the name filter
+func TestDataSourceForemanHostFacts_Read(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+		bytes, _ := os.ReadFile(HostsTestDataPath + "/query_response_single.json")
+		w.Write(bytes)
+	})
+	var search string
+	mux.HandleFunc(HostsURI+"/34068/facts", func(w http.ResponseWriter, r *http.Request) {
+		search = r.URL.Query().Get("search")
+		bytes, _ := os.ReadFile("testdata/3.11/hosts/facts_response.json")
+		w.Write(bytes)
+	})
+
+	ds := dataSourceForemanHostFacts()
+	d := ds.TestResourceData()
+	d.Set("name", "foremanterraformtest.dev.company.com")
+	d.Set("name_filter", "os::")
+
+	diags := ds.ReadContext(context.TODO(), d, client)
+	if diags.HasError() {
+		t.Fatalf("Data source read returned error [%+v]", diags)
+	}
+
+	if search != `name ~ "os::"` {
+		t.Fatalf("Data source read did not send the name filter, got [%s]", search)
+	}
+	facts := d.Get("facts").(map[string]interface{})
+	if d.Id() != "34068" || len(facts) != 5 || facts["os::release::major"] != "8" {
+		t.Fatalf("Data source read did not set the facts, got ID [%s] and facts [%v]", d.Id(), facts)
+	}
+}
@@ -0,0 +1,100 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanHostStatus() *schema.Resource {
+	ds := map[string]*schema.Schema{
+
+		autodoc.MetaAttribute: {
+			Type:     schema.TypeBool,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"%s The global status and the sub-statuses of a host, ie: to "+
+					"wait for a healthy host before it is used.",
+				autodoc.MetaSummary,
+			),
+		},
+
+		"healthy": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the global status of the host is OK.",
+		},
+	}
+
+	for _, statusType := range api.HostStatusTypes {
+		untracked := ""
+		if statusType != "global" {
+			untracked = " Not set if Foreman does not track the status."
+		}
+		ds[statusType+"_status"] = &schema.Schema{
+			Type:     schema.TypeInt,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"Severity of the %s status: `0` (OK), `1` (warning) or `2` "+
+					"(error).%s",
+				statusType,
+				untracked,
+			),
+		}
+		ds[statusType+"_status_label"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("Label of the %s status.%s", statusType, untracked),
+		}
+	}
+
+	addDataSourceForemanHostLookup(ds)
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanHostStatusRead,
+
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanHostStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_host_status.go#Read")
+
+	client := meta.(*api.Client)
+
+	hostId, lookupErr := dataSourceForemanHostLookup(ctx, d, client)
+	if lookupErr != nil {
+		return diag.FromErr(lookupErr)
+	}
+
+	for _, statusType := range api.HostStatusTypes {
+		status, readErr := client.ReadHostStatus(ctx, hostId, statusType)
+
+		// NOTE(ALL): sub-statuses of plugins which are not installed, ie:
+		//   the execution status, are not found
+		if httpErr, ok := readErr.(api.HTTPError); ok && httpErr.StatusCode == 404 && statusType != "global" {
+			log.Debugf("Host [%d] has no %s status", hostId, statusType)
+			continue
+		} else if readErr != nil {
+			return diag.FromErr(readErr)
+		}
+
+		d.Set(statusType+"_status", status.Status)
+		d.Set(statusType+"_status_label", status.StatusLabel)
+		if statusType == "global" {
+			d.Set("healthy", status.Status == api.HostStatusOK)
+		}
+	}
+
+	d.SetId(strconv.Itoa(hostId))
+
+	return nil
+}
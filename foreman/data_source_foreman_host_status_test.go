@@ -0,0 +1,63 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+// Ensures the statuses of the looked up host are read and statuses Foreman
+// does not track are left unset
+func TestDataSourceForemanHostStatus_Read(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+		bytes, _ := os.ReadFile(HostsTestDataPath + "/query_response_single.json")
+		w.Write(bytes)
+	})
+	statuses := map[string]string{
+		"global":        `{"status": 1, "status_label": "Warning"}`,
+		"configuration": `{"status": 1, "status_label": "Out of sync"}`,
+		"build":         `{"status": 0, "status_label": "Installed"}`,
+	}
+	mux.HandleFunc(HostsURI+"/34068/status/", func(w http.ResponseWriter, r *http.Request) {
+		status, ok := statuses[strings.TrimPrefix(r.URL.Path, HostsURI+"/34068/status/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"message": "Status not found"}}`)
+			return
+		}
+		fmt.Fprint(w, status)
+	})
+
+	ds := dataSourceForemanHostStatus()
+	d := ds.TestResourceData()
+	d.Set("name", "foremanterraformtest.dev.company.com")
+
+	diags := ds.ReadContext(context.TODO(), d, client)
+	if diags.HasError() {
+		t.Fatalf("Data source read returned error [%+v]", diags)
+	}
+
+	if d.Id() != "34068" || d.Get("healthy") != false || d.Get("global_status") != 1 ||
+		d.Get("configuration_status_label") != "Out of sync" || d.Get("build_status_label") != "Installed" {
+		t.Fatalf("Data source read did not set the statuses, got [%v]", d.State().Attributes)
+	}
+	if _, ok := d.GetOk("execution_status_label"); ok {
+		t.Fatalf("Data source read set the untracked execution status, got [%v]", d.Get("execution_status_label"))
+	}
+
+	// the global status is required
+	delete(statuses, "global")
+	d = ds.TestResourceData()
+	d.Set("name", "foremanterraformtest.dev.company.com")
+	if diags := ds.ReadContext(context.TODO(), d, client); !diags.HasError() {
+		t.Fatalf("Data source read without a global status did not return an error")
+	}
+}
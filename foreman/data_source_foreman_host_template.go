@@ -0,0 +1,81 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceForemanHostTemplate() *schema.Resource {
+	ds := map[string]*schema.Schema{
+
+		autodoc.MetaAttribute: {
+			Type:     schema.TypeBool,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"%s A provisioning template rendered for a host, ie: to "+
+					"inspect the kickstart or user data before the host is "+
+					"rebuilt.",
+				autodoc.MetaSummary,
+			),
+		},
+
+		"template_kind": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+			Description: fmt.Sprintf(
+				"Name of the template kind to render, ie: the name of a "+
+					"`foreman_templatekind`. Foreman renders the template of "+
+					"this kind it would use to build the host. "+
+					"%s \"provision\"",
+				autodoc.MetaExample,
+			),
+		},
+
+		"rendered": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The rendered template.",
+		},
+	}
+
+	addDataSourceForemanHostLookup(ds)
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanHostTemplateRead,
+
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanHostTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_host_template.go#Read")
+
+	client := meta.(*api.Client)
+
+	hostId, lookupErr := dataSourceForemanHostLookup(ctx, d, client)
+	if lookupErr != nil {
+		return diag.FromErr(lookupErr)
+	}
+
+	kind := d.Get("template_kind").(string)
+	rendered, renderErr := client.RenderHostTemplate(ctx, hostId, kind)
+	if renderErr != nil {
+		return diag.Errorf("Rendering the [%s] template of host [%d] failed: %s", kind, hostId, renderErr)
+	}
+
+	d.SetId(strconv.Itoa(hostId))
+	d.Set("rendered", rendered)
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+// Ensures the template of the requested kind is rendered for the looked up
+// host and returned as-is
+func TestDataSourceForemanHostTemplate_Read(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+		bytes, _ := os.ReadFile(HostsTestDataPath + "/query_response_single.json")
+		w.Write(bytes)
+	})
+	templates := map[string]string{
+		"provision": "#version=RHEL8\nurl --url http://mirror.dev.company.com/centos/7.4\n",
+		"user_data": "#cloud-config\nhostname: foremanterraformtest\n",
+	}
+	mux.HandleFunc(HostsURI+"/34068/template/", func(w http.ResponseWriter, r *http.Request) {
+		kind := strings.TrimPrefix(r.URL.Path, HostsURI+"/34068/template/")
+		template, ok := templates[kind]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"error": {"message": "No template with kind %s for foremanterraformtest.dev.company.com"}}`, kind)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, template)
+	})
+
+	ds := dataSourceForemanHostTemplate()
+	for kind, template := range templates {
+		d := ds.TestResourceData()
+		d.Set("name", "foremanterraformtest.dev.company.com")
+		d.Set("template_kind", kind)
+
+		diags := ds.ReadContext(context.TODO(), d, client)
+		if diags.HasError() {
+			t.Fatalf("Data source read of [%s] returned error [%+v]", kind, diags)
+		}
+		if d.Id() != "34068" || d.Get("rendered") != template {
+			t.Fatalf("Data source read of [%s] did not set the template, got [%s]", kind, d.Get("rendered"))
+		}
+	}
+
+	d := ds.TestResourceData()
+	d.Set("name", "foremanterraformtest.dev.company.com")
+	d.Set("template_kind", "PXELinux")
+	if diags := ds.ReadContext(context.TODO(), d, client); !diags.HasError() {
+		t.Fatalf("Data source read of a kind without template did not return an error")
+	}
+}
@@ -0,0 +1,170 @@
+package foreman
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+// ----------------------------------------------------------------------------
+// Lookup
+// ----------------------------------------------------------------------------
+
+// Ensures the host data source sends the correct search for each lookup
+// attribute and reads the found host
+func TestDataSourceForemanHost_Lookup(t *testing.T) {
+	testCases := []struct {
+		Attr     string
+		Value    string
+		Expected string
+	}{
+		{
+			Attr:     "name",
+			Value:    "foremanterraformtest.dev.company.com",
+			Expected: `name = "foremanterraformtest.dev.company.com"`,
+		},
+		{
+			Attr:  "fqdn",
+			Value: "foremanterraformtest.dev.company.com",
+			Expected: `name = "foremanterraformtest.dev.company.com" or ` +
+				`(name = "foremanterraformtest" and domain = "dev.company.com")`,
+		},
+		{
+			Attr:     "search",
+			Value:    "hostgroup_title = web",
+			Expected: `(hostgroup_title = web)`,
+		},
+		{
+			Attr:     "id",
+			Value:    "34068",
+			Expected: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		var search string
+		var queried bool
+		mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+			queried = true
+			search = r.URL.Query().Get("search")
+			bytes, _ := os.ReadFile(HostsTestDataPath + "/query_response_single.json")
+			w.Write(bytes)
+		})
+		var read bool
+		mux.HandleFunc(HostsURI+"/34068", func(w http.ResponseWriter, r *http.Request) {
+			read = true
+			bytes, _ := os.ReadFile(HostsTestDataPath + "/read_response.json")
+			w.Write(bytes)
+		})
+
+		ds := dataSourceForemanHost()
+		d := ds.TestResourceData()
+		d.Set(testCase.Attr, testCase.Value)
+
+		diags := ds.ReadContext(context.TODO(), d, client)
+		server.Close()
+		if diags.HasError() {
+			t.Fatalf("Data source read by [%s] returned error [%+v]", testCase.Attr, diags)
+		}
+
+		if queried != (testCase.Expected != "") || search != testCase.Expected {
+			t.Fatalf(
+				"Data source read by [%s] did not send the correct search. "+
+					"Expected [%s], got [%s]",
+				testCase.Attr,
+				testCase.Expected,
+				search,
+			)
+		}
+		if !read {
+			t.Fatalf("Data source read by [%s] did not read the host", testCase.Attr)
+		}
+		if d.Id() != "34068" || d.Get("fqdn") != "foremanterraformtest.dev.company.com" {
+			t.Fatalf(
+				"Data source read by [%s] did not set the attributes of the host, got ID [%s] and FQDN [%s]",
+				testCase.Attr,
+				d.Id(),
+				d.Get("fqdn"),
+			)
+		}
+		if len(d.Get("interfaces_attributes").([]interface{})) == 0 {
+			t.Fatalf("Data source read by [%s] did not set the interfaces of the host", testCase.Attr)
+		}
+	}
+}
+
+// Ensures the host data source fails if the lookup is ambiguous or does not
+// match any host
+func TestDataSourceForemanHost_LookupError(t *testing.T) {
+	testCases := []string{
+		TestDataPath + "/query_response_zero.json",
+		HostsTestDataPath + "/query_response_multi.json",
+	}
+
+	for _, responseFile := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+			bytes, _ := os.ReadFile(responseFile)
+			w.Write(bytes)
+		})
+
+		ds := dataSourceForemanHost()
+		d := ds.TestResourceData()
+		d.Set("name", "foremanterraformtest")
+
+		diags := ds.ReadContext(context.TODO(), d, client)
+		server.Close()
+		if !diags.HasError() {
+			t.Fatalf("Data source read with response [%s] did not return an error", responseFile)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Plural Data Source
+// ----------------------------------------------------------------------------
+
+// Ensures the hosts data source returns all hosts matching the search
+func TestDataSourceForemanHosts_Results(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	var search string
+	mux.HandleFunc(HostsURI, func(w http.ResponseWriter, r *http.Request) {
+		search = r.URL.Query().Get("search")
+		bytes, _ := os.ReadFile(HostsTestDataPath + "/query_response_multi.json")
+		w.Write(bytes)
+	})
+
+	ds := dataSourceForemanHosts()
+	d := ds.TestResourceData()
+	d.Set("search", "hostgroup_title = web")
+
+	diags := ds.ReadContext(context.TODO(), d, client)
+	if diags.HasError() {
+		t.Fatalf("Data source read returned error [%+v]", diags)
+	}
+
+	if search != "(hostgroup_title = web)" {
+		t.Fatalf("Data source did not send the search. Expected [(hostgroup_title = web)], got [%s]", search)
+	}
+
+	results := d.Get("results").([]interface{})
+	if len(results) != 2 {
+		t.Fatalf("Data source did not return all results. Expected [2], got [%d]", len(results))
+	}
+
+	second := results[1].(map[string]interface{})
+	if second["id"] != 34069 ||
+		second["fqdn"] != "foremanterraformtest2.dev.company.com" ||
+		second["shortname"] != "foremanterraformtest2" ||
+		second["hostgroup_id"] != 98 {
+		t.Fatalf("Data source did not set the attributes of the second result, got [%+v]", second)
+	}
+}
@@ -33,6 +33,8 @@ func dataSourceForemanHostgroup() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "title")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanHostgroupRead,
@@ -50,7 +52,13 @@ func dataSourceForemanHostgroupRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("ForemanHostgroup: [%+v]", h)
 
-	queryResponse, queryErr := client.QueryHostgroup(ctx, h)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanHostgroup](ctx, client, api.HostgroupEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryHostgroup(ctx, h)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -78,3 +86,13 @@ func dataSourceForemanHostgroupRead(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+// dataSourceForemanHostgroups returns all hostgroups matching a search
+func dataSourceForemanHostgroups() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanHostgroup(),
+		"List of hostgroups matching a Foreman scoped search.",
+		staticEndpoint(api.HostgroupEndpointPrefix),
+		setResourceDataFromForemanHostgroup,
+	)
+}
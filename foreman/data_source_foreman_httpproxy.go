@@ -29,6 +29,8 @@ func dataSourceForemanHTTPProxy() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanHTTPProxyRead,
@@ -46,7 +48,13 @@ func dataSourceForemanHTTPProxyRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("ForemanHTTPProxy: [%+v]", s)
 
-	queryResponse, queryErr := client.QueryHTTPProxy(ctx, s)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanHTTPProxy](ctx, client, api.HTTPProxyEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryHTTPProxy(ctx, s)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanHTTPProxyRead(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+// dataSourceForemanHTTPProxies returns all HTTP proxies matching a search
+func dataSourceForemanHTTPProxies() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanHTTPProxy(),
+		"List of HTTP proxies matching a Foreman scoped search.",
+		staticEndpoint(api.HTTPProxyEndpointPrefix),
+		setResourceDataFromForemanHTTPProxy,
+	)
+}
@@ -30,6 +30,8 @@ func dataSourceForemanImage() *schema.Resource {
 		Description: "The id of the Compute Resource the image is associated with",
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanImageRead,
@@ -47,7 +49,13 @@ func dataSourceForemanImageRead(ctx context.Context, d *schema.ResourceData, met
 
 	log.Debugf("ForemanImage: [%+v]", image)
 
-	queryResponse, queryErr := client.QueryImage(ctx, image)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanImage](ctx, client, fmt.Sprintf("%s/%d/images", api.ComputeResourceEndpoint, image.ComputeResourceID), search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryImage(ctx, image)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -27,6 +27,8 @@ func dataSourceForemanJobTemplate() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanJobTemplateRead,
 		Schema:      ds,
@@ -39,7 +41,13 @@ func dataSourceForemanJobTemplateRead(ctx context.Context, d *schema.ResourceDat
 	client := meta.(*api.Client)
 	jt := buildForemanJobTemplate(d)
 
-	queryResponse, err := client.QueryJobTemplate(ctx, jt)
+	var queryResponse api.QueryResponse
+	var err error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, err = api.SearchAll[api.ForemanJobTemplate](ctx, client, api.JobTemplateEndpointPrefix, search, nil)
+	} else {
+		queryResponse, err = client.QueryJobTemplate(ctx, jt)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -66,3 +74,13 @@ func dataSourceForemanJobTemplateRead(ctx context.Context, d *schema.ResourceDat
 
 	return nil
 }
+
+// dataSourceForemanJobTemplates returns all job templates matching a search
+func dataSourceForemanJobTemplates() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanJobTemplate(),
+		"List of job templates matching a Foreman scoped search.",
+		staticEndpoint(api.JobTemplateEndpointPrefix),
+		setResourceDataFromForemanJobTemplate,
+	)
+}
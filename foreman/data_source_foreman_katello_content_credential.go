@@ -28,6 +28,8 @@ func dataSourceForemanKatelloContentCredential() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanKatelloContentCredentialRead,
@@ -45,7 +47,13 @@ func dataSourceForemanKatelloContentCredentialRead(ctx context.Context, d *schem
 
 	log.Debugf("ForemanKatelloContentCredential: [%+v]", contentCredential)
 
-	queryResponse, queryErr := client.QueryKatelloContentCredential(ctx, contentCredential)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanKatelloContentCredential](ctx, client, api.KatelloContentCredentialEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryKatelloContentCredential(ctx, contentCredential)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -73,3 +81,13 @@ func dataSourceForemanKatelloContentCredentialRead(ctx context.Context, d *schem
 
 	return nil
 }
+
+// dataSourceForemanKatelloContentCredentials returns all Katello content credentials matching a search
+func dataSourceForemanKatelloContentCredentials() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanKatelloContentCredential(),
+		"List of Katello content credentials matching a Foreman scoped search.",
+		organizationEndpoint(api.KatelloContentCredentialEndpointPrefix),
+		setResourceDataFromForemanKatelloContentCredential,
+	)
+}
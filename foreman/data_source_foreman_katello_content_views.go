@@ -22,6 +22,8 @@ func dataSourceForemanKatelloContentView() *schema.Resource {
 		Description: fmt.Sprintf("Name of the content view. %s \"my content view\"", autodoc.MetaExample),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanKatelloContentViewRead,
 		Schema:      ds,
@@ -36,7 +38,13 @@ func dataSourceForemanKatelloContentViewRead(ctx context.Context, d *schema.Reso
 
 	utils.Debugf("cv: %+v", cv)
 
-	queryResponse, err := client.QueryContentView(ctx, cv)
+	var queryResponse api.QueryResponse
+	var err error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, err = api.SearchAll[api.ContentView](ctx, client, api.ContentViewEndpointPrefix, search, client.OrganizationParams())
+	} else {
+		queryResponse, err = client.QueryContentView(ctx, cv)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanKatelloContentViewRead(ctx context.Context, d *schema.Reso
 
 	return nil
 }
+
+// dataSourceForemanKatelloContentViews returns all Katello content views matching a search
+func dataSourceForemanKatelloContentViews() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanKatelloContentView(),
+		"List of Katello content views matching a Foreman scoped search.",
+		organizationEndpoint(api.ContentViewEndpointPrefix),
+		setResourceDataFromForemanKatelloContentView,
+	)
+}
@@ -22,6 +22,8 @@ func dataSourceForemanKatelloLifecycleEnvironment() *schema.Resource {
 		Description: fmt.Sprintf("Name of the lifecycle environment. %s \"Library\"", autodoc.MetaExample),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanKatelloLifecycleRead,
 		Schema:      ds,
@@ -36,7 +38,13 @@ func dataSourceForemanKatelloLifecycleRead(ctx context.Context, d *schema.Resour
 
 	utils.Debugf("lifecycle env: %+v", lce)
 
-	queryResponse, err := client.QueryLifecycleEnvironment(ctx, lce)
+	var queryResponse api.QueryResponse
+	var err error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, err = api.SearchAll[api.LifecycleEnvironment](ctx, client, api.LifecycleEnvironmentEndpointPrefix, search, client.OrganizationParams())
+	} else {
+		queryResponse, err = client.QueryLifecycleEnvironment(ctx, lce)
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -63,3 +71,13 @@ func dataSourceForemanKatelloLifecycleRead(ctx context.Context, d *schema.Resour
 
 	return nil
 }
+
+// dataSourceForemanKatelloLifecycleEnvironments returns all Katello lifecycle environments matching a search
+func dataSourceForemanKatelloLifecycleEnvironments() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanKatelloLifecycleEnvironment(),
+		"List of Katello lifecycle environments matching a Foreman scoped search.",
+		organizationEndpoint(api.LifecycleEnvironmentEndpointPrefix),
+		setResourceDataFromForemanKatelloLifecycleEnvironment,
+	)
+}
@@ -28,6 +28,8 @@ func dataSourceForemanKatelloProduct() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanKatelloProductRead,
@@ -45,7 +47,13 @@ func dataSourceForemanKatelloProductRead(ctx context.Context, d *schema.Resource
 
 	log.Debugf("ForemanKatelloProduct: [%+v]", product)
 
-	queryResponse, queryErr := client.QueryKatelloProduct(ctx, product)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanKatelloProduct](ctx, client, api.KatelloProductEndpointPrefix, search, client.OrganizationParams())
+	} else {
+		queryResponse, queryErr = client.QueryKatelloProduct(ctx, product)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -73,3 +81,13 @@ func dataSourceForemanKatelloProductRead(ctx context.Context, d *schema.Resource
 
 	return nil
 }
+
+// dataSourceForemanKatelloProducts returns all Katello products matching a search
+func dataSourceForemanKatelloProducts() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanKatelloProduct(),
+		"List of Katello products matching a Foreman scoped search.",
+		organizationEndpoint(api.KatelloProductEndpointPrefix),
+		setResourceDataFromForemanKatelloProduct,
+	)
+}
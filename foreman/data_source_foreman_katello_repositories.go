@@ -28,6 +28,8 @@ func dataSourceForemanKatelloRepository() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanKatelloRepositoryRead,
@@ -45,7 +47,13 @@ func dataSourceForemanKatelloRepositoryRead(ctx context.Context, d *schema.Resou
 
 	log.Debugf("ForemanKatelloRepository: [%+v]", repository)
 
-	queryResponse, queryErr := client.QueryKatelloRepository(ctx, repository)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanKatelloRepository](ctx, client, api.KatelloRepositoryEndpointPrefix, search, client.OrganizationParams())
+	} else {
+		queryResponse, queryErr = client.QueryKatelloRepository(ctx, repository)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -73,3 +81,13 @@ func dataSourceForemanKatelloRepositoryRead(ctx context.Context, d *schema.Resou
 
 	return nil
 }
+
+// dataSourceForemanKatelloRepositories returns all Katello repositories matching a search
+func dataSourceForemanKatelloRepositories() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanKatelloRepository(),
+		"List of Katello repositories matching a Foreman scoped search.",
+		organizationEndpoint(api.KatelloRepositoryEndpointPrefix),
+		setResourceDataFromForemanKatelloRepository,
+	)
+}
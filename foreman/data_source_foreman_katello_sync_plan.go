@@ -28,6 +28,8 @@ func dataSourceForemanKatelloSyncPlan() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanKatelloSyncPlanRead,
@@ -45,7 +47,13 @@ func dataSourceForemanKatelloSyncPlanRead(ctx context.Context, d *schema.Resourc
 
 	log.Debugf("ForemanKatelloSyncPlan: [%+v]", syncPlan)
 
-	queryResponse, queryErr := client.QueryKatelloSyncPlan(ctx, syncPlan)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanKatelloSyncPlan](ctx, client, api.KatelloSyncPlanIndexEndpoint, search, client.OrganizationParams())
+	} else {
+		queryResponse, queryErr = client.QueryKatelloSyncPlan(ctx, syncPlan)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -73,3 +81,13 @@ func dataSourceForemanKatelloSyncPlanRead(ctx context.Context, d *schema.Resourc
 
 	return nil
 }
+
+// dataSourceForemanKatelloSyncPlans returns all Katello sync plans matching a search
+func dataSourceForemanKatelloSyncPlans() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanKatelloSyncPlan(),
+		"List of Katello sync plans matching a Foreman scoped search.",
+		organizationEndpoint(api.KatelloSyncPlanIndexEndpoint),
+		setResourceDataFromForemanKatelloSyncPlan,
+	)
+}
@@ -0,0 +1,102 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanLocation() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanLocation()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"Name of the location. %s \"Berlin\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["title"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The title is the path-like name of a nested location, in the "+
+				"form of: \"<parent 1>/<parent 2>/.../<name>\". %s \"Europe/Germany/Berlin\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	addDataSourceSearch(ds, "name", "title")
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanLocationRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_location.go#Read")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanLocation](ctx, client, api.LocationEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryLocation(ctx, l)
+	}
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source location returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source location returned more than 1 result")
+	}
+
+	var queryLocation api.ForemanLocation
+	var ok bool
+	if queryLocation, ok = queryResponse.Results[0].(api.ForemanLocation); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanLocation], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	l = &queryLocation
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	setResourceDataFromForemanLocation(d, l)
+
+	return nil
+}
+
+// dataSourceForemanLocations returns all locations matching a search
+func dataSourceForemanLocations() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanLocation(),
+		"List of locations matching a Foreman scoped search.",
+		staticEndpoint(api.LocationEndpointPrefix),
+		setResourceDataFromForemanLocation,
+	)
+}
@@ -29,6 +29,8 @@ func dataSourceForemanMedia() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanMediaRead,
@@ -46,7 +48,13 @@ func dataSourceForemanMediaRead(ctx context.Context, d *schema.ResourceData, met
 
 	log.Debugf("ForemanMedia: [%+v]", m)
 
-	queryResponse, queryErr := client.QueryMedia(ctx, m)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanMedia](ctx, client, api.MediaEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryMedia(ctx, m)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanMediaRead(ctx context.Context, d *schema.ResourceData, met
 
 	return nil
 }
+
+// dataSourceForemanMedias returns all installation media matching a search
+func dataSourceForemanMedias() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanMedia(),
+		"List of installation media matching a Foreman scoped search.",
+		staticEndpoint(api.MediaEndpointPrefix),
+		setResourceDataFromForemanMedia,
+	)
+}
@@ -29,6 +29,8 @@ func dataSourceForemanModel() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanModelRead,
@@ -46,7 +48,13 @@ func dataSourceForemanModelRead(ctx context.Context, d *schema.ResourceData, met
 
 	log.Debugf("ForemanModel: [%+v]", m)
 
-	queryResponse, queryErr := client.QueryModel(ctx, m)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanModel](ctx, client, api.ModelEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryModel(ctx, m)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanModelRead(ctx context.Context, d *schema.ResourceData, met
 
 	return nil
 }
+
+// dataSourceForemanModels returns all hardware models matching a search
+func dataSourceForemanModels() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanModel(),
+		"List of hardware models matching a Foreman scoped search.",
+		staticEndpoint(api.ModelEndpointPrefix),
+		setResourceDataFromForemanModel,
+	)
+}
@@ -31,6 +31,8 @@ func dataSourceForemanOperatingSystem() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "title")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanOperatingSystemRead,
@@ -48,7 +50,13 @@ func dataSourceForemanOperatingSystemRead(ctx context.Context, d *schema.Resourc
 
 	log.Debugf("ForemanOperatingSystem: [%+v]", o)
 
-	queryResponse, queryErr := client.QueryOperatingSystem(ctx, o)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanOperatingSystem](ctx, client, api.OperatingSystemEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryOperatingSystem(ctx, o)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -76,3 +84,13 @@ func dataSourceForemanOperatingSystemRead(ctx context.Context, d *schema.Resourc
 
 	return nil
 }
+
+// dataSourceForemanOperatingSystems returns all operating systems matching a search
+func dataSourceForemanOperatingSystems() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanOperatingSystem(),
+		"List of operating systems matching a Foreman scoped search.",
+		staticEndpoint(api.OperatingSystemEndpointPrefix),
+		setResourceDataFromForemanOperatingSystem,
+	)
+}
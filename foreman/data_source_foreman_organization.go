@@ -0,0 +1,102 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanOrganization() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanOrganization()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"Name of the organization. %s \"Default Organization\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["title"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The title is the path-like name of a nested organization, in the "+
+				"form of: \"<parent 1>/<parent 2>/.../<name>\". %s \"ACME/Sales\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	addDataSourceSearch(ds, "name", "title")
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanOrganizationRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_organization.go#Read")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanOrganization](ctx, client, api.OrganizationEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryOrganization(ctx, o)
+	}
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source organization returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source organization returned more than 1 result")
+	}
+
+	var queryOrganization api.ForemanOrganization
+	var ok bool
+	if queryOrganization, ok = queryResponse.Results[0].(api.ForemanOrganization); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanOrganization], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	o = &queryOrganization
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	setResourceDataFromForemanOrganization(d, o)
+
+	return nil
+}
+
+// dataSourceForemanOrganizations returns all organizations matching a search
+func dataSourceForemanOrganizations() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanOrganization(),
+		"List of organizations matching a Foreman scoped search.",
+		staticEndpoint(api.OrganizationEndpointPrefix),
+		setResourceDataFromForemanOrganization,
+	)
+}
@@ -29,6 +29,8 @@ func dataSourceForemanPartitionTable() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanPartitionTableRead,
@@ -46,7 +48,13 @@ func dataSourceForemanPartitionTableRead(ctx context.Context, d *schema.Resource
 
 	log.Debugf("ForemanPartitionTable: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryPartitionTable(ctx, t)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanPartitionTable](ctx, client, api.PartitionTableEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryPartitionTable(ctx, t)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanPartitionTableRead(ctx context.Context, d *schema.Resource
 
 	return nil
 }
+
+// dataSourceForemanPartitionTables returns all partition tables matching a search
+func dataSourceForemanPartitionTables() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanPartitionTable(),
+		"List of partition tables matching a Foreman scoped search.",
+		staticEndpoint(api.PartitionTableEndpointPrefix),
+		setResourceDataFromForemanPartitionTable,
+	)
+}
@@ -0,0 +1,143 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanPermission() *schema.Resource {
+	ds := &schema.Resource{
+
+		ReadContext: dataSourceForemanPermissionRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Permissions are defined by Foreman and its plugins and "+
+						"are granted by the filters of a role.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the permission. "+
+						"%s \"view_hosts\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"Resource type the permission applies to. "+
+						"%s \"Host\"",
+					autodoc.MetaExample,
+				),
+			},
+		},
+	}
+
+	addDataSourceSearch(ds.Schema, "name")
+
+	return ds
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanPermission constructs a ForemanPermission reference from a
+// resource data reference.  The struct's members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanPermission(d *schema.ResourceData) *api.ForemanPermission {
+	p := api.ForemanPermission{}
+	obj := buildForemanObject(d)
+	p.ForemanObject = *obj
+	if attr, ok := d.GetOk("resource_type"); ok {
+		p.ResourceType = attr.(string)
+	}
+	return &p
+}
+
+// setResourceDataFromForemanPermission sets a ResourceData's attributes from
+// the attributes of the supplied ForemanPermission reference
+func setResourceDataFromForemanPermission(d *schema.ResourceData, fp *api.ForemanPermission) {
+	d.SetId(strconv.Itoa(fp.Id))
+	d.Set("name", fp.Name)
+	d.Set("resource_type", fp.ResourceType)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func dataSourceForemanPermissionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_permission.go#Read")
+
+	client := meta.(*api.Client)
+	p := buildForemanPermission(d)
+
+	log.Debugf("ForemanPermission: [%+v]", p)
+
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanPermission](ctx, client, api.PermissionEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryPermission(ctx, p)
+	}
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source permission returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source permission returned more than 1 result")
+	}
+
+	var queryPermission api.ForemanPermission
+	var ok bool
+	if queryPermission, ok = queryResponse.Results[0].(api.ForemanPermission); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanPermission], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	p = &queryPermission
+
+	log.Debugf("ForemanPermission: [%+v]", p)
+
+	setResourceDataFromForemanPermission(d, p)
+
+	return nil
+}
+
+// dataSourceForemanPermissions returns all permissions matching a search,
+// ie: all permissions of a resource type
+func dataSourceForemanPermissions() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanPermission(),
+		"List of permissions matching a Foreman scoped search, ie: "+
+			"`resource_type = Host`.",
+		staticEndpoint(api.PermissionEndpointPrefix),
+		setResourceDataFromForemanPermission,
+	)
+}
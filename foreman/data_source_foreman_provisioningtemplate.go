@@ -29,6 +29,8 @@ func dataSourceForemanProvisioningTemplate() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanProvisioningTemplateRead,
@@ -46,7 +48,13 @@ func dataSourceForemanProvisioningTemplateRead(ctx context.Context, d *schema.Re
 
 	log.Debugf("ForemanProvisioningTemplate: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryProvisioningTemplate(ctx, t)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanProvisioningTemplate](ctx, client, api.ProvisioningTemplateEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryProvisioningTemplate(ctx, t)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanProvisioningTemplateRead(ctx context.Context, d *schema.Re
 
 	return nil
 }
+
+// dataSourceForemanProvisioningTemplates returns all provisioning templates matching a search
+func dataSourceForemanProvisioningTemplates() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanProvisioningTemplate(),
+		"List of provisioning templates matching a Foreman scoped search.",
+		staticEndpoint(api.ProvisioningTemplateEndpointPrefix),
+		setResourceDataFromForemanProvisioningTemplate,
+	)
+}
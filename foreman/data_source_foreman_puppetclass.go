@@ -14,7 +14,7 @@ import (
 )
 
 func dataSourceForemanPuppetClass() *schema.Resource {
-	return &schema.Resource{
+	ds := &schema.Resource{
 
 		ReadContext: dataSourceForemanPuppetClassRead,
 
@@ -39,6 +39,10 @@ func dataSourceForemanPuppetClass() *schema.Resource {
 			},
 		},
 	}
+
+	addDataSourceSearch(ds.Schema, "name")
+
+	return ds
 }
 
 // -----------------------------------------------------------------------------
@@ -75,7 +79,13 @@ func dataSourceForemanPuppetClassRead(ctx context.Context, d *schema.ResourceDat
 
 	log.Debugf("ForemanPuppetClass: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryPuppetClass(ctx, t)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanPuppetClass](ctx, client, api.PuppetClassEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryPuppetClass(ctx, t)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -103,3 +113,13 @@ func dataSourceForemanPuppetClassRead(ctx context.Context, d *schema.ResourceDat
 
 	return nil
 }
+
+// dataSourceForemanPuppetClasses returns all Puppet classes matching a search
+func dataSourceForemanPuppetClasses() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanPuppetClass(),
+		"List of Puppet classes matching a Foreman scoped search.",
+		staticEndpoint(api.PuppetClassEndpointPrefix),
+		setResourceDataFromForemanPuppetClass,
+	)
+}
@@ -0,0 +1,92 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanRealm() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanRealm()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"Name of the realm. %s \"corp-ad\"",
+			autodoc.MetaExample,
+		),
+	}
+	addDataSourceSearch(ds, "name")
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanRealmRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanRealmRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_realm.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanRealm](ctx, client, api.RealmEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryRealm(ctx, r)
+	}
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source realm returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source realm returned more than 1 result")
+	}
+
+	var queryRealm api.ForemanRealm
+	var ok bool
+	if queryRealm, ok = queryResponse.Results[0].(api.ForemanRealm); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanRealm], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	r = &queryRealm
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	setResourceDataFromForemanRealm(d, r)
+
+	return nil
+}
+
+// dataSourceForemanRealms returns all realms matching a search
+func dataSourceForemanRealms() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanRealm(),
+		"List of realms matching a Foreman scoped search.",
+		staticEndpoint(api.RealmEndpointPrefix),
+		setResourceDataFromForemanRealm,
+	)
+}
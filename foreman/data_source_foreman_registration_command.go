@@ -0,0 +1,189 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DEFAULT_REGISTRATION_JWT_EXPIRATION is the default validity of the token
+// of a registration command in hours, the same as in the Foreman UI
+const DEFAULT_REGISTRATION_JWT_EXPIRATION = 4
+
+func dataSourceForemanRegistrationCommand() *schema.Resource {
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanRegistrationCommandRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s The global registration command, which registers an "+
+						"existing machine as host in Foreman, ie: in cloud-init "+
+						"or with a `remote-exec` provisioner. A new command is "+
+						"generated on every read.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"organization_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the organization of the host. Defaults to the organization of the provider.",
+			},
+			"location_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the location of the host. Defaults to the location of the provider.",
+			},
+			"hostgroup_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: fmt.Sprintf(
+					"ID of the hostgroup of the host. %s 1",
+					autodoc.MetaExample,
+				),
+			},
+			"operatingsystem_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the operating system of the host, which selects the registration template.",
+			},
+			"smart_proxy_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the smart proxy the host registers through.",
+			},
+			"activation_keys": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Names of the Katello activation keys of the host. " +
+					"Only required if the hostgroup has no activation keys.",
+			},
+
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip the verification of the Foreman server certificate when the command is run.",
+			},
+			"jwt_expiration": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DEFAULT_REGISTRATION_JWT_EXPIRATION,
+				ValidateFunc: validation.IntBetween(0, 999999),
+				Description: "Hours the token of the command is valid, `0` for " +
+					"no expiry. Defaults to `4`.",
+			},
+			"setup_insights": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set up Insights on the host. If omitted, the host " +
+					"parameter `host_registration_insights` decides.",
+			},
+			"setup_remote_execution": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Set up remote execution on the host. If omitted, the " +
+					"host parameter `host_registration_remote_execution` decides.",
+			},
+			"update_packages": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Update the packages of the host after the registration.",
+			},
+
+			"command": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The registration command. It contains a token which grants the registration of hosts.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanRegistrationCommand constructs a ForemanRegistrationCommand
+// reference from a resource data reference
+func buildForemanRegistrationCommand(d *schema.ResourceData) *api.ForemanRegistrationCommand {
+	log.Tracef("data_source_foreman_registration_command.go#buildForemanRegistrationCommand")
+
+	rc := api.ForemanRegistrationCommand{
+		OrganizationId:    d.Get("organization_id").(int),
+		LocationId:        d.Get("location_id").(int),
+		HostgroupId:       d.Get("hostgroup_id").(int),
+		OperatingSystemId: d.Get("operatingsystem_id").(int),
+		SmartProxyId:      d.Get("smart_proxy_id").(int),
+		ActivationKeys:    conv.InterfaceSliceToStringSlice(d.Get("activation_keys").([]interface{})),
+		Insecure:          d.Get("insecure").(bool),
+		JWTExpiration:     d.Get("jwt_expiration").(int),
+		UpdatePackages:    d.Get("update_packages").(bool),
+	}
+
+	// NOTE(ALL): false is a value of its own for the setup flags, it
+	//   overrides the host parameters.  Only omitted flags are not sent.
+	rawConfig := d.GetRawConfig()
+	if !rawConfig.IsNull() && rawConfig.IsKnown() {
+		if v := rawConfig.GetAttr("setup_insights"); !v.IsNull() && v.IsKnown() {
+			setupInsights := v.True()
+			rc.SetupInsights = &setupInsights
+		}
+		if v := rawConfig.GetAttr("setup_remote_execution"); !v.IsNull() && v.IsKnown() {
+			setupRemoteExecution := v.True()
+			rc.SetupRemoteExecution = &setupRemoteExecution
+		}
+	}
+
+	return &rc
+}
+
+// -----------------------------------------------------------------------------
+// Data Source Read Implementation
+// -----------------------------------------------------------------------------
+
+func dataSourceForemanRegistrationCommandRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_registration_command.go#Read")
+
+	client := meta.(*api.Client)
+	rc := buildForemanRegistrationCommand(d)
+
+	command, createErr := client.CreateRegistrationCommand(ctx, rc)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	// The ID identifies the options, the token of the command changes on
+	// every read
+	options, _ := json.Marshal(rc)
+	d.SetId(strconv.FormatUint(uint64(crc32.ChecksumIEEE(options)), 10))
+	d.Set("command", command)
+
+	return nil
+}
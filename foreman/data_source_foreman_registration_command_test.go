@@ -0,0 +1,67 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+const RegistrationCommandsURI = api.FOREMAN_API_URL_PREFIX + "/registration_commands"
+
+// Ensures the options are sent wrapped in registration_command, the omitted
+// setup flags are not sent and the returned command is set
+func TestDataSourceForemanRegistrationCommand_Read(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	const command = "set -o pipefail && curl -sS 'https://foreman.dev.company.com/register?activation_keys=rhel9' -H 'Authorization: Bearer token' | bash"
+
+	var sent map[string]interface{}
+	mux.HandleFunc(RegistrationCommandsURI, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected a POST request, got [%s]", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &sent); err != nil {
+			t.Errorf("Request body [%s] is not valid JSON: %s", body, err)
+		}
+		respBody, _ := json.Marshal(map[string]string{"registration_command": command})
+		fmt.Fprint(w, string(respBody))
+	})
+
+	ds := dataSourceForemanRegistrationCommand()
+	d := ds.TestResourceData()
+	d.Set("hostgroup_id", 7)
+	d.Set("activation_keys", []interface{}{"rhel9"})
+	d.Set("jwt_expiration", 0)
+
+	diags := ds.ReadContext(context.TODO(), d, client)
+	if diags.HasError() {
+		t.Fatalf("Data source read returned error [%+v]", diags)
+	}
+
+	rc, ok := sent["registration_command"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("Request body [%+v] has no registration_command", sent)
+	}
+	if rc["hostgroup_id"] != float64(7) || rc["jwt_expiration"] != float64(0) {
+		t.Fatalf("Request did not send the hostgroup and the expiry, got [%+v]", rc)
+	}
+	if keys, ok := rc["activation_keys"].([]interface{}); !ok || len(keys) != 1 || keys[0] != "rhel9" {
+		t.Fatalf("Request did not send the activation keys, got [%+v]", rc["activation_keys"])
+	}
+	for _, flag := range []string{"setup_insights", "setup_remote_execution", "operatingsystem_id"} {
+		if _, ok := rc[flag]; ok {
+			t.Fatalf("Request sent the omitted option [%s]", flag)
+		}
+	}
+
+	if d.Id() == "" || d.Get("command") != command {
+		t.Fatalf("Data source read did not set the command, got [%s]", d.Get("command"))
+	}
+}
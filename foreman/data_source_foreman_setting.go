@@ -3,7 +3,6 @@ package foreman
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
@@ -14,8 +13,8 @@ import (
 )
 
 func dataSourceForemanSetting() *schema.Resource {
-	// Build schema from scratch, because Setting is a data source only.
-	// There is no resource schema we could re-use.
+	// Build schema from scratch, because the resource schema requires the
+	// value and has no search
 
 	dataSourceSchema := map[string]*schema.Schema{
 
@@ -81,6 +80,8 @@ func dataSourceForemanSetting() *schema.Resource {
 		},
 	}
 
+	addDataSourceSearch(dataSourceSchema, "name")
+
 	return &schema.Resource{
 		ReadContext: dataSourceForemanSettingRead,
 		Schema:      dataSourceSchema,
@@ -101,7 +102,13 @@ func dataSourceForemanSettingRead(ctx context.Context, d *schema.ResourceData, m
 
 	log.Debugf("ForemanSetting: [%+v]", setting)
 
-	queryResponse, queryErr := client.QuerySetting(ctx, setting)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanSetting](ctx, client, api.SettingEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QuerySetting(ctx, setting)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -123,29 +130,11 @@ func dataSourceForemanSettingRead(ctx context.Context, d *schema.ResourceData, m
 	}
 	setting = &querySetting
 
-	// Convert boolean or integer values to strings to match the Terraform resource schema.
+	// Convert the values to strings to match the Terraform resource schema.
 	// Foreman uses "boolean", "integer", "array" and "hash", besides "string"/"text", as types in "settings_type".
 	// See https://github.com/theforeman/foreman/blob/0025f26123a22b84052292ed3ef749c91a563274/app/models/setting.rb#L111
-	switch setting.Value.(type) {
-	case bool:
-		setting.Value = strconv.FormatBool(setting.Value.(bool))
-	case int:
-		setting.Value = strconv.FormatInt(setting.Value.(int64), 10)
-	case string:
-	default:
-		// noop
-	}
-
-	// Same for the default value
-	switch setting.Default.(type) {
-	case bool:
-		setting.Default = strconv.FormatBool(setting.Default.(bool))
-	case int:
-		setting.Default = strconv.FormatInt(setting.Default.(int64), 10)
-	case string:
-	default:
-		// noop
-	}
+	setting.Value = api.FormatSettingValue(setting.Value)
+	setting.Default = api.FormatSettingValue(setting.Default)
 
 	log.Debugf("ForemanSetting: [%+v]", setting)
 
@@ -3,9 +3,9 @@ package foreman
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
 
@@ -14,71 +14,40 @@ import (
 )
 
 func dataSourceForemanSmartClassParameter() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanSmartClassParameter()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["parameter"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"Smart class parameter name."+
+				"%s \"example_param\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["puppetclass_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Required: true,
+		Description: fmt.Sprintf(
+			"ID of the puppet class containing this parameter."+
+				"%s 1",
+			autodoc.MetaExample,
+		),
+	}
+	addDataSourceSearch(ds, "parameter")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanSmartClassParameterRead,
 
-		Schema: map[string]*schema.Schema{
-
-			autodoc.MetaAttribute: {
-				Type:     schema.TypeBool,
-				Computed: true,
-				Description: fmt.Sprintf(
-					"%s Foreman representation of a smart class parameter.",
-					autodoc.MetaSummary,
-				),
-			},
-
-			"parameter": {
-				Type:     schema.TypeString,
-				Required: true,
-				Description: fmt.Sprintf(
-					"Smart class parameter name."+
-						"%s \"example_param\"",
-					autodoc.MetaExample,
-				),
-			},
-
-			"puppetclass_id": {
-				Type:     schema.TypeInt,
-				Required: true,
-				Description: fmt.Sprintf(
-					"ID of the puppet class containing this parameter."+
-						"%s 1",
-					autodoc.MetaExample,
-				),
-			},
-		},
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
 	}
 }
 
-// -----------------------------------------------------------------------------
-// Conversion Helpers
-// -----------------------------------------------------------------------------
-
-// buildForemanSmartClassParameter constructs a ForemanSmartClassParameter reference from a
-// resource data reference.  The struct's  members are populated from the data
-// populated in the resource data.  Missing members will be left to the zero
-// value for that member's type.
-func buildForemanSmartClassParameter(d *schema.ResourceData) *api.ForemanSmartClassParameter {
-	t := api.ForemanSmartClassParameter{}
-	obj := buildForemanObject(d)
-	t.ForemanObject = *obj
-
-	t.Parameter = d.Get("parameter").(string)
-	t.PuppetClassId = d.Get("puppetclass_id").(int)
-
-	return &t
-}
-
-// setResourceDataFromForemanSmartClassParameter sets a ResourceData's attributes from
-// the attributes of the supplied ForemanSmartClassParameter reference
-func setResourceDataFromForemanSmartClassParameter(d *schema.ResourceData, fk *api.ForemanSmartClassParameter) {
-	d.SetId(strconv.Itoa(fk.Id))
-	d.Set("parameter", fk.Parameter)
-	d.Set("puppetclass_id", fk.PuppetClassId)
-}
-
 // -----------------------------------------------------------------------------
 // Resource CRUD Operations
 // -----------------------------------------------------------------------------
@@ -91,7 +60,13 @@ func dataSourceForemanSmartClassParameterRead(ctx context.Context, d *schema.Res
 
 	log.Debugf("ForemanSmartClassParameter: [%+v]", t)
 
-	queryResponse, queryErr := client.QuerySmartClassParameter(ctx, t)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanSmartClassParameter](ctx, client, fmt.Sprintf(api.SmartClassParameterQueryEndpointPrefix, t.PuppetClassId), search, nil)
+	} else {
+		queryResponse, queryErr = client.QuerySmartClassParameter(ctx, t)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
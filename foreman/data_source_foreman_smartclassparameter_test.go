@@ -29,6 +29,19 @@ func ForemanSmartClassParameterToInstanceState(obj api.ForemanSmartClassParamete
 	attr := map[string]string{}
 	attr["parameter"] = obj.Parameter
 	attr["puppetclass_id"] = strconv.Itoa(obj.PuppetClassId)
+	attr["description"] = obj.Description
+	attr["override"] = strconv.FormatBool(obj.Override)
+	attr["parameter_type"] = obj.ParameterType
+	attr["default_value"] = obj.DefaultValue
+	attr["hidden_value"] = strconv.FormatBool(obj.HiddenValue)
+	attr["omit"] = strconv.FormatBool(obj.Omit)
+	attr["required"] = strconv.FormatBool(obj.Required)
+	attr["validator_type"] = obj.ValidatorType
+	attr["validator_rule"] = obj.ValidatorRule
+	attr["override_value_order"] = obj.OverrideValueOrder
+	attr["merge_overrides"] = strconv.FormatBool(obj.MergeOverrides)
+	attr["merge_default"] = strconv.FormatBool(obj.MergeDefault)
+	attr["avoid_duplicates"] = strconv.FormatBool(obj.AvoidDuplicates)
 	state.Attributes = attr
 	return &state
 }
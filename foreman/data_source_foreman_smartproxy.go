@@ -29,6 +29,8 @@ func dataSourceForemanSmartProxy() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanSmartProxyRead,
@@ -46,7 +48,13 @@ func dataSourceForemanSmartProxyRead(ctx context.Context, d *schema.ResourceData
 
 	log.Debugf("ForemanSmartProxy: [%+v]", s)
 
-	queryResponse, queryErr := client.QuerySmartProxy(ctx, s)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanSmartProxy](ctx, client, api.SmartProxyEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QuerySmartProxy(ctx, s)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -74,3 +82,13 @@ func dataSourceForemanSmartProxyRead(ctx context.Context, d *schema.ResourceData
 
 	return nil
 }
+
+// dataSourceForemanSmartProxies returns all smart proxies matching a search
+func dataSourceForemanSmartProxies() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanSmartProxy(),
+		"List of smart proxies matching a Foreman scoped search.",
+		staticEndpoint(api.SmartProxyEndpointPrefix),
+		setResourceDataFromForemanSmartProxy,
+	)
+}
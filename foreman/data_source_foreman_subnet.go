@@ -39,6 +39,11 @@ func dataSourceForemanSubnet() *schema.Resource {
 		),
 	}
 
+	// all lookup attributes are optional, so the search only conflicts
+	// with them instead of replacing their requirement
+	addDataSourceSearch(ds)
+	ds["search"].ConflictsWith = []string{"name", "network"}
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanSubnetRead,
@@ -56,7 +61,13 @@ func dataSourceForemanSubnetRead(ctx context.Context, d *schema.ResourceData, me
 
 	log.Debugf("ForemanSubnet: [%+v]", s)
 
-	queryResponse, queryErr := client.QuerySubnet(ctx, s)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanSubnet](ctx, client, api.SubnetEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QuerySubnet(ctx, s)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -84,3 +95,13 @@ func dataSourceForemanSubnetRead(ctx context.Context, d *schema.ResourceData, me
 
 	return nil
 }
+
+// dataSourceForemanSubnets returns all subnets matching a search
+func dataSourceForemanSubnets() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanSubnet(),
+		"List of subnets matching a Foreman scoped search.",
+		staticEndpoint(api.SubnetEndpointPrefix),
+		setResourceDataFromForemanSubnet,
+	)
+}
@@ -14,7 +14,7 @@ import (
 )
 
 func dataSourceForemanTemplateKind() *schema.Resource {
-	return &schema.Resource{
+	ds := &schema.Resource{
 
 		ReadContext: dataSourceForemanTemplateKindRead,
 
@@ -31,6 +31,10 @@ func dataSourceForemanTemplateKind() *schema.Resource {
 			},
 		},
 	}
+
+	addDataSourceSearch(ds.Schema, "name")
+
+	return ds
 }
 
 // -----------------------------------------------------------------------------
@@ -67,7 +71,13 @@ func dataSourceForemanTemplateKindRead(ctx context.Context, d *schema.ResourceDa
 
 	log.Debugf("ForemanTemplateKind: [%+v]", t)
 
-	queryResponse, queryErr := client.QueryTemplateKind(ctx, t)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanTemplateKind](ctx, client, api.TemplateKindEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryTemplateKind(ctx, t)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -95,3 +105,13 @@ func dataSourceForemanTemplateKindRead(ctx context.Context, d *schema.ResourceDa
 
 	return nil
 }
+
+// dataSourceForemanTemplateKinds returns all template kinds matching a search
+func dataSourceForemanTemplateKinds() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanTemplateKind(),
+		"List of template kinds matching a Foreman scoped search.",
+		staticEndpoint(api.TemplateKindEndpointPrefix),
+		setResourceDataFromForemanTemplateKind,
+	)
+}
@@ -18,6 +18,17 @@ func dataSourceForemanUser() *schema.Resource {
 	r := resourceForemanUser()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
 
+	// describe the combined lookup once instead of on every attribute
+	ds[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s User can be used to allow access to foreman. The user is "+
+				"looked up by the set lookup attributes, which must all match.",
+			autodoc.MetaSummary,
+		),
+	}
+
 	// define searchable attributes for the data source
 	ds["description"] = &schema.Schema{
 		Type:     schema.TypeString,
@@ -33,7 +44,7 @@ func dataSourceForemanUser() *schema.Resource {
 		Type:     schema.TypeString,
 		Optional: true,
 		Description: fmt.Sprintf(
-			"Firstname of the user."+
+			"Firstname of the user. "+
 				"%s \"Louis\"",
 			autodoc.MetaExample,
 		),
@@ -43,7 +54,7 @@ func dataSourceForemanUser() *schema.Resource {
 		Type:     schema.TypeString,
 		Optional: true,
 		Description: fmt.Sprintf(
-			"Lastname of the user."+
+			"Lastname of the user. "+
 				"%s \"Jansens\"",
 			autodoc.MetaExample,
 		),
@@ -53,7 +64,7 @@ func dataSourceForemanUser() *schema.Resource {
 		Type:     schema.TypeString,
 		Optional: true,
 		Description: fmt.Sprintf(
-			"loginname of the user."+
+			"loginname of the user. "+
 				"%s \"username\"",
 			autodoc.MetaExample,
 		),
@@ -63,11 +74,16 @@ func dataSourceForemanUser() *schema.Resource {
 		Type:     schema.TypeString,
 		Optional: true,
 		Description: fmt.Sprintf(
-			"email of the user."+
+			"email of the user. "+
 				"%s \"test@example.com\"",
 			autodoc.MetaExample,
 		),
 	}
+	// all lookup attributes are optional and combined with AND, so the
+	// search only conflicts with them instead of replacing their requirement
+	addDataSourceSearch(ds)
+	ds["search"].ConflictsWith = []string{"description", "firstname", "lastname", "login", "mail"}
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanUserRead,
@@ -85,7 +101,13 @@ func dataSourceForemanUserRead(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Debugf("ForemanUser: [%+v]", s)
 
-	queryResponse, queryErr := client.QueryUser(ctx, s)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanUser](ctx, client, api.UserEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryUser(ctx, s)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -113,3 +135,13 @@ func dataSourceForemanUserRead(ctx context.Context, d *schema.ResourceData, meta
 
 	return nil
 }
+
+// dataSourceForemanUsers returns all users matching a search
+func dataSourceForemanUsers() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanUser(),
+		"List of users matching a Foreman scoped search.",
+		staticEndpoint(api.UserEndpointPrefix),
+		setResourceDataFromForemanUser,
+	)
+}
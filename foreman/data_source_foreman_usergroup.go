@@ -30,6 +30,8 @@ func dataSourceForemanUsergroup() *schema.Resource {
 		),
 	}
 
+	addDataSourceSearch(ds, "name")
+
 	return &schema.Resource{
 
 		ReadContext: dataSourceForemanUsergroupRead,
@@ -47,7 +49,13 @@ func dataSourceForemanUsergroupRead(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("ForemanUsergroup: [%+v]", u)
 
-	queryResponse, queryErr := client.QueryUsergroup(ctx, u)
+	var queryResponse api.QueryResponse
+	var queryErr error
+	if search, ok := dataSourceSearch(d); ok {
+		queryResponse, queryErr = api.SearchAll[api.ForemanUsergroup](ctx, client, api.UsergroupEndpointPrefix, search, nil)
+	} else {
+		queryResponse, queryErr = client.QueryUsergroup(ctx, u)
+	}
 	if queryErr != nil {
 		return diag.FromErr(queryErr)
 	}
@@ -75,3 +83,13 @@ func dataSourceForemanUsergroupRead(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+// dataSourceForemanUsergroups returns all usergroups matching a search
+func dataSourceForemanUsergroups() *schema.Resource {
+	return dataSourceForemanList(
+		dataSourceForemanUsergroup(),
+		"List of usergroups matching a Foreman scoped search.",
+		staticEndpoint(api.UsergroupEndpointPrefix),
+		setResourceDataFromForemanUsergroup,
+	)
+}
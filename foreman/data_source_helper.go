@@ -0,0 +1,245 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"hash/crc32"
+	"net/url"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// Generic Search
+// -----------------------------------------------------------------------------
+
+// dataSourceSearchSchema returns the schema of the generic "search" argument
+// shared by all data sources
+func dataSourceSearchSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Description: "Raw Foreman scoped search expression used to look up the " +
+			"object instead of the lookup attributes, ie: " +
+			"`name ~ web and location = Berlin`. The expression is passed to " +
+			"Foreman as-is and has to match exactly one object.",
+	}
+}
+
+// addDataSourceSearch adds the generic "search" argument to a data source
+// schema.  The supplied lookup attributes become optional, exactly one of
+// them or the search has to be set.
+func addDataSourceSearch(ds map[string]*schema.Schema, lookupAttrs ...string) {
+	ds["search"] = dataSourceSearchSchema()
+
+	if len(lookupAttrs) == 0 {
+		return
+	}
+
+	exactlyOneOf := append([]string{"search"}, lookupAttrs...)
+	ds["search"].ExactlyOneOf = exactlyOneOf
+	for _, attr := range lookupAttrs {
+		ds[attr].Required = false
+		ds[attr].Optional = true
+		ds[attr].Computed = true
+		ds[attr].ExactlyOneOf = exactlyOneOf
+	}
+}
+
+// dataSourceSearch returns the raw search of the data source wrapped in a
+// SearchQuery and whether the search was set
+func dataSourceSearch(d *schema.ResourceData) (*api.SearchQuery, bool) {
+	attr, ok := d.GetOk("search")
+	if !ok {
+		return nil, false
+	}
+	return api.NewSearchQuery().Raw(attr.(string)), true
+}
+
+// -----------------------------------------------------------------------------
+// Plural Data Sources
+// -----------------------------------------------------------------------------
+
+// dataSourceForemanList builds a data source which returns all objects
+// matching a search as a list, ie: for use with for_each.  The attributes of
+// each object are the same as the ones of the singular data source and are
+// set with the setResourceDataFrom* function of the object type.
+//
+// endpoint returns the index endpoint and additional URL parameters for the
+// paginated search.
+func dataSourceForemanList[T any](
+	singular *schema.Resource,
+	summary string,
+	endpoint func(client *api.Client) (string, url.Values),
+	setResourceData func(*schema.ResourceData, *T),
+) *schema.Resource {
+	return dataSourceForemanListWithError(singular, summary, endpoint, func(d *schema.ResourceData, obj *T) error {
+		setResourceData(d, obj)
+		return nil
+	})
+}
+
+// dataSourceForemanListWithError is dataSourceForemanList for
+// setResourceDataFrom* functions returning an error.  The read fails with the
+// first error.
+func dataSourceForemanListWithError[T any](
+	singular *schema.Resource,
+	summary string,
+	endpoint func(client *api.Client) (string, url.Values),
+	setResourceData func(*schema.ResourceData, *T) error,
+) *schema.Resource {
+
+	// copy attributes from the singular data source, all of them computed
+	elem := helper.DataSourceSchemaFromResourceSchema(singular.Schema)
+	delete(elem, autodoc.MetaAttribute)
+	delete(elem, "search")
+	elem["id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Unique identifier of the object.",
+	}
+	elemResource := &schema.Resource{
+		Schema: elem,
+	}
+
+	return &schema.Resource{
+
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			log.Tracef("data_source_helper.go#dataSourceForemanList#Read")
+
+			client := meta.(*api.Client)
+			search := api.NewSearchQuery().Raw(d.Get("search").(string))
+			reqEndpoint, params := endpoint(client)
+
+			queryResponse, queryErr := api.SearchAll[T](ctx, client, reqEndpoint, search, params)
+			if queryErr != nil {
+				return diag.FromErr(queryErr)
+			}
+
+			log.Debugf("queryResponse: [%+v]", queryResponse)
+
+			results := make([]interface{}, 0, len(queryResponse.Results))
+			ids := make([]int, 0, len(queryResponse.Results))
+			for _, result := range queryResponse.Results {
+				obj, ok := result.(T)
+				if !ok {
+					return diag.Errorf(
+						"Data source results contain unexpected type. Expected "+
+							"[%T], got [%T]",
+						obj,
+						result,
+					)
+				}
+
+				// Reuse the conversion of the singular object type by setting
+				// the object on a standalone ResourceData of the element schema
+				objData := elemResource.Data(nil)
+				if setErr := setResourceData(objData, &obj); setErr != nil {
+					return diag.FromErr(setErr)
+				}
+
+				id, _ := strconv.Atoi(objData.Id())
+				objMap := map[string]interface{}{
+					"id": id,
+				}
+				for key := range elem {
+					if key == "id" {
+						continue
+					}
+					objMap[key] = flattenResourceDataValue(objData.Get(key))
+				}
+				results = append(results, objMap)
+				ids = append(ids, id)
+			}
+
+			d.SetId(strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(reqEndpoint+"?"+search.String()))), 10))
+			d.Set("ids", ids)
+			d.Set("results", results)
+
+			return nil
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: fmt.Sprintf("%s %s", autodoc.MetaSummary, summary),
+			},
+
+			"search": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Raw Foreman scoped search expression. If omitted, all "+
+						"objects are returned. "+
+						"%s \"name ~ web\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of all objects matching the search.",
+			},
+
+			"results": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        elemResource,
+				Description: "All objects matching the search.",
+			},
+		},
+	}
+}
+
+// staticEndpoint returns an endpoint function for dataSourceForemanList
+// which always uses the supplied index endpoint without further parameters
+func staticEndpoint(reqEndpoint string) func(*api.Client) (string, url.Values) {
+	return func(*api.Client) (string, url.Values) {
+		return reqEndpoint, nil
+	}
+}
+
+// organizationEndpoint returns an endpoint function for dataSourceForemanList
+// which scopes the index endpoint to the organization of the provider
+func organizationEndpoint(reqEndpoint string) func(*api.Client) (string, url.Values) {
+	return func(client *api.Client) (string, url.Values) {
+		return reqEndpoint, client.OrganizationParams()
+	}
+}
+
+// flattenResourceDataValue converts the value of a ResourceData attribute to
+// a plain value which can be set as part of a list element.  Sets, including
+// nested ones, are converted to lists.
+func flattenResourceDataValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *schema.Set:
+		return flattenResourceDataValue(v.List())
+	case []interface{}:
+		flattened := make([]interface{}, len(v))
+		for idx, item := range v {
+			flattened[idx] = flattenResourceDataValue(item)
+		}
+		return flattened
+	case map[string]interface{}:
+		flattened := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			flattened[key] = flattenResourceDataValue(item)
+		}
+		return flattened
+	default:
+		return value
+	}
+}
@@ -0,0 +1,164 @@
+package foreman
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------------------
+// Generic Search
+// ----------------------------------------------------------------------------
+
+// Ensures a singular data source sends the raw search instead of the
+// lookup attribute when the search is set
+func TestDataSourceSearch_RawSearch(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	var search string
+	mux.HandleFunc(DomainsURI, func(w http.ResponseWriter, r *http.Request) {
+		search = r.URL.Query().Get("search")
+		bytes, _ := os.ReadFile(DomainsTestDataPath + "/query_response_single.json")
+		w.Write(bytes)
+	})
+
+	ds := dataSourceForemanDomain()
+	d := ds.TestResourceData()
+	d.Set("search", "name ~ dev and fullname = \"\"")
+
+	diags := ds.ReadContext(context.TODO(), d, client)
+	if diags.HasError() {
+		t.Fatalf("Data source read returned error [%+v]", diags)
+	}
+
+	expected := `(name ~ dev and fullname = "")`
+	if search != expected {
+		t.Fatalf(
+			"Data source did not send the raw search. Expected [%s], got [%s]",
+			expected,
+			search,
+		)
+	}
+	if d.Get("name").(string) == "" {
+		t.Fatalf("Data source did not set the name of the found domain")
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Plural Data Sources
+// ----------------------------------------------------------------------------
+
+// Ensures a plural data source returns all results of the search with the
+// attributes of the singular data source
+func TestDataSourceForemanList_Results(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	// the fixture claims more matches than it contains, the second page is
+	// empty and ends the pagination
+	var search string
+	mux.HandleFunc(DomainsURI, func(w http.ResponseWriter, r *http.Request) {
+		search = r.URL.Query().Get("search")
+		responseFile := TestDataPath + "/query_response_zero.json"
+		if r.URL.Query().Get("page") == "1" {
+			responseFile = DomainsTestDataPath + "/query_response_multi.json"
+		}
+		bytes, _ := os.ReadFile(responseFile)
+		w.Write(bytes)
+	})
+
+	ds := dataSourceForemanDomains()
+	d := ds.TestResourceData()
+	d.Set("search", "name ~ dc1")
+
+	diags := ds.ReadContext(context.TODO(), d, client)
+	if diags.HasError() {
+		t.Fatalf("Data source read returned error [%+v]", diags)
+	}
+
+	if search != "(name ~ dc1)" {
+		t.Fatalf("Data source did not send the search. Expected [(name ~ dc1)], got [%s]", search)
+	}
+	if d.Id() == "" {
+		t.Fatalf("Data source did not set an ID")
+	}
+
+	results := d.Get("results").([]interface{})
+	ids := d.Get("ids").([]interface{})
+	if len(results) != 4 || len(ids) != 4 {
+		t.Fatalf(
+			"Data source did not return all results. Expected [4], got [%d] results and [%d] ids",
+			len(results),
+			len(ids),
+		)
+	}
+
+	first := results[0].(map[string]interface{})
+	if first["id"] != 35 || first["name"] != "dev.dc1.company.com" || ids[0] != 35 {
+		t.Fatalf("Data source did not set the attributes of the first result, got [%+v]", first)
+	}
+}
+
+// Ensures the results of a plural data source are empty if nothing matches
+func TestDataSourceForemanList_NoResults(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(SubnetsURI, func(w http.ResponseWriter, r *http.Request) {
+		bytes, _ := os.ReadFile(TestDataPath + "/query_response_zero.json")
+		w.Write(bytes)
+	})
+
+	ds := dataSourceForemanSubnets()
+	d := ds.TestResourceData()
+
+	diags := ds.ReadContext(context.TODO(), d, client)
+	if diags.HasError() {
+		t.Fatalf("Data source read returned error [%+v]", diags)
+	}
+
+	if results := d.Get("results").([]interface{}); len(results) != 0 {
+		t.Fatalf("Data source returned [%d] results, expected none", len(results))
+	}
+}
+
+// Ensures a plural data source fails if the attributes of a result can not
+// be set instead of returning partial results
+func TestDataSourceForemanList_SetError(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(DomainsURI, func(w http.ResponseWriter, r *http.Request) {
+		responseFile := TestDataPath + "/query_response_zero.json"
+		if r.URL.Query().Get("page") == "1" {
+			responseFile = DomainsTestDataPath + "/query_response_multi.json"
+		}
+		bytes, _ := os.ReadFile(responseFile)
+		w.Write(bytes)
+	})
+
+	ds := dataSourceForemanListWithError(
+		dataSourceForemanDomain(),
+		"List of domains.",
+		staticEndpoint(api.DomainEndpointPrefix),
+		func(d *schema.ResourceData, fd *api.ForemanDomain) error {
+			return errors.New("invalid domain")
+		},
+	)
+	d := ds.TestResourceData()
+
+	diags := ds.ReadContext(context.TODO(), d, client)
+	if !diags.HasError() || diags[0].Summary != "invalid domain" {
+		t.Fatalf("Data source read did not return the error, got [%+v]", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("Data source read set the ID [%s] despite the error", d.Id())
+	}
+}
@@ -0,0 +1,425 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// Fixture Matrix
+// -----------------------------------------------------------------------------
+
+// FixtureMatrixFiles are the response fixtures of an object, which are run
+// through the round-trip in every version directory containing them
+var FixtureMatrixFiles = []string{
+	"read_response.json",
+	"create_response.json",
+	"update_response.json",
+}
+
+// fixtureRoundTripFunc decodes a response fixture and runs it through the
+// resource's functions.  It returns an error describing the first difference.
+type fixtureRoundTripFunc func(data []byte) error
+
+// fixtureMatrixEntry registers the round-trip of a resource.  Dirs lists the
+// fixture directories of the resource below the version directories, ie:
+// "domains" for "testdata/1.11/domains".
+type fixtureMatrixEntry struct {
+	Resource  string
+	Dirs      []string
+	RoundTrip fixtureRoundTripFunc
+}
+
+// fixtureMatrix lists the resources tested against the fixtures of every
+// Foreman version.  New version directories below testdata are picked up
+// automatically.
+var fixtureMatrix = []fixtureMatrixEntry{
+	{"foreman_architecture", []string{"architectures"}, fixtureRoundTrip(resourceForemanArchitecture, setResourceDataFromForemanArchitecture, buildForemanArchitecture)},
+	{"foreman_auth_source_ldap", []string{"auth_source_ldaps"}, fixtureRoundTrip(resourceForemanAuthSourceLdap, setResourceDataFromForemanAuthSourceLdap, buildForemanAuthSourceLdap)},
+	{"foreman_computeresource", []string{"computeresources", "compute_resources"}, fixtureRoundTrip(resourceForemanComputeResource, setResourceDataFromForemanComputeResource, buildForemanComputeResource)},
+	{"foreman_config_group", []string{"config_groups"}, fixtureRoundTrip(resourceForemanConfigGroup, setResourceDataFromForemanConfigGroup, buildForemanConfigGroup)},
+	{"foreman_discovery_rule", []string{"discovery_rules"}, fixtureRoundTrip(resourceForemanDiscoveryRule, setResourceDataFromForemanDiscoveryRuleResponse, buildForemanDiscoveryRuleResponse)},
+	{"foreman_domain", []string{"domains"}, fixtureRoundTrip(resourceForemanDomain, setResourceDataFromForemanDomain, buildForemanDomain)},
+	{"foreman_environment", []string{"environments"}, fixtureRoundTrip(resourceForemanEnvironment, setResourceDataFromForemanEnvironment, buildForemanEnvironment)},
+	{"foreman_filter", []string{"filters"}, fixtureRoundTrip(resourceForemanFilter, setResourceDataFromForemanFilter, buildForemanFilter)},
+	{"foreman_host", []string{"hosts"}, fixtureRoundTripWithError(resourceForemanHost, setResourceDataFromForemanHost, buildForemanHost)},
+	{"foreman_host_interface", []string{"interfaces"}, fixtureRoundTrip(resourceForemanHostInterface, setResourceDataFromForemanHostInterface, buildForemanHostInterface)},
+	{"foreman_hostgroup", []string{"hostgroups"}, fixtureRoundTrip(resourceForemanHostgroup, setResourceDataFromForemanHostgroup, buildForemanHostgroup)},
+	{"foreman_httpproxy", []string{"http_proxies"}, fixtureRoundTrip(resourceForemanHTTPProxy, setResourceDataFromForemanHTTPProxy, buildForemanHTTPProxy)},
+	{"foreman_image", []string{"images", "image"}, fixtureRoundTrip(resourceForemanImage, setResourceDataFromForemanImage, buildForemanImage)},
+	{"foreman_jobtemplate", []string{"job_templates", "job_template"}, fixtureRoundTrip(resourceForemanJobTemplate, setResourceDataFromForemanJobTemplate, buildForemanJobTemplate)},
+	{"foreman_location", []string{"locations"}, fixtureRoundTrip(resourceForemanLocation, setResourceDataFromForemanLocation, buildForemanLocation)},
+	{"foreman_media", []string{"media"}, fixtureRoundTrip(resourceForemanMedia, setResourceDataFromForemanMedia, buildForemanMedia)},
+	{"foreman_model", []string{"models"}, fixtureRoundTrip(resourceForemanModel, setResourceDataFromForemanModel, buildForemanModel)},
+	{"foreman_operatingsystem", []string{"operatingsystems"}, fixtureRoundTrip(resourceForemanOperatingSystem, setResourceDataFromForemanOperatingSystem, buildForemanOperatingSystem)},
+	{"foreman_organization", []string{"organizations"}, fixtureRoundTrip(resourceForemanOrganization, setResourceDataFromForemanOrganization, buildForemanOrganization)},
+	{"foreman_override_value", []string{"override_values"}, fixtureRoundTrip(resourceForemanOverrideValue, setResourceDataFromForemanOverrideValue, buildForemanOverrideValue)},
+	{"foreman_partitiontable", []string{"ptables"}, fixtureRoundTrip(resourceForemanPartitionTable, setResourceDataFromForemanPartitionTable, buildForemanPartitionTable)},
+	{"foreman_provisioningtemplate", []string{"provisioning_templates"}, fixtureRoundTrip(resourceForemanProvisioningTemplate, setResourceDataFromForemanProvisioningTemplate, buildForemanProvisioningTemplate)},
+	{"foreman_realm", []string{"realms"}, fixtureRoundTrip(resourceForemanRealm, setResourceDataFromForemanRealm, buildForemanRealm)},
+	{"foreman_role", []string{"roles"}, fixtureRoundTrip(resourceForemanRole, setResourceDataFromForemanRole, buildForemanRole)},
+	{"foreman_smartclassparameter", []string{"smart_class_parameters"}, fixtureRoundTrip(resourceForemanSmartClassParameter, setResourceDataFromForemanSmartClassParameter, buildForemanSmartClassParameter)},
+	{"foreman_smartproxy", []string{"smart_proxies"}, fixtureRoundTrip(resourceForemanSmartProxy, setResourceDataFromForemanSmartProxy, buildForemanSmartProxy)},
+	{"foreman_subnet", []string{"subnets"}, fixtureRoundTrip(resourceForemanSubnet, setResourceDataFromForemanSubnet, buildForemanSubnet)},
+	{"foreman_usergroup", []string{"usergroups"}, fixtureRoundTrip(resourceForemanUsergroup, setResourceDataFromForemanUsergroup, buildForemanUsergroup)},
+	{"foreman_webhook", []string{"webhooks"}, fixtureRoundTrip(resourceForemanWebhook, setResourceDataFromForemanWebhookResponse, buildForemanWebhookResponse)},
+	{"foreman_webhooktemplate", []string{"webhook_templates"}, fixtureRoundTrip(resourceForemanWebhookTemplate, setResourceDataFromForemanWebhookTemplateResponse, buildForemanWebhookTemplateResponse)},
+}
+
+// fixtureRoundTrip returns the round-trip of a resource: the fixture is
+// decoded into T, set to a ResourceData and built back into T.  The built T
+// is marshalled and the request must match the fields of the fixture, then
+// it is set to a second ResourceData which must have the same state.
+func fixtureRoundTrip[T any](resource func() *schema.Resource, set func(*schema.ResourceData, *T), build func(*schema.ResourceData) *T) fixtureRoundTripFunc {
+	return fixtureRoundTripWithError(resource, func(d *schema.ResourceData, obj *T) error {
+		set(d, obj)
+		return nil
+	}, build)
+}
+
+// fixtureRoundTripWithError is fixtureRoundTrip for setResourceDataFrom*
+// functions returning an error
+func fixtureRoundTripWithError[T any](resource func() *schema.Resource, set func(*schema.ResourceData, *T) error, build func(*schema.ResourceData) *T) fixtureRoundTripFunc {
+	return func(data []byte) error {
+		var decoded T
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return fmt.Errorf("decoding the fixture failed: %w", err)
+		}
+
+		r := resource()
+		d1 := r.Data(nil)
+		if err := set(d1, &decoded); err != nil {
+			return fmt.Errorf("setting the decoded object failed: %w", err)
+		}
+		if d1.Id() == "" || d1.Id() == "0" {
+			return fmt.Errorf("the decoded object has no ID")
+		}
+
+		built := build(d1)
+		request, err := json.Marshal(built)
+		if err != nil {
+			return fmt.Errorf("marshalling the built object failed: %w", err)
+		}
+		if err := compareFixtureRequest(data, request); err != nil {
+			return err
+		}
+
+		d2 := r.Data(nil)
+		if err := set(d2, built); err != nil {
+			return fmt.Errorf("setting the built object failed: %w", err)
+		}
+
+		return compareFixtureState(d1, d2)
+	}
+}
+
+// compareFixtureState returns an error listing the attributes which differ
+// between the states of two ResourceData
+func compareFixtureState(d1 *schema.ResourceData, d2 *schema.ResourceData) error {
+	a1 := d1.State().Attributes
+	a2 := d2.State().Attributes
+	if reflect.DeepEqual(a1, a2) {
+		return nil
+	}
+
+	diffs := []string{}
+	for key, value := range a1 {
+		if a2[key] != value {
+			diffs = append(diffs, fmt.Sprintf("%s: [%s] != [%s]", key, value, a2[key]))
+		}
+	}
+	for key, value := range a2 {
+		if _, ok := a1[key]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: missing != [%s]", key, value))
+		}
+	}
+	sort.Strings(diffs)
+	return fmt.Errorf("the state differs after the round-trip:\n  %s", strings.Join(diffs, "\n  "))
+}
+
+// compareFixtureRequest returns an error listing the attributes of the
+// marshalled request which differ from the fixture.  Attributes the fixture
+// returns as nested objects, ie: "locations", are compared to the ID lists
+// of the request, ie: "location_ids".  Attributes the fixture does not
+// return, ie: passwords, and nested "*_attributes" are not compared.
+func compareFixtureRequest(fixture []byte, request []byte) error {
+	var fixtureMap map[string]interface{}
+	if err := json.Unmarshal(fixture, &fixtureMap); err != nil {
+		return fmt.Errorf("decoding the fixture failed: %w", err)
+	}
+	var requestMap map[string]interface{}
+	if err := json.Unmarshal(request, &requestMap); err != nil {
+		return fmt.Errorf("decoding the marshalled request failed: %w", err)
+	}
+
+	diffs := []string{}
+	for key, value := range requestMap {
+		if strings.HasSuffix(key, "_attributes") || fixtureServerAttributes[key] {
+			continue
+		}
+		expected, ok := fixtureMap[key]
+		if !ok && strings.HasSuffix(key, "_ids") {
+			var nested interface{}
+			if nested, ok = fixtureMap[pluralize(strings.TrimSuffix(key, "_ids"))]; ok {
+				expected = nestedObjectIds(nested)
+			}
+		}
+		if !ok {
+			continue
+		}
+		// nested objects are only returned by Foreman, they are set
+		// through the "*_id" and "*_attributes" parameters
+		if _, ok := expected.(map[string]interface{}); ok {
+			continue
+		}
+		if _, ok := expected.([]interface{}); ok && value == nil {
+			continue
+		}
+		if !reflect.DeepEqual(normalizeFixtureValue(value), normalizeFixtureValue(expected)) {
+			diffs = append(diffs, fmt.Sprintf("%s: [%v] != [%v]", key, value, expected))
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+	sort.Strings(diffs)
+	return fmt.Errorf("the marshalled request differs from the fixture:\n  %s", strings.Join(diffs, "\n  "))
+}
+
+// fixtureServerAttributes are maintained by Foreman or differ between
+// requests and responses by design and are not compared
+var fixtureServerAttributes = map[string]bool{
+	"created_at":   true,
+	"updated_at":   true,
+	"password_set": true,
+	// an unset VLAN ID is sent as 0 and returned as null
+	"vlanid": true,
+}
+
+// normalizeFixtureValue converts a decoded JSON value for the comparison of
+// requests and responses: numbers sent as strings are converted to numbers,
+// values sent as JSON strings are decoded, lists are sorted, nested objects
+// are reduced to their IDs and null, empty strings and empty lists are equal
+func normalizeFixtureValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+		// structured values, ie: of override values, are sent as JSON
+		var decoded interface{}
+		if strings.HasPrefix(v, "{") || strings.HasPrefix(v, "[") {
+			if err := json.Unmarshal([]byte(v), &decoded); err == nil {
+				return normalizeFixtureValue(decoded)
+			}
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return nil
+		}
+		normalized := make([]interface{}, len(v))
+		for idx, item := range v {
+			if obj, ok := item.(map[string]interface{}); ok && obj["id"] != nil {
+				item = obj["id"]
+			}
+			normalized[idx] = normalizeFixtureValue(item)
+		}
+		sort.Slice(normalized, func(i, j int) bool {
+			return fmt.Sprint(normalized[i]) < fmt.Sprint(normalized[j])
+		})
+		return normalized
+	case map[string]interface{}:
+		normalized := map[string]interface{}{}
+		for key, item := range v {
+			normalized[key] = normalizeFixtureValue(item)
+		}
+		return normalized
+	}
+	return value
+}
+
+// nestedObjectIds returns the IDs of the nested objects of a response, ie:
+// of the "locations" of an organization
+func nestedObjectIds(nested interface{}) []interface{} {
+	ids := []interface{}{}
+	objs, _ := nested.([]interface{})
+	for _, obj := range objs {
+		if m, ok := obj.(map[string]interface{}); ok {
+			ids = append(ids, m["id"])
+		}
+	}
+	return ids
+}
+
+// pluralize returns the plural of the singular name of an association, ie:
+// "smart_proxies" for "smart_proxy"
+func pluralize(name string) string {
+	switch {
+	case name == "medium":
+		return "media"
+	case strings.HasSuffix(name, "y"):
+		return strings.TrimSuffix(name, "y") + "ies"
+	case strings.HasSuffix(name, "s"):
+		return name + "es"
+	default:
+		return name + "s"
+	}
+}
+
+// fixtureVersions returns the Foreman version directories below testdata,
+// ordered by version
+func fixtureVersions(t *testing.T) []string {
+	entries, err := os.ReadDir("testdata")
+	if err != nil {
+		t.Fatalf("Reading the testdata directory failed: [%s]", err)
+	}
+
+	versions := []string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// compareVersions compares two dotted version numbers numerically
+func compareVersions(v1 string, v2 string) int {
+	p1 := strings.Split(v1, ".")
+	p2 := strings.Split(v2, ".")
+	for idx := 0; idx < len(p1) || idx < len(p2); idx++ {
+		var n1, n2 int
+		if idx < len(p1) {
+			n1, _ = strconv.Atoi(p1[idx])
+		}
+		if idx < len(p2) {
+			n2, _ = strconv.Atoi(p2[idx])
+		}
+		if n1 != n2 {
+			return n1 - n2
+		}
+	}
+	return 0
+}
+
+// Runs the round-trip of every registered resource against the fixtures of
+// every Foreman version and reports the versions each resource has been
+// proven against.  Run with -v to see the report.
+func TestFixtureMatrix(t *testing.T) {
+	versions := fixtureVersions(t)
+	proven := map[string][]string{}
+
+	for _, entry := range fixtureMatrix {
+		for _, version := range versions {
+			for _, dir := range entry.Dirs {
+				for _, file := range FixtureMatrixFiles {
+					path := filepath.Join("testdata", version, dir, file)
+					data, readErr := os.ReadFile(path)
+					if readErr != nil {
+						continue
+					}
+
+					passed := t.Run(entry.Resource+"/"+version+"/"+file, func(t *testing.T) {
+						if err := entry.RoundTrip(data); err != nil {
+							t.Fatalf("Round-trip of [%s] failed: %s", path, err)
+						}
+					})
+					if passed && !containsString(proven[entry.Resource], version) {
+						proven[entry.Resource] = append(proven[entry.Resource], version)
+					}
+				}
+			}
+		}
+	}
+
+	report := []string{fmt.Sprintf("%-30s %s", "RESOURCE", strings.Join(versions, " "))}
+	for _, entry := range fixtureMatrix {
+		columns := make([]string, len(versions))
+		for idx, version := range versions {
+			mark := "-"
+			if containsString(proven[entry.Resource], version) {
+				mark = "x"
+			}
+			columns[idx] = fmt.Sprintf("%-*s", len(version), mark)
+		}
+		report = append(report, fmt.Sprintf("%-30s %s", entry.Resource, strings.Join(columns, " ")))
+	}
+	t.Logf("Foreman versions proven per resource:\n%s", strings.Join(report, "\n"))
+}
+
+// Ensures version directories are ordered numerically
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		V1       string
+		V2       string
+		Expected int
+	}{
+		{V1: "1.11", V2: "1.20", Expected: -1},
+		{V1: "3.1.2", V2: "3.11", Expected: -1},
+		{V1: "3.6", V2: "3.1.2", Expected: 1},
+		{V1: "3.6", V2: "3.6.0", Expected: 0},
+	}
+
+	for _, testCase := range testCases {
+		output := compareVersions(testCase.V1, testCase.V2)
+		if (output < 0 && testCase.Expected >= 0) || (output > 0 && testCase.Expected <= 0) ||
+			(output == 0 && testCase.Expected != 0) {
+			t.Fatalf(
+				"compareVersions did not return correct value. Expected [%d], got [%d] for [%s] and [%s]",
+				testCase.Expected,
+				output,
+				testCase.V1,
+				testCase.V2,
+			)
+		}
+	}
+}
+
+// Ensures requests are compared to the fields and nested objects of the
+// fixture
+func TestCompareFixtureRequest(t *testing.T) {
+	fixture := `{"id": 1, "name": "org", "description": null, "locations": [{"id": 2, "name": "DC1"}, {"id": 3, "name": "DC2"}], "created_at": "2013-05-17 21:38:03 UTC"}`
+	testCases := []struct {
+		Request string
+		Valid   bool
+	}{
+		{`{"name": "org", "description": "", "location_ids": [3, 2], "created_at": ""}`, true},
+		{`{"name": "org", "location_ids": ["2", "3"], "password": "secret"}`, true},
+		{`{"name": "org", "location_ids": [2]}`, false},
+		{`{"name": "org", "location_ids": []}`, false},
+		{`{"name": "other", "location_ids": [2, 3]}`, false},
+	}
+
+	for _, testCase := range testCases {
+		err := compareFixtureRequest([]byte(fixture), []byte(testCase.Request))
+		if (err == nil) != testCase.Valid {
+			t.Fatalf("compareFixtureRequest returned [%v] for the request [%s]", err, testCase.Request)
+		}
+	}
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
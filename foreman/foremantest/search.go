@@ -0,0 +1,362 @@
+package foremantest
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Scoped Search
+// ----------------------------------------------------------------------------
+
+// searchExpr is a parsed scoped search expression which can be matched
+// against a stored object
+type searchExpr interface {
+	match(obj map[string]interface{}) bool
+}
+
+type searchAnd struct{ left, right searchExpr }
+type searchOr struct{ left, right searchExpr }
+type searchNot struct{ expr searchExpr }
+
+// searchTerm compares a field of the object with one or more values.  A term
+// without a field (free text) matches the name of the object.
+type searchTerm struct {
+	field  string
+	op     string
+	values []string
+}
+
+func (e searchAnd) match(obj map[string]interface{}) bool {
+	return e.left.match(obj) && e.right.match(obj)
+}
+
+func (e searchOr) match(obj map[string]interface{}) bool {
+	return e.left.match(obj) || e.right.match(obj)
+}
+
+func (e searchNot) match(obj map[string]interface{}) bool {
+	return !e.expr.match(obj)
+}
+
+func (e searchTerm) match(obj map[string]interface{}) bool {
+	field := e.field
+	op := e.op
+	if field == "" {
+		field, op = "name", "~"
+	}
+
+	raw, ok := obj[field]
+	if !ok || raw == nil {
+		// scoped search treats comparisons with missing values as false,
+		// except for the negated operators
+		return op == "!=" || op == "!~" || op == "!^"
+	}
+	value := searchValueString(raw)
+
+	switch op {
+	case "=":
+		return value == e.values[0]
+	case "!=":
+		return value != e.values[0]
+	case "~":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(e.values[0]))
+	case "!~":
+		return !strings.Contains(strings.ToLower(value), strings.ToLower(e.values[0]))
+	case "^", "!^":
+		in := false
+		for _, v := range e.values {
+			if value == v {
+				in = true
+			}
+		}
+		return in == (op == "^")
+	case ">", "<", ">=", "<=":
+		return compareSearchValues(value, e.values[0], op)
+	}
+	return false
+}
+
+// searchValueString converts a decoded JSON value to the string used for
+// comparisons
+func searchValueString(raw interface{}) string {
+	switch v := raw.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// compareSearchValues compares numerically if both values are numbers and
+// lexically otherwise
+func compareSearchValues(value string, other string, op string) bool {
+	cmp := strings.Compare(value, other)
+	if a, errA := strconv.ParseFloat(value, 64); errA == nil {
+		if b, errB := strconv.ParseFloat(other, 64); errB == nil {
+			switch {
+			case a < b:
+				cmp = -1
+			case a > b:
+				cmp = 1
+			default:
+				cmp = 0
+			}
+		}
+	}
+
+	switch op {
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	case ">=":
+		return cmp >= 0
+	default:
+		return cmp <= 0
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Parser
+// ----------------------------------------------------------------------------
+
+// searchOperators lists the comparison operators, longest first so the
+// tokenizer prefers "!=" over "!"
+var searchOperators = []string{"!=", "!~", "!^", ">=", "<=", "=", "~", "^", ">", "<"}
+
+// parseSearch parses a scoped search expression.  The supported syntax is a
+// subset of the Foreman scoped search language: comparisons, "in" lists,
+// free text, "and", "or", "not" and parentheses.  An empty expression
+// matches all objects.
+func parseSearch(search string) (searchExpr, error) {
+	tokens, err := tokenizeSearch(search)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := &searchParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in search", p.tokens[p.pos].text)
+	}
+	return expr, nil
+}
+
+// searchToken is a single token of a scoped search expression.  Quoted
+// values are never treated as keywords or operators.
+type searchToken struct {
+	text   string
+	quoted bool
+}
+
+func tokenizeSearch(search string) ([]searchToken, error) {
+	tokens := []searchToken{}
+	runes := []rune(search)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')' || c == ',':
+			tokens = append(tokens, searchToken{text: string(c)})
+			i++
+		case c == '"' || c == '\'':
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != c; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated quoted value in search")
+			}
+			i++
+			tokens = append(tokens, searchToken{text: b.String(), quoted: true})
+		default:
+			if op := searchOperatorAt(runes[i:]); op != "" {
+				tokens = append(tokens, searchToken{text: op})
+				i += len(op)
+				continue
+			}
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n(),\"'", runes[i]) &&
+				searchOperatorAt(runes[i:]) == "" {
+				i++
+			}
+			tokens = append(tokens, searchToken{text: string(runes[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+func searchOperatorAt(runes []rune) string {
+	for _, op := range searchOperators {
+		if strings.HasPrefix(string(runes), op) {
+			return op
+		}
+	}
+	return ""
+}
+
+type searchParser struct {
+	tokens []searchToken
+	pos    int
+}
+
+func (p *searchParser) peek() (searchToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return searchToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *searchParser) keyword(word string) bool {
+	tok, ok := p.peek()
+	if ok && !tok.quoted && strings.EqualFold(tok.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *searchParser) parseOr() (searchExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") || p.keyword("|") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = searchOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *searchParser) parseAnd() (searchExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if !p.keyword("and") && !p.keyword("&") {
+			// adjacent terms are implicitly joined with "and"
+			tok, ok := p.peek()
+			if !ok || tok.text == ")" || (!tok.quoted && strings.EqualFold(tok.text, "or")) {
+				return left, nil
+			}
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = searchAnd{left, right}
+	}
+}
+
+func (p *searchParser) parseUnary() (searchExpr, error) {
+	if p.keyword("not") || p.keyword("!") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return searchNot{expr}, nil
+	}
+
+	tok, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of search")
+	}
+	if tok.text == "(" && !tok.quoted {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.peek(); !ok || closing.text != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis in search")
+		}
+		p.pos++
+		return expr, nil
+	}
+
+	return p.parseTerm()
+}
+
+func (p *searchParser) parseTerm() (searchExpr, error) {
+	field, _ := p.peek()
+	p.pos++
+
+	op, ok := p.peek()
+	if !ok || op.quoted || !isSearchOperator(op.text) {
+		// free text search
+		return searchTerm{values: []string{field.text}}, nil
+	}
+	p.pos++
+
+	if op.text == "^" || op.text == "!^" {
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return searchTerm{field: field.text, op: op.text, values: values}, nil
+	}
+
+	value, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("missing value for %q in search", field.text)
+	}
+	p.pos++
+	return searchTerm{field: field.text, op: op.text, values: []string{value.text}}, nil
+}
+
+func (p *searchParser) parseList() ([]string, error) {
+	if open, ok := p.peek(); !ok || open.text != "(" {
+		value, ok := p.peek()
+		if !ok {
+			return nil, fmt.Errorf("missing values in search")
+		}
+		p.pos++
+		return []string{value.text}, nil
+	}
+	p.pos++
+
+	values := []string{}
+	for {
+		tok, ok := p.peek()
+		if !ok {
+			return nil, fmt.Errorf("missing closing parenthesis in search")
+		}
+		p.pos++
+		if tok.text == ")" && !tok.quoted {
+			return values, nil
+		}
+		if tok.text == "," && !tok.quoted {
+			continue
+		}
+		values = append(values, tok.text)
+	}
+}
+
+func isSearchOperator(text string) bool {
+	for _, op := range searchOperators {
+		if text == op {
+			return true
+		}
+	}
+	return false
+}
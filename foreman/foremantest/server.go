@@ -0,0 +1,931 @@
+// Package foremantest provides a stateful in-memory fake of the Foreman API
+// for offline tests.
+//
+// The fake keeps the objects created through the API in memory, assigns IDs,
+// filters index requests with scoped search and the organization/location
+// taxonomy and emulates the asynchronous Katello tasks answered with
+// "202 Accepted".  It is generic: every collection of the Foreman, Katello
+// and Foreman Puppet APIs is accepted without registration, so the resources
+// of the provider can be created, read, updated and deleted against it
+// without a live Foreman installation.
+//
+// Usage:
+//
+//	server := foremantest.NewServer()
+//	defer server.Close()
+//
+//	server.Seed("organizations", map[string]interface{}{"name": "Default Organization"})
+//	resource.UnitTest(t, resource.TestCase{
+//		ProviderFactories: providerFactories,
+//		Steps: []resource.TestStep{{Config: server.ProviderConfig() + config}},
+//	})
+package foremantest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiPrefixes lists the URL prefixes of the APIs served by the fake, longest
+// first.  Requests for other paths are answered with "404 Not Found".
+var apiPrefixes = []string{
+	"/katello/api/v2",
+	"/katello/api",
+	"/foreman_puppet/api/v2",
+	"/foreman_puppet/api",
+	"/foreman_tasks/api",
+	"/api/v2",
+	"/api",
+}
+
+// nestedCollections lists the collections nested below an object, ie:
+// "parameters" for /api/hosts/:id/parameters.  Other path segments following
+// an object ID address an action on the object, ie: "facts" for
+// /api/hosts/:id/facts.
+var nestedCollections = map[string]bool{
+	"external_usergroups":    true,
+	"filters":                true,
+	"images":                 true,
+	"interfaces":             true,
+	"media":                  true,
+	"os_default_templates":   true,
+	"override_values":        true,
+	"parameters":             true,
+	"repositories":           true,
+	"smart_class_parameters": true,
+	"sync_plans":             true,
+	"template_inputs":        true,
+}
+
+// taskDelay is the number of times a task is reported as pending when it is
+// read from the foreman_tasks API
+const taskDelay = 1
+
+// Server is an in-memory fake Foreman server listening on a local port
+type Server struct {
+	*httptest.Server
+
+	mux *http.ServeMux
+
+	mu sync.Mutex
+	// Last ID assigned to an object.  IDs are unique across collections,
+	// which catches IDs being mixed up between object types in tests.
+	lastID int
+	// Objects by collection (ie: "domains") and ID
+	collections map[string]map[int]map[string]interface{}
+	// Asynchronous tasks by UUID
+	tasks map[string]*task
+	// Functions called after an object of a collection was written
+	writeHooks map[string][]func(obj map[string]interface{})
+}
+
+// task is an asynchronous Katello task.  The task is reported as pending for
+// the first reads and finished afterwards.
+type task struct {
+	data  map[string]interface{}
+	reads int
+}
+
+// NewServer starts and returns a new fake Foreman server.  The server must be
+// closed with Close after the test.
+func NewServer() *Server {
+	s := &Server{
+		mux:         http.NewServeMux(),
+		collections: map[string]map[int]map[string]interface{}{},
+		tasks:       map[string]*task{},
+		writeHooks:  map[string][]func(obj map[string]interface{}){},
+	}
+	s.mux.HandleFunc("/", s.serveAPI)
+	s.Server = httptest.NewServer(s.mux)
+	return s
+}
+
+// Hostname returns the host and port of the server, as used for the
+// server_hostname argument of the provider
+func (s *Server) Hostname() string {
+	serverURL, _ := url.Parse(s.URL)
+	return serverURL.Host
+}
+
+// ProviderConfig returns a provider block configured for the server
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "foreman" {
+  server_hostname = %q
+  server_protocol = "http"
+
+  client_username = "admin"
+  client_password = "changeme"
+}
+`, s.Hostname())
+}
+
+// HandleFunc registers a handler for a path, taking precedence over the
+// generic handling of the fake.  Patterns follow the rules of
+// http.ServeMux, ie: "/api/hosts/1/power".
+func (s *Server) HandleFunc(pattern string, handler http.HandlerFunc) {
+	s.mux.HandleFunc(pattern, handler)
+}
+
+// OnWrite registers a function which is called with the stored object after
+// an object of the collection was created or updated.  It can be used to
+// derive attributes Foreman computes on the server side.  The function is
+// called without the lock of the server and may call its methods, ie: Get.
+// It receives a copy of the object, the changes are stored after all
+// functions returned.
+func (s *Server) OnWrite(collection string, fn func(obj map[string]interface{})) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writeHooks[collection] = append(s.writeHooks[collection], fn)
+}
+
+// Seed stores an object in a collection, ie: "organizations", and returns
+// the assigned ID
+func (s *Server) Seed(collection string, obj map[string]interface{}) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.insert(collection, copyObject(obj))
+}
+
+// Get returns a copy of an object of a collection
+func (s *Server) Get(collection string, id int) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.collections[collection][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(obj), true
+}
+
+// Update merges attributes into an object of a collection, ie: to emulate
+// changes made outside of Terraform.  Returns false if the object does not
+// exist.
+func (s *Server) Update(collection string, id int, attrs map[string]interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.collections[collection][id]
+	if !ok {
+		return false
+	}
+	for key, value := range copyObject(attrs) {
+		obj[key] = value
+	}
+	s.written(collection, obj)
+	return true
+}
+
+// List returns copies of all objects of a collection, ordered by ID
+func (s *Server) List(collection string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objs := s.sorted(collection)
+	for idx, obj := range objs {
+		objs[idx] = copyObject(obj)
+	}
+	return objs
+}
+
+// ----------------------------------------------------------------------------
+// Request Handling
+// ----------------------------------------------------------------------------
+
+// apiRequest is a request parsed into the addressed collection, object and
+// action
+type apiRequest struct {
+	// Collection of the request, ie: "domains"
+	collection string
+	// ID of the addressed object, 0 for the collection itself
+	id int
+	// Action on the object, ie: "publish" for
+	// /katello/api/content_views/:id/publish
+	action string
+	// Attributes set by the path of nested collections, ie: "host_id" for
+	// /api/hosts/:id/parameters
+	parents map[string]interface{}
+	// Whether the request was sent to the Katello API
+	katello bool
+}
+
+// parseAPIPath parses the path of a request.  Returns false if the path does
+// not address an API collection.
+func parseAPIPath(path string) (apiRequest, bool) {
+	req := apiRequest{parents: map[string]interface{}{}}
+
+	prefix := ""
+	for _, p := range apiPrefixes {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			prefix = p
+			break
+		}
+	}
+	if prefix == "" {
+		return req, false
+	}
+	req.katello = strings.HasPrefix(prefix, "/katello")
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, prefix), "/"), "/")
+	if len(segments) == 0 || segments[0] == "" {
+		return req, false
+	}
+
+	for idx := 0; idx < len(segments); idx += 2 {
+		req.collection = segments[idx]
+		req.id = 0
+		if idx+1 >= len(segments) {
+			break
+		}
+		id, err := strconv.Atoi(segments[idx+1])
+		if err != nil {
+			// unknown IDs (ie: UUIDs of tasks) are passed as action
+			req.action = strings.Join(segments[idx+1:], "/")
+			break
+		}
+		req.id = id
+		if idx+2 < len(segments) && !nestedCollections[segments[idx+2]] {
+			req.action = strings.Join(segments[idx+2:], "/")
+			break
+		}
+		if idx+2 < len(segments) {
+			req.parents[singular(req.collection)+"_id"] = float64(id)
+		}
+	}
+	return req, true
+}
+
+// serveAPI is the generic handler for all API requests
+func (s *Server) serveAPI(w http.ResponseWriter, r *http.Request) {
+	req, ok := parseAPIPath(r.URL.Path)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Route %s not found", r.URL.Path))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.collection == "tasks" {
+		s.serveTask(w, r, req)
+		return
+	}
+
+	switch {
+	case req.action != "":
+		s.serveAction(w, r, req)
+	case req.id == 0 && r.Method == http.MethodGet:
+		s.serveIndex(w, r, req)
+	case req.id == 0 && r.Method == http.MethodPost:
+		s.serveCreate(w, r, req)
+	case req.id != 0 && r.Method == http.MethodGet:
+		s.serveRead(w, req)
+	case req.id != 0 && r.Method == http.MethodPut:
+		s.serveUpdate(w, r, req)
+	case req.id != 0 && r.Method == http.MethodDelete:
+		s.serveDelete(w, req)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Route %s %s not found", r.Method, r.URL.Path))
+	}
+}
+
+// serveIndex lists the objects of a collection.  The objects are filtered by
+// the nested path, the taxonomy parameters and the scoped search and
+// returned in pages.
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request, req apiRequest) {
+	query := r.URL.Query()
+
+	expr, err := parseSearch(query.Get("search"))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	// Katello filters by parent parameters, ie: product_id for repositories
+	filters := map[string]interface{}{}
+	for key, value := range req.parents {
+		filters[key] = value
+	}
+	for key := range query {
+		if id, err := strconv.ParseFloat(query.Get(key), 64); err == nil &&
+			strings.HasSuffix(key, "_id") && key != "organization_id" && key != "location_id" {
+			filters[key] = id
+		}
+	}
+
+	all := s.sorted(req.collection)
+	matches := []interface{}{}
+	for _, obj := range all {
+		if !matchAttributes(obj, filters) ||
+			!matchTaxonomy(obj, "organization", query.Get("organization_id")) ||
+			!matchTaxonomy(obj, "location", query.Get("location_id")) ||
+			(expr != nil && !expr.match(obj)) {
+			continue
+		}
+		matches = append(matches, obj)
+	}
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("per_page"))
+	if perPage < 1 {
+		perPage = 20
+	}
+	start := (page - 1) * perPage
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + perPage
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	var results interface{} = matches[start:end]
+	if req.collection == "puppetclasses" {
+		// the Puppet classes are grouped by module
+		results = groupByModule(matches[start:end])
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total":    len(all),
+		"subtotal": len(matches),
+		"page":     page,
+		"per_page": perPage,
+		"search":   query.Get("search"),
+		"sort":     map[string]interface{}{"by": nil, "order": nil},
+		"results":  results,
+	})
+}
+
+// serveCreate stores a new object.  Foreman requests wrap the attributes in
+// an object named after the collection, Katello requests send them
+// unwrapped.
+func (s *Server) serveCreate(w http.ResponseWriter, r *http.Request, req apiRequest) {
+	attrs, err := readAttributes(r, req.collection)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	for key, value := range req.parents {
+		attrs[key] = value
+	}
+
+	if name, ok := attrs["name"]; ok && s.nameTaken(req.collection, 0, name, attrs, req.parents) {
+		writeError(w, http.StatusUnprocessableEntity, "Name has already been taken")
+		return
+	}
+
+	id := s.insert(req.collection, attrs)
+	writeJSON(w, http.StatusCreated, s.collections[req.collection][id])
+}
+
+// serveRead returns a single object
+func (s *Server) serveRead(w http.ResponseWriter, req apiRequest) {
+	obj, ok := s.collections[req.collection][req.id]
+	if !ok {
+		writeNotFound(w, req)
+		return
+	}
+	writeJSON(w, http.StatusOK, obj)
+}
+
+// serveUpdate merges the attributes of the request into an object
+func (s *Server) serveUpdate(w http.ResponseWriter, r *http.Request, req apiRequest) {
+	obj, ok := s.collections[req.collection][req.id]
+	if !ok {
+		writeNotFound(w, req)
+		return
+	}
+
+	attrs, err := readAttributes(r, req.collection)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if name, ok := attrs["name"]; ok && s.nameTaken(req.collection, req.id, name, obj, req.parents) {
+		writeError(w, http.StatusUnprocessableEntity, "Name has already been taken")
+		return
+	}
+
+	for key, value := range attrs {
+		if key != "id" {
+			obj[key] = value
+		}
+	}
+	s.written(req.collection, obj)
+	writeJSON(w, http.StatusOK, obj)
+}
+
+// serveDelete removes an object.  Katello answers deletions of products,
+// repositories and content views with an asynchronous task.
+func (s *Server) serveDelete(w http.ResponseWriter, req apiRequest) {
+	obj, ok := s.collections[req.collection][req.id]
+	if !ok {
+		writeNotFound(w, req)
+		return
+	}
+	delete(s.collections[req.collection], req.id)
+	if req.collection == "external_usergroups" {
+		s.nestExternalUsergroups(obj["usergroup_id"])
+	}
+
+	if req.katello {
+		switch req.collection {
+		case "products", "repositories", "content_views":
+			label := fmt.Sprintf("Actions::Katello::%s::Destroy", actionResource(req.collection))
+			s.writeTask(w, label, map[string]interface{}{singular(req.collection) + "_id": float64(req.id)}, "success")
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, obj)
+}
+
+// serveAction handles actions on objects.  Only the Katello content view
+// actions, the cloning of roles, the refresh of external usergroups and the
+// power and disassociate actions of hosts are emulated, other actions need a
+// handler registered with HandleFunc.
+func (s *Server) serveAction(w http.ResponseWriter, r *http.Request, req apiRequest) {
+	obj, ok := s.collections[req.collection][req.id]
+	if !ok {
+		writeNotFound(w, req)
+		return
+	}
+
+	switch {
+	case req.collection == "content_views" && req.action == "publish" && r.Method == http.MethodPost:
+		version, _ := obj["latest_version"].(string)
+		major, _ := strconv.Atoi(strings.Split(version, ".")[0])
+		count, _ := obj["version_count"].(float64)
+		obj["latest_version"] = fmt.Sprintf("%d.0", major+1)
+		obj["version_count"] = count + 1
+		obj["last_published"] = time.Now().UTC().Format("2006-01-02 15:04:05 UTC")
+		s.writeTask(w, "Actions::Katello::ContentView::Publish",
+			map[string]interface{}{"content_view_id": float64(req.id)}, "success")
+	case req.collection == "content_views" && req.action == "remove" && r.Method == http.MethodPut:
+		delete(s.collections[req.collection], req.id)
+		s.writeTask(w, "Actions::Katello::ContentView::Remove",
+			map[string]interface{}{"content_view_id": float64(req.id)}, "success")
+	case req.collection == "roles" && req.action == "clone" && r.Method == http.MethodPost:
+		s.cloneRole(w, r, req, obj)
+	case req.collection == "external_usergroups" && req.action == "refresh" && r.Method == http.MethodPut:
+		writeJSON(w, http.StatusOK, obj)
+	case req.collection == "hosts" && req.action == "power" && r.Method == http.MethodPut:
+		s.powerHost(w, r, obj)
+	case req.collection == "hosts" && req.action == "disassociate" && r.Method == http.MethodPut:
+		delete(obj, "compute_resource_id")
+		delete(obj, "uuid")
+		s.written(req.collection, obj)
+		writeJSON(w, http.StatusOK, obj)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Route %s %s not found", r.Method, r.URL.Path))
+	}
+}
+
+// powerHost runs a power action on a host.  The power state is kept in the
+// "power_state" attribute of the host, hosts without the attribute have no
+// BMC or compute resource to run power actions with.
+func (s *Server) powerHost(w http.ResponseWriter, r *http.Request, host map[string]interface{}) {
+	var body struct {
+		PowerAction string `json:"power_action"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	state, ok := host["power_state"].(string)
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Power operations are not enabled on this host.")
+		return
+	}
+
+	switch body.PowerAction {
+	case "state":
+		writeJSON(w, http.StatusOK, map[string]interface{}{"power": state})
+		return
+	case "on", "cycle", "soft":
+		host["power_state"] = "on"
+	case "off":
+		host["power_state"] = "off"
+	default:
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Unknown power action: %s", body.PowerAction))
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"power": true})
+}
+
+// cloneRole creates a copy of a role and its filters with the attributes of
+// the request
+func (s *Server) cloneRole(w http.ResponseWriter, r *http.Request, req apiRequest, role map[string]interface{}) {
+	attrs, err := readAttributes(r, req.collection)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if s.nameTaken(req.collection, 0, attrs["name"], attrs, req.parents) {
+		writeError(w, http.StatusUnprocessableEntity, "Name has already been taken")
+		return
+	}
+
+	clone := copyObject(role)
+	delete(clone, "id")
+	for key, value := range attrs {
+		clone[key] = value
+	}
+	clone["builtin"] = float64(0)
+	clone["origin"] = nil
+	clone["cloned_from_id"] = float64(req.id)
+	id := s.insert(req.collection, clone)
+
+	for _, filter := range s.sorted("filters") {
+		if filter["role_id"] == float64(req.id) {
+			filterClone := copyObject(filter)
+			delete(filterClone, "id")
+			filterClone["role_id"] = float64(id)
+			s.insert("filters", filterClone)
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, s.collections[req.collection][id])
+}
+
+// serveTask returns an asynchronous task.  Tasks are reported as pending for
+// the first taskDelay reads.
+func (s *Server) serveTask(w http.ResponseWriter, r *http.Request, req apiRequest) {
+	t, ok := s.tasks[req.action]
+	if !ok || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Resource task not found by id '%s'", req.action))
+		return
+	}
+
+	t.reads++
+	if t.reads > taskDelay {
+		t.data["pending"] = false
+		t.data["state"] = "stopped"
+		t.data["progress"] = 1.0
+		t.data["ended_at"] = time.Now().UTC().Format(time.RFC3339)
+	}
+	writeJSON(w, http.StatusOK, t.data)
+}
+
+// writeTask creates a pending task and writes it as "202 Accepted" response
+func (s *Server) writeTask(w http.ResponseWriter, label string, output map[string]interface{}, result string) {
+	s.lastID++
+	uuid := fmt.Sprintf("00000000-0000-4000-8000-%012d", s.lastID)
+	data := map[string]interface{}{
+		"id":         uuid,
+		"label":      label,
+		"pending":    true,
+		"state":      "running",
+		"result":     result,
+		"progress":   0.5,
+		"started_at": time.Now().UTC().Format(time.RFC3339),
+		"input":      map[string]interface{}{},
+		"output":     output,
+		"humanized": map[string]interface{}{
+			"action": label,
+			"errors": []string{},
+		},
+	}
+	s.tasks[uuid] = &task{data: data}
+	writeJSON(w, http.StatusAccepted, data)
+}
+
+// ----------------------------------------------------------------------------
+// Storage
+// ----------------------------------------------------------------------------
+
+// insert assigns an ID to the object and stores it.  The lock must be held.
+func (s *Server) insert(collection string, obj map[string]interface{}) int {
+	s.lastID++
+	obj["id"] = float64(s.lastID)
+	if s.collections[collection] == nil {
+		s.collections[collection] = map[int]map[string]interface{}{}
+	}
+	s.collections[collection][s.lastID] = obj
+	s.written(collection, obj)
+	return s.lastID
+}
+
+// written derives the attributes Foreman computes on the server side and
+// runs the hooks of the collection.  The lock must be held, it is released
+// while the hooks run.
+func (s *Server) written(collection string, obj map[string]interface{}) {
+	// IDs sent as string are cast to numbers, like Rails does
+	for key, value := range obj {
+		if str, ok := value.(string); ok && strings.HasSuffix(key, "_id") {
+			if id, err := strconv.Atoi(str); err == nil {
+				obj[key] = float64(id)
+			}
+		}
+	}
+
+	// nested parameters replace the parameters of the object and are
+	// returned as "parameters"
+	for key, value := range obj {
+		attrs, ok := value.([]interface{})
+		if !ok || !strings.HasSuffix(key, "_parameters_attributes") {
+			continue
+		}
+		params := []interface{}{}
+		for _, attr := range attrs {
+			if param, ok := attr.(map[string]interface{}); ok && param["_destroy"] != true {
+				params = append(params, map[string]interface{}{"name": param["name"], "value": param["value"]})
+			}
+		}
+		obj["parameters"] = params
+		delete(obj, key)
+	}
+
+	// the associated organizations and locations are returned as nested
+	// objects
+	for ids, nested := range map[string]string{"organization_ids": "organizations", "location_ids": "locations"} {
+		if values, ok := obj[ids].([]interface{}); ok && nested != collection {
+			objects := []interface{}{}
+			for _, id := range values {
+				if taxonomy, ok := s.lookup(nested, id); ok {
+					objects = append(objects, map[string]interface{}{"id": taxonomy["id"], "name": taxonomy["name"]})
+				}
+			}
+			obj[nested] = objects
+		}
+	}
+
+	switch collection {
+	case "hostgroups", "locations", "organizations":
+		// nested objects are titled with the path of their parents
+		obj["title"] = obj["name"]
+		if parentID, ok := obj["parent_id"].(float64); ok {
+			if parent, ok := s.collections[collection][int(parentID)]; ok {
+				obj["title"] = fmt.Sprintf("%v/%v", parent["title"], obj["name"])
+			}
+		}
+	case "users", "usergroups":
+		// assigned roles are returned as nested objects
+		if ids, ok := obj["role_ids"].([]interface{}); ok {
+			roles := []interface{}{}
+			for _, id := range ids {
+				if role, ok := s.lookup("roles", id); ok {
+					roles = append(roles, map[string]interface{}{"id": role["id"], "name": role["name"]})
+				}
+			}
+			obj["roles"] = roles
+		}
+	case "external_usergroups":
+		s.nestExternalUsergroups(obj["usergroup_id"])
+	case "filters":
+		// filters return their role and permissions as nested objects and
+		// the booleans with a question mark
+		if role, ok := s.lookup("roles", obj["role_id"]); ok {
+			obj["role"] = map[string]interface{}{"id": role["id"], "name": role["name"]}
+		}
+		permissions := []interface{}{}
+		if ids, ok := obj["permission_ids"].([]interface{}); ok {
+			for _, id := range ids {
+				if p, ok := s.lookup("permissions", id); ok {
+					permissions = append(permissions, p)
+				}
+			}
+		}
+		obj["permissions"] = permissions
+		search, _ := obj["search"].(string)
+		obj["unlimited?"] = search == ""
+		obj["override?"] = obj["override"] == true
+	case "config_groups":
+		// the puppet classes are returned as nested objects
+		puppetclasses := []interface{}{}
+		if ids, ok := obj["puppetclass_ids"].([]interface{}); ok {
+			for _, id := range ids {
+				if class, ok := s.lookup("puppetclasses", id); ok {
+					puppetclasses = append(puppetclasses, map[string]interface{}{"id": class["id"], "name": class["name"]})
+				}
+			}
+		}
+		obj["puppetclasses"] = puppetclasses
+	case "smart_class_parameters":
+		obj["hidden_value?"] = obj["hidden_value"] == true
+	case "operatingsystems":
+		title := fmt.Sprintf("%v %v", obj["name"], obj["major"])
+		if minor, ok := obj["minor"].(string); ok && minor != "" {
+			title += "." + minor
+		}
+		obj["title"] = title
+	}
+
+	// the hooks run without the lock, so they can call back into the server.
+	// They change a copy, which replaces the attributes of the object.
+	hooks := append([]func(obj map[string]interface{}){}, s.writeHooks[collection]...)
+	if len(hooks) == 0 {
+		return
+	}
+	hooked := copyObject(obj)
+	s.mu.Unlock()
+	for _, fn := range hooks {
+		fn(hooked)
+	}
+	s.mu.Lock()
+	for key := range obj {
+		delete(obj, key)
+	}
+	for key, value := range hooked {
+		obj[key] = value
+	}
+}
+
+// nestExternalUsergroups sets the external usergroups of a usergroup as
+// nested objects, like Foreman returns them.  The lock must be held.
+func (s *Server) nestExternalUsergroups(usergroupID interface{}) {
+	usergroup, ok := s.lookup("usergroups", usergroupID)
+	if !ok {
+		return
+	}
+	groups := []interface{}{}
+	for _, group := range s.sorted("external_usergroups") {
+		if group["usergroup_id"] == usergroupID {
+			groups = append(groups, map[string]interface{}{
+				"id":             group["id"],
+				"name":           group["name"],
+				"auth_source_id": group["auth_source_id"],
+			})
+		}
+	}
+	usergroup["external_usergroups"] = groups
+}
+
+// lookup returns the object of a collection with an ID decoded from JSON.
+// The lock must be held.
+func (s *Server) lookup(collection string, id interface{}) (map[string]interface{}, bool) {
+	idNum, ok := id.(float64)
+	if !ok {
+		return nil, false
+	}
+	obj, ok := s.collections[collection][int(idNum)]
+	return obj, ok
+}
+
+// sorted returns the objects of a collection ordered by ID.  The lock must
+// be held.
+func (s *Server) sorted(collection string) []map[string]interface{} {
+	objs := make([]map[string]interface{}, 0, len(s.collections[collection]))
+	for _, obj := range s.collections[collection] {
+		objs = append(objs, obj)
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		return objs[i]["id"].(float64) < objs[j]["id"].(float64)
+	})
+	return objs
+}
+
+// nameTaken returns true if another object of the collection with the same
+// parents and organization already uses the name.  The lock must be held.
+func (s *Server) nameTaken(collection string, id int, name interface{}, attrs map[string]interface{}, parents map[string]interface{}) bool {
+	for otherID, other := range s.collections[collection] {
+		if otherID == id || other["name"] != name || !matchAttributes(other, parents) {
+			continue
+		}
+		if attrs["organization_id"] != nil && other["organization_id"] != attrs["organization_id"] {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Helper Functions
+// ----------------------------------------------------------------------------
+
+// readAttributes decodes the body of a create or update request.  Attributes
+// wrapped in an object named after the collection are unwrapped and the
+// taxonomy of the request is assigned to them.
+func readAttributes(r *http.Request, collection string) (map[string]interface{}, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	attrs := map[string]interface{}{}
+	if len(body) == 0 {
+		return attrs, nil
+	}
+	if err := json.Unmarshal(body, &attrs); err != nil {
+		return nil, fmt.Errorf("Invalid JSON body: %s", err)
+	}
+
+	wrapped, ok := attrs[singular(collection)].(map[string]interface{})
+	if !ok {
+		return attrs, nil
+	}
+	for _, taxonomy := range []string{"organization", "location"} {
+		id, ok := attrs[taxonomy+"_id"].(float64)
+		if _, set := wrapped[taxonomy+"_ids"]; ok && id > 0 && !set {
+			wrapped[taxonomy+"_ids"] = []interface{}{id}
+		}
+	}
+	return wrapped, nil
+}
+
+// matchAttributes returns true if the object has all supplied attributes
+func matchAttributes(obj map[string]interface{}, attrs map[string]interface{}) bool {
+	for key, value := range attrs {
+		if obj[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTaxonomy returns true if the object belongs to the organization or
+// location with the supplied ID.  Objects without taxonomy and requests
+// without the parameter always match.
+func matchTaxonomy(obj map[string]interface{}, taxonomy string, param string) bool {
+	id, err := strconv.ParseFloat(param, 64)
+	if err != nil || id <= 0 {
+		return true
+	}
+	if value, ok := obj[taxonomy+"_id"].(float64); ok {
+		return value == id
+	}
+	if values, ok := obj[taxonomy+"_ids"].([]interface{}); ok {
+		for _, value := range values {
+			if value == id {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// groupByModule groups Puppet classes by the module part of their name, as
+// done by the Puppet class index of Foreman
+func groupByModule(objs []interface{}) map[string]interface{} {
+	grouped := map[string]interface{}{}
+	for _, obj := range objs {
+		name, _ := obj.(map[string]interface{})["name"].(string)
+		module, _, _ := strings.Cut(name, "::")
+		list, _ := grouped[module].([]interface{})
+		grouped[module] = append(list, obj)
+	}
+	return grouped
+}
+
+// singular returns the singular form of a collection name, which Foreman
+// uses as the name of the wrapping object and of ID attributes
+func singular(collection string) string {
+	switch {
+	case collection == "media":
+		return "medium"
+	case strings.HasSuffix(collection, "ies"):
+		return strings.TrimSuffix(collection, "ies") + "y"
+	case strings.HasSuffix(collection, "sses"):
+		return strings.TrimSuffix(collection, "es")
+	default:
+		return strings.TrimSuffix(collection, "s")
+	}
+}
+
+// actionResource returns the resource name of a collection in Katello task
+// labels, ie: "ContentView" for "content_views"
+func actionResource(collection string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(singular(collection), "_") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// copyObject returns a deep copy of an object, so callers can not modify the
+// stored state
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(obj)
+	objCopy := map[string]interface{}{}
+	_ = json.Unmarshal(data, &objCopy)
+	return objCopy
+}
+
+func writeNotFound(w http.ResponseWriter, req apiRequest) {
+	writeError(w, http.StatusNotFound,
+		fmt.Sprintf("Resource %s not found by id '%d'", singular(req.collection), req.id))
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{"message": message},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,290 @@
+package foremantest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newClient returns an API client for the fake server
+func newClient(server *foremantest.Server, cfg api.ClientConfig) *api.Client {
+	serverURL, _ := url.Parse(server.URL)
+	return api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, cfg)
+}
+
+// ----------------------------------------------------------------------------
+// CRUD
+// ----------------------------------------------------------------------------
+
+// Ensures objects are created, read, updated and deleted with the state kept
+// between the requests
+func TestServer_CRUD(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	client := newClient(server, api.ClientConfig{})
+	ctx := context.TODO()
+
+	created, err := client.CreateDomain(ctx, &api.ForemanDomain{
+		ForemanObject: api.ForemanObject{Name: "example.com"},
+		Fullname:      "Example",
+	})
+	if err != nil {
+		t.Fatalf("CreateDomain returned error [%s]", err)
+	}
+	if created.Id == 0 {
+		t.Fatalf("CreateDomain did not assign an ID")
+	}
+
+	read, err := client.ReadDomain(ctx, created.Id)
+	if err != nil {
+		t.Fatalf("ReadDomain returned error [%s]", err)
+	}
+	if read.Name != "example.com" || read.Fullname != "Example" {
+		t.Fatalf("ReadDomain did not return the created domain, got [%+v]", read)
+	}
+
+	read.Fullname = "Updated"
+	if _, err := client.UpdateDomain(ctx, read, read.Id); err != nil {
+		t.Fatalf("UpdateDomain returned error [%s]", err)
+	}
+	if stored, _ := server.Get("domains", created.Id); stored["fullname"] != "Updated" {
+		t.Fatalf("UpdateDomain did not update the stored domain, got [%v]", stored)
+	}
+
+	if _, err := client.CreateDomain(ctx, &api.ForemanDomain{
+		ForemanObject: api.ForemanObject{Name: "example.com"},
+	}); err == nil {
+		t.Fatalf("CreateDomain did not return an error for a duplicate name")
+	}
+
+	if err := client.DeleteDomain(ctx, created.Id); err != nil {
+		t.Fatalf("DeleteDomain returned error [%s]", err)
+	}
+	if _, err := client.ReadDomain(ctx, created.Id); err == nil {
+		t.Fatalf("ReadDomain did not return an error for a deleted domain")
+	}
+}
+
+// Ensures write hooks can call back into the server and their changes are
+// stored and returned
+func TestServer_OnWrite(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	client := newClient(server, api.ClientConfig{})
+
+	server.OnWrite("domains", func(obj map[string]interface{}) {
+		id, _ := obj["id"].(float64)
+		if _, ok := server.Get("domains", int(id)); !ok {
+			t.Errorf("Get did not return the written domain [%v]", obj["id"])
+		}
+		obj["fullname"] = fmt.Sprintf("Domain %v", obj["name"])
+	})
+
+	done := make(chan struct{})
+	var created *api.ForemanDomain
+	var err error
+	go func() {
+		defer close(done)
+		created, err = client.CreateDomain(context.TODO(), &api.ForemanDomain{
+			ForemanObject: api.ForemanObject{Name: "example.com"},
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("CreateDomain did not return, the write hook deadlocked")
+	}
+
+	if err != nil || created.Fullname != "Domain example.com" {
+		t.Fatalf("CreateDomain did not return the attributes of the hook, got [%+v] and error [%v]", created, err)
+	}
+	if stored, _ := server.Get("domains", created.Id); stored["fullname"] != "Domain example.com" {
+		t.Fatalf("Hook changes were not stored, got [%v]", stored)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Search
+// ----------------------------------------------------------------------------
+
+// Ensures index requests are filtered with scoped search and the taxonomy
+func TestServer_Search(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	client := newClient(server, api.ClientConfig{})
+	ctx := context.TODO()
+
+	server.Seed("domains", map[string]interface{}{"name": "a.example.com", "organization_ids": []interface{}{1}})
+	server.Seed("domains", map[string]interface{}{"name": "b.example.com", "organization_ids": []interface{}{2}})
+	server.Seed("domains", map[string]interface{}{"name": "c.example.org"})
+
+	testCases := []struct {
+		Search   string
+		Params   url.Values
+		Expected []string
+	}{
+		{Search: `name = "a.example.com"`, Expected: []string{"a.example.com"}},
+		{Search: `name ~ example.com`, Expected: []string{"a.example.com", "b.example.com"}},
+		{Search: `name ^ ("b.example.com", "c.example.org")`, Expected: []string{"b.example.com", "c.example.org"}},
+		{Search: `not name ~ example.com or name = a.example.com`, Expected: []string{"a.example.com", "c.example.org"}},
+		{Search: `(name = a.example.com or name = b.example.com) and id > 1`, Expected: []string{"b.example.com"}},
+		{Search: `example.org`, Expected: []string{"c.example.org"}},
+		{Params: url.Values{"organization_id": []string{"2"}}, Expected: []string{"b.example.com", "c.example.org"}},
+	}
+
+	for _, testCase := range testCases {
+		var search *api.SearchQuery
+		if testCase.Search != "" {
+			search = (&api.SearchQuery{}).Raw(testCase.Search)
+		}
+		resp, err := api.SearchAll[api.ForemanObject](ctx, client, api.DomainEndpointPrefix, search, testCase.Params)
+		if err != nil {
+			t.Fatalf("SearchAll returned error [%s] for search [%s]", err, testCase.Search)
+		}
+
+		names := []string{}
+		for _, result := range resp.Results {
+			names = append(names, result.(api.ForemanObject).Name)
+		}
+		if len(names) != len(testCase.Expected) {
+			t.Fatalf("Expected [%v], got [%v] for search [%s]", testCase.Expected, names, testCase.Search)
+		}
+		for idx := range names {
+			if names[idx] != testCase.Expected[idx] {
+				t.Fatalf("Expected [%v], got [%v] for search [%s]", testCase.Expected, names, testCase.Search)
+			}
+		}
+	}
+}
+
+// Ensures only the known nested collections are served below an object and
+// other path segments are routed as actions
+func TestServer_NestedCollections(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	hostId := server.Seed("hosts", map[string]interface{}{"name": "web01.example.com"})
+	server.Seed("parameters", map[string]interface{}{"name": "role", "host_id": hostId})
+	server.Seed("parameters", map[string]interface{}{"name": "role", "hostgroup_id": hostId + 100})
+
+	testCases := []struct {
+		Path       string
+		StatusCode int
+		Subtotal   int
+	}{
+		{Path: fmt.Sprintf("/api/hosts/%d/parameters", hostId), StatusCode: http.StatusOK, Subtotal: 1},
+		{Path: fmt.Sprintf("/api/hosts/%d/facts", hostId), StatusCode: http.StatusNotFound},
+		{Path: fmt.Sprintf("/api/hosts/%d/status/global", hostId), StatusCode: http.StatusNotFound},
+	}
+
+	for _, testCase := range testCases {
+		resp, err := http.Get(server.URL + testCase.Path)
+		if err != nil {
+			t.Fatalf("GET [%s] returned error [%s]", testCase.Path, err)
+		}
+		var body struct {
+			Subtotal int `json:"subtotal"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		resp.Body.Close()
+		if resp.StatusCode != testCase.StatusCode || body.Subtotal != testCase.Subtotal {
+			t.Fatalf(
+				"GET [%s] expected status [%d] with [%d] results, got [%d] with [%d]",
+				testCase.Path,
+				testCase.StatusCode,
+				testCase.Subtotal,
+				resp.StatusCode,
+				body.Subtotal,
+			)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Katello Tasks
+// ----------------------------------------------------------------------------
+
+// Ensures content views are published and deleted through asynchronous
+// tasks
+func TestServer_KatelloTasks(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	client := newClient(server, api.ClientConfig{OrganizationID: 1, LocationID: 2})
+	ctx := context.TODO()
+
+	cv, err := client.CreateKatelloContentView(ctx, &api.ContentView{
+		ForemanObject:  api.ForemanObject{Name: "base"},
+		OrganizationId: 1,
+	})
+	if err != nil {
+		t.Fatalf("CreateKatelloContentView returned error [%s]", err)
+	}
+	if cv.Name != "base" || cv.LatestVersion != "1.0" {
+		t.Fatalf("CreateKatelloContentView did not publish the content view, got [%+v]", cv)
+	}
+
+	if err := client.DeleteKatelloContentView(ctx, cv.Id); err != nil {
+		t.Fatalf("DeleteKatelloContentView returned error [%s]", err)
+	}
+	if _, ok := server.Get("content_views", cv.Id); ok {
+		t.Fatalf("DeleteKatelloContentView did not delete the content view")
+	}
+
+	product, err := client.CreateKatelloProduct(ctx, &api.ForemanKatelloProduct{
+		ForemanObject: api.ForemanObject{Name: "EPEL"},
+	})
+	if err != nil {
+		t.Fatalf("CreateKatelloProduct returned error [%s]", err)
+	}
+	if err := client.DeleteKatelloProduct(ctx, product.Id); err != nil {
+		t.Fatalf("DeleteKatelloProduct returned error [%s]", err)
+	}
+	if len(server.List("products")) != 0 {
+		t.Fatalf("DeleteKatelloProduct did not delete the product")
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Provider Resources
+// ----------------------------------------------------------------------------
+
+// Ensures the provider's resources run their lifecycle against the fake
+func TestServer_ProviderResource(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	client := newClient(server, api.ClientConfig{})
+	ctx := context.TODO()
+
+	r := foreman.Provider().ResourcesMap["foreman_domain"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":     "example.com",
+		"fullname": "Example",
+	})
+
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Read returned error [%s]", diags[0].Summary)
+	}
+	if d.Get("fullname") != "Example" {
+		t.Fatalf("Expected [%s], got [%s]", "Example", d.Get("fullname"))
+	}
+
+	if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Delete returned error [%s]", diags[0].Summary)
+	}
+	if len(server.List("domains")) != 0 {
+		t.Fatalf("Delete did not delete the domain")
+	}
+}
@@ -0,0 +1,103 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// -----------------------------------------------------------------------------
+// Import by Name
+// -----------------------------------------------------------------------------
+
+// importLookup queries Foreman for the objects identified by the supplied
+// human readable value, ie: the name of a domain
+type importLookup func(ctx context.Context, client *api.Client, value string) (api.QueryResponse, error)
+
+// importResult is implemented by all API models embedding api.ForemanObject
+type importResult interface {
+	ObjectId() int
+}
+
+// importStateByLookup returns an import function which accepts the numeric
+// ID of an object as well as human readable identifiers of the form
+// "<attribute>:<value>", ie: "name:example.com" or "title:base/web".  The
+// attributes are the keys of lookups, each value is resolved to exactly one
+// object with the corresponding lookup.
+//
+// If defaultAttr is set, import IDs without a known attribute prefix are
+// resolved with the lookup of defaultAttr, ie: a plain FQDN for hosts.
+func importStateByLookup(defaultAttr string, lookups map[string]importLookup) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		log.Tracef("import_helper.go#importStateByLookup")
+
+		importId := d.Id()
+		if _, err := strconv.Atoi(importId); err == nil {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		attr, value, found := strings.Cut(importId, ":")
+		lookup, ok := lookups[attr]
+		if !found || !ok {
+			if defaultAttr == "" {
+				return nil, fmt.Errorf(
+					"Import ID [%s] is neither a numeric ID nor of the form "+
+						"<attribute>:<value>, supported attributes: %s",
+					importId,
+					strings.Join(importLookupAttrs(lookups), ", "),
+				)
+			}
+			attr, value, lookup = defaultAttr, importId, lookups[defaultAttr]
+		}
+
+		queryResponse, queryErr := lookup(ctx, meta.(*api.Client), value)
+		if queryErr != nil {
+			return nil, queryErr
+		}
+
+		log.Debugf("queryResponse: [%+v]", queryResponse)
+
+		if queryResponse.Subtotal == 0 || len(queryResponse.Results) == 0 {
+			return nil, fmt.Errorf("Import by %s [%s] returned no results", attr, value)
+		} else if queryResponse.Subtotal > 1 || len(queryResponse.Results) > 1 {
+			return nil, fmt.Errorf("Import by %s [%s] returned more than 1 result", attr, value)
+		}
+
+		result, ok := queryResponse.Results[0].(importResult)
+		if !ok {
+			return nil, fmt.Errorf(
+				"Import results contain unexpected type [%T]",
+				queryResponse.Results[0],
+			)
+		}
+
+		d.SetId(strconv.Itoa(result.ObjectId()))
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// importLookupAttrs returns the sorted attribute prefixes of the lookups
+func importLookupAttrs(lookups map[string]importLookup) []string {
+	attrs := make([]string, 0, len(lookups))
+	for attr := range lookups {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+	return attrs
+}
+
+// importByName returns an import function for object types which are looked
+// up by their name only, ie: "name:example.com"
+func importByName(query importLookup) schema.StateContextFunc {
+	return importStateByLookup("", map[string]importLookup{
+		"name": query,
+	})
+}
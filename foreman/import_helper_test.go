@@ -0,0 +1,168 @@
+package foreman
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+)
+
+// ----------------------------------------------------------------------------
+// Import by Name
+// ----------------------------------------------------------------------------
+
+// Ensures the importers resolve human readable identifiers to the numeric ID
+// of the object and keep numeric IDs as they are
+func TestImportStateByLookup(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		Resource     string
+		ImportId     string
+		URI          string
+		ResponseFile string
+		Search       string
+		ExpectedId   string
+	}{
+		{
+			Name:       "numeric id",
+			Resource:   "foreman_domain",
+			ImportId:   "35",
+			ExpectedId: "35",
+		},
+		{
+			Name:         "domain by name",
+			Resource:     "foreman_domain",
+			ImportId:     "name:dev.dc1.company.com",
+			URI:          DomainsURI,
+			ResponseFile: DomainsTestDataPath + "/query_response_single.json",
+			Search:       `name = "dev.dc1.company.com"`,
+			ExpectedId:   "35",
+		},
+		{
+			Name:         "hostgroup by title",
+			Resource:     "foreman_hostgroup",
+			ImportId:     "title:base/web",
+			URI:          HostgroupsURI,
+			ResponseFile: HostgroupsTestDataPath + "/query_response_single.json",
+			Search:       `title = "base/web"`,
+			ExpectedId:   "166",
+		},
+		{
+			Name:         "host by plain fqdn",
+			Resource:     "foreman_host",
+			ImportId:     "foremanterraformtest.dev.company.com",
+			URI:          HostsURI,
+			ResponseFile: HostsTestDataPath + "/query_response_single.json",
+			Search: `name = "foremanterraformtest.dev.company.com" or ` +
+				`(name = "foremanterraformtest" and domain = "dev.company.com")`,
+			ExpectedId: "34068",
+		},
+		{
+			Name:         "host by name",
+			Resource:     "foreman_host",
+			ImportId:     "name:foremanterraformtest",
+			URI:          HostsURI,
+			ResponseFile: HostsTestDataPath + "/query_response_single.json",
+			Search:       `name = "foremanterraformtest"`,
+			ExpectedId:   "34068",
+		},
+	}
+
+	provider := Provider()
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		var search string
+		if testCase.URI != "" {
+			mux.HandleFunc(testCase.URI, func(w http.ResponseWriter, r *http.Request) {
+				search = r.URL.Query().Get("search")
+				bytes, _ := os.ReadFile(testCase.ResponseFile)
+				w.Write(bytes)
+			})
+		}
+
+		r := provider.ResourcesMap[testCase.Resource]
+		d := r.TestResourceData()
+		d.SetId(testCase.ImportId)
+
+		results, err := r.Importer.StateContext(context.TODO(), d, client)
+		server.Close()
+		if err != nil {
+			t.Fatalf("Import for test case [%s] returned error [%s]", testCase.Name, err)
+		}
+
+		if search != testCase.Search {
+			t.Fatalf(
+				"Import for test case [%s] did not send the correct search. "+
+					"Expected [%s], got [%s]",
+				testCase.Name,
+				testCase.Search,
+				search,
+			)
+		}
+		if len(results) != 1 || results[0].Id() != testCase.ExpectedId {
+			t.Fatalf(
+				"Import for test case [%s] did not set the correct ID. "+
+					"Expected [%s], got [%s]",
+				testCase.Name,
+				testCase.ExpectedId,
+				d.Id(),
+			)
+		}
+	}
+}
+
+// Ensures the importers fail for unknown attributes and for identifiers
+// which do not match exactly one object
+func TestImportStateByLookup_Error(t *testing.T) {
+	testCases := []struct {
+		Name         string
+		ImportId     string
+		ResponseFile string
+	}{
+		{
+			Name:     "unknown attribute",
+			ImportId: "fullname:dev.dc1.company.com",
+		},
+		{
+			Name:     "missing attribute",
+			ImportId: "dev.dc1.company.com",
+		},
+		{
+			Name:         "no results",
+			ImportId:     "name:dev.dc1.company.com",
+			ResponseFile: TestDataPath + "/query_response_zero.json",
+		},
+		{
+			Name:         "multiple results",
+			ImportId:     "name:dev.dc1.company.com",
+			ResponseFile: DomainsTestDataPath + "/query_response_multi.json",
+		},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		var queried bool
+		mux.HandleFunc(DomainsURI, func(w http.ResponseWriter, r *http.Request) {
+			queried = true
+			bytes, _ := os.ReadFile(testCase.ResponseFile)
+			w.Write(bytes)
+		})
+
+		r := resourceForemanDomain()
+		d := r.TestResourceData()
+		d.SetId(testCase.ImportId)
+
+		_, err := r.Importer.StateContext(context.TODO(), d, client)
+		server.Close()
+		if err == nil {
+			t.Fatalf("Import for test case [%s] did not return an error", testCase.Name)
+		}
+		if queried != (testCase.ResponseFile != "") {
+			t.Fatalf("Import for test case [%s] unexpectedly queried Foreman: [%t]", testCase.Name, queried)
+		}
+	}
+}
@@ -178,6 +178,7 @@ func Provider() *schema.Provider {
 		ResourcesMap: map[string]*schema.Resource{
 			"foreman_architecture":                  resourceForemanArchitecture(),
 			"foreman_host":                          resourceForemanHost(),
+			"foreman_host_interface":                resourceForemanHostInterface(),
 			"foreman_hostgroup":                     resourceForemanHostgroup(),
 			"foreman_discovery_rule":                resourceForemanDiscoveryRule(),
 			"foreman_media":                         resourceForemanMedia(),
@@ -209,41 +210,94 @@ func Provider() *schema.Provider {
 			"foreman_templateinput":                 resourceForemanTemplateInput(),
 			"foreman_webhook":                       resourceForemanWebhook(),
 			"foreman_webhooktemplate":               resourceForemanWebhookTemplate(),
+			"foreman_organization":                  resourceForemanOrganization(),
+			"foreman_location":                      resourceForemanLocation(),
+			"foreman_role":                          resourceForemanRole(),
+			"foreman_filter":                        resourceForemanFilter(),
+			"foreman_auth_source_ldap":              resourceForemanAuthSourceLdap(),
+			"foreman_setting":                       resourceForemanSetting(),
+			"foreman_realm":                         resourceForemanRealm(),
+			"foreman_config_group":                  resourceForemanConfigGroup(),
+			"foreman_smartclassparameter":           resourceForemanSmartClassParameter(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"foreman_architecture":                  dataSourceForemanArchitecture(),
-			"foreman_domain":                        dataSourceForemanDomain(),
-			"foreman_environment":                   dataSourceForemanEnvironment(),
-			"foreman_hostgroup":                     dataSourceForemanHostgroup(),
-			"foreman_media":                         dataSourceForemanMedia(),
-			"foreman_model":                         dataSourceForemanModel(),
-			"foreman_operatingsystem":               dataSourceForemanOperatingSystem(),
-			"foreman_partitiontable":                dataSourceForemanPartitionTable(),
-			"foreman_provisioningtemplate":          dataSourceForemanProvisioningTemplate(),
-			"foreman_puppetclass":                   dataSourceForemanPuppetClass(),
-			"foreman_smartclassparameter":           dataSourceForemanSmartClassParameter(),
-			"foreman_smartproxy":                    dataSourceForemanSmartProxy(),
-			"foreman_subnet":                        dataSourceForemanSubnet(),
-			"foreman_templatekind":                  dataSourceForemanTemplateKind(),
-			"foreman_computeprofile":                dataSourceForemanComputeProfile(),
-			"foreman_computeresource":               dataSourceForemanComputeResource(),
-			"foreman_image":                         dataSourceForemanImage(),
-			"foreman_parameter":                     dataSourceForemanParameter(),
-			"foreman_global_parameter":              dataSourceForemanCommonParameter(),
-			"foreman_defaulttemplate":               dataSourceForemanDefaultTemplate(),
-			"foreman_httpproxy":                     dataSourceForemanHTTPProxy(),
-			"foreman_katello_content_credential":    dataSourceForemanKatelloContentCredential(),
-			"foreman_katello_lifecycle_environment": dataSourceForemanKatelloLifecycleEnvironment(),
-			"foreman_katello_product":               dataSourceForemanKatelloProduct(),
-			"foreman_katello_repository":            dataSourceForemanKatelloRepository(),
-			"foreman_katello_content_view":          dataSourceForemanKatelloContentView(),
-			"foreman_katello_sync_plan":             dataSourceForemanKatelloSyncPlan(),
-			"foreman_user":                          dataSourceForemanUser(),
-			"foreman_usergroup":                     dataSourceForemanUsergroup(),
-			"foreman_setting":                       dataSourceForemanSetting(),
-			"foreman_jobtemplate":                   dataSourceForemanJobTemplate(),
-			"foreman_templateinput":                 dataSourceForemanTemplateInput(),
+			"foreman_architecture":                   dataSourceForemanArchitecture(),
+			"foreman_domain":                         dataSourceForemanDomain(),
+			"foreman_environment":                    dataSourceForemanEnvironment(),
+			"foreman_host":                           dataSourceForemanHost(),
+			"foreman_host_facts":                     dataSourceForemanHostFacts(),
+			"foreman_host_status":                    dataSourceForemanHostStatus(),
+			"foreman_host_template":                  dataSourceForemanHostTemplate(),
+			"foreman_hostgroup":                      dataSourceForemanHostgroup(),
+			"foreman_media":                          dataSourceForemanMedia(),
+			"foreman_model":                          dataSourceForemanModel(),
+			"foreman_operatingsystem":                dataSourceForemanOperatingSystem(),
+			"foreman_partitiontable":                 dataSourceForemanPartitionTable(),
+			"foreman_provisioningtemplate":           dataSourceForemanProvisioningTemplate(),
+			"foreman_puppetclass":                    dataSourceForemanPuppetClass(),
+			"foreman_registration_command":           dataSourceForemanRegistrationCommand(),
+			"foreman_smartclassparameter":            dataSourceForemanSmartClassParameter(),
+			"foreman_smartproxy":                     dataSourceForemanSmartProxy(),
+			"foreman_subnet":                         dataSourceForemanSubnet(),
+			"foreman_templatekind":                   dataSourceForemanTemplateKind(),
+			"foreman_computeprofile":                 dataSourceForemanComputeProfile(),
+			"foreman_computeresource":                dataSourceForemanComputeResource(),
+			"foreman_image":                          dataSourceForemanImage(),
+			"foreman_parameter":                      dataSourceForemanParameter(),
+			"foreman_global_parameter":               dataSourceForemanCommonParameter(),
+			"foreman_defaulttemplate":                dataSourceForemanDefaultTemplate(),
+			"foreman_httpproxy":                      dataSourceForemanHTTPProxy(),
+			"foreman_katello_content_credential":     dataSourceForemanKatelloContentCredential(),
+			"foreman_katello_lifecycle_environment":  dataSourceForemanKatelloLifecycleEnvironment(),
+			"foreman_katello_product":                dataSourceForemanKatelloProduct(),
+			"foreman_katello_repository":             dataSourceForemanKatelloRepository(),
+			"foreman_katello_content_view":           dataSourceForemanKatelloContentView(),
+			"foreman_katello_sync_plan":              dataSourceForemanKatelloSyncPlan(),
+			"foreman_user":                           dataSourceForemanUser(),
+			"foreman_usergroup":                      dataSourceForemanUsergroup(),
+			"foreman_setting":                        dataSourceForemanSetting(),
+			"foreman_jobtemplate":                    dataSourceForemanJobTemplate(),
+			"foreman_templateinput":                  dataSourceForemanTemplateInput(),
+			"foreman_organization":                   dataSourceForemanOrganization(),
+			"foreman_location":                       dataSourceForemanLocation(),
+			"foreman_role":                           dataSourceForemanRole(),
+			"foreman_permission":                     dataSourceForemanPermission(),
+			"foreman_auth_source_ldap":               dataSourceForemanAuthSourceLdap(),
+			"foreman_realm":                          dataSourceForemanRealm(),
+			"foreman_architectures":                  dataSourceForemanArchitectures(),
+			"foreman_domains":                        dataSourceForemanDomains(),
+			"foreman_environments":                   dataSourceForemanEnvironments(),
+			"foreman_hosts":                          dataSourceForemanHosts(),
+			"foreman_hostgroups":                     dataSourceForemanHostgroups(),
+			"foreman_medias":                         dataSourceForemanMedias(),
+			"foreman_models":                         dataSourceForemanModels(),
+			"foreman_operatingsystems":               dataSourceForemanOperatingSystems(),
+			"foreman_partitiontables":                dataSourceForemanPartitionTables(),
+			"foreman_provisioningtemplates":          dataSourceForemanProvisioningTemplates(),
+			"foreman_puppetclasses":                  dataSourceForemanPuppetClasses(),
+			"foreman_smartproxies":                   dataSourceForemanSmartProxies(),
+			"foreman_subnets":                        dataSourceForemanSubnets(),
+			"foreman_templatekinds":                  dataSourceForemanTemplateKinds(),
+			"foreman_computeprofiles":                dataSourceForemanComputeProfiles(),
+			"foreman_computeresources":               dataSourceForemanComputeResources(),
+			"foreman_global_parameters":              dataSourceForemanCommonParameters(),
+			"foreman_httpproxies":                    dataSourceForemanHTTPProxies(),
+			"foreman_jobtemplates":                   dataSourceForemanJobTemplates(),
+			"foreman_users":                          dataSourceForemanUsers(),
+			"foreman_usergroups":                     dataSourceForemanUsergroups(),
+			"foreman_katello_content_credentials":    dataSourceForemanKatelloContentCredentials(),
+			"foreman_katello_lifecycle_environments": dataSourceForemanKatelloLifecycleEnvironments(),
+			"foreman_katello_products":               dataSourceForemanKatelloProducts(),
+			"foreman_katello_repositories":           dataSourceForemanKatelloRepositories(),
+			"foreman_katello_content_views":          dataSourceForemanKatelloContentViews(),
+			"foreman_katello_sync_plans":             dataSourceForemanKatelloSyncPlans(),
+			"foreman_organizations":                  dataSourceForemanOrganizations(),
+			"foreman_locations":                      dataSourceForemanLocations(),
+			"foreman_roles":                          dataSourceForemanRoles(),
+			"foreman_permissions":                    dataSourceForemanPermissions(),
+			"foreman_auth_source_ldaps":              dataSourceForemanAuthSourceLdaps(),
+			"foreman_realms":                         dataSourceForemanRealms(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
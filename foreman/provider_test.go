@@ -0,0 +1,186 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Ensures the schemas of all resources and data sources are valid
+func TestProvider_InternalValidate(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("Provider schema is invalid. Error: [%s]", err.Error())
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Lifecycle Tests
+// ----------------------------------------------------------------------------
+
+// providerFactories returns the provider factories of the Terraform test
+// harness, which runs the provider in-process
+func providerFactories() map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"foreman": func() (*schema.Provider, error) {
+			return Provider(), nil
+		},
+	}
+}
+
+// lifecycleTest describes a run of a resource through Terraform against the
+// fake Foreman server
+type lifecycleTest struct {
+	// Address of the resource in the configurations, ie:
+	// "foreman_domain.test"
+	Address string
+	// Collection of the fake server storing the object, ie: "domains"
+	Collection string
+	// Configurations of the resource before and after the update.  The
+	// provider block of the fake server is prepended.
+	Create string
+	Update string
+	// Checks run after the create and the update
+	CreateChecks []resource.TestCheckFunc
+	UpdateChecks []resource.TestCheckFunc
+	// Attributes changed on the fake server outside of Terraform.  The plan
+	// after the change must revert them.
+	Drift map[string]interface{}
+	// Attributes not compared between the imported and the applied state,
+	// ie: write-only passwords
+	ImportStateVerifyIgnore []string
+}
+
+// testResourceLifecycle runs the resource through Terraform against the fake
+// Foreman server: create, a replan without changes, update, import, drift
+// detection and destroy
+func testResourceLifecycle(t *testing.T, server *foremantest.Server, lt lifecycleTest) {
+	providerConfig := server.ProviderConfig()
+
+	// the ID of the object, as read from the state after the update
+	var id int
+	storeId := func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[lt.Address]
+		if !ok {
+			return fmt.Errorf("Resource [%s] not found in the state", lt.Address)
+		}
+		var err error
+		id, err = strconv.Atoi(rs.Primary.ID)
+		return err
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories(),
+		CheckDestroy: func(s *terraform.State) error {
+			if _, ok := server.Get(lt.Collection, id); ok {
+				return fmt.Errorf("Object [%d] of [%s] was not deleted", id, lt.Collection)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + lt.Create,
+				Check:  resource.ComposeAggregateTestCheckFunc(lt.CreateChecks...),
+			},
+			{
+				Config:   providerConfig + lt.Create,
+				PlanOnly: true,
+			},
+			{
+				Config: providerConfig + lt.Update,
+				Check:  resource.ComposeAggregateTestCheckFunc(append(lt.UpdateChecks, storeId)...),
+			},
+			{
+				Config:                  providerConfig + lt.Update,
+				ResourceName:            lt.Address,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: lt.ImportStateVerifyIgnore,
+			},
+			{
+				PreConfig: func() {
+					if !server.Update(lt.Collection, id, lt.Drift) {
+						t.Fatalf("Object [%d] of [%s] not found", id, lt.Collection)
+					}
+				},
+				Config:             providerConfig + lt.Update,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: providerConfig + lt.Update,
+				Check:  resource.ComposeAggregateTestCheckFunc(lt.UpdateChecks...),
+			},
+		},
+	})
+}
+
+// testResourceClearTaxonomies runs a resource limited to a location and an
+// organization through Terraform against the fake Foreman server, then
+// empties both sets.  config is the configuration of the resource with a %s
+// verb for the taxonomy attributes, it can refer to "foreman_location.test"
+// and "foreman_organization.test".
+func testResourceClearTaxonomies(t *testing.T, server *foremantest.Server, address string, collection string, config string) {
+	taxonomies := func(locationIds string, organizationIds string) string {
+		return server.ProviderConfig() + `
+resource "foreman_location" "test" {
+  name = "Berlin"
+}
+
+resource "foreman_organization" "test" {
+  name = "ACME"
+}
+` + fmt.Sprintf(config, fmt.Sprintf("location_ids = %s\n  organization_ids = %s", locationIds, organizationIds))
+	}
+	checkServer := func(expected int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			id, _ := strconv.Atoi(s.RootModule().Resources[address].Primary.ID)
+			obj, ok := server.Get(collection, id)
+			if !ok {
+				return fmt.Errorf("Object [%d] of [%s] not found", id, collection)
+			}
+			locationIds, _ := obj["location_ids"].([]interface{})
+			organizationIds, _ := obj["organization_ids"].([]interface{})
+			if len(locationIds) != expected || len(organizationIds) != expected {
+				return fmt.Errorf(
+					"Expected [%d] locations and organizations on the server, got [%v] and [%v]",
+					expected,
+					obj["location_ids"],
+					obj["organization_ids"],
+				)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: taxonomies("[foreman_location.test.id]", "[foreman_organization.test.id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "location_ids.#", "1"),
+					resource.TestCheckResourceAttr(address, "organization_ids.#", "1"),
+					checkServer(1),
+				),
+			},
+			{
+				Config: taxonomies("[]", "[]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "location_ids.#", "0"),
+					resource.TestCheckResourceAttr(address, "organization_ids.#", "0"),
+					checkServer(0),
+				),
+			},
+			{
+				Config:   taxonomies("[]", "[]"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
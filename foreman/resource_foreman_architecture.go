@@ -32,7 +32,9 @@ func resourceForemanArchitecture() *schema.Resource {
 		DeleteContext: resourceForemanArchitectureDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryArchitecture(ctx, &api.ForemanArchitecture{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
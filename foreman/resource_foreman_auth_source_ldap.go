@@ -0,0 +1,358 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanAuthSourceLdap() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanAuthSourceLdapCreate,
+		ReadContext:   resourceForemanAuthSourceLdapRead,
+		UpdateContext: resourceForemanAuthSourceLdapUpdate,
+		DeleteContext: resourceForemanAuthSourceLdapDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryAuthSourceLdap(ctx, &api.ForemanAuthSourceLdap{ForemanObject: api.ForemanObject{Name: name}})
+			}),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s LDAP authentication sources authenticate users against "+
+						"an LDAP server, ie: Active Directory or FreeIPA.  Users "+
+						"can be created on their first login and the members of "+
+						"LDAP groups synced into usergroups.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the auth source. %s \"corp-ad\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"host": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Hostname of the LDAP server. %s \"ldap.example.com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      389,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Port of the LDAP server, usually 389 or 636 with TLS.",
+			},
+
+			"tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the connection to the LDAP server is encrypted with TLS.",
+			},
+
+			"server_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "posix",
+				ValidateFunc: validation.StringInSlice(api.AuthSourceLdapServerTypes, false),
+				Description: fmt.Sprintf(
+					"Type of the LDAP server, one of: %q.",
+					api.AuthSourceLdapServerTypes,
+				),
+			},
+
+			"base_dn": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Base DN of the users. %s \"ou=people,dc=example,dc=com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"groups_base": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Base DN of the groups, used to sync external usergroups. "+
+						"%s \"ou=groups,dc=example,dc=com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"ldap_filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP filter limiting the users which can log in. "+
+						"%s \"(memberOf=cn=foreman,ou=groups,dc=example,dc=com)\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"use_netgroups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the groups are netgroups instead of POSIX groups.",
+			},
+
+			"account": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Account used to bind to the LDAP server.  \"$login\" binds "+
+						"with the credentials of the user logging in. "+
+						"%s \"cn=foreman,ou=services,dc=example,dc=com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"account_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "Password of the bind account.  Foreman does not " +
+					"return the password, changes made outside of Terraform " +
+					"are not detected.",
+			},
+
+			"attr_login": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP attribute of the login, required for on-the-fly "+
+						"registration. %s \"sAMAccountName\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"attr_firstname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute of the first name, required for on-the-fly registration.",
+			},
+
+			"attr_lastname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute of the last name, required for on-the-fly registration.",
+			},
+
+			"attr_mail": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute of the email address, required for on-the-fly registration.",
+			},
+
+			"attr_photo": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute of the user photo.",
+			},
+
+			"onthefly_register": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Whether users are created in Foreman on their first " +
+					"login.  Requires the `attr_*` attributes except `attr_photo`.",
+			},
+
+			"usergroup_sync": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether the membership of users in external " +
+					"usergroups is synced on login.",
+			},
+
+			"location_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Computed:    true,
+				Description: "IDs of the locations of the auth source.",
+			},
+
+			"organization_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Computed:    true,
+				Description: "IDs of the organizations of the auth source.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanAuthSourceLdap constructs a ForemanAuthSourceLdap struct from a
+// resource data reference.  The struct's members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanAuthSourceLdap(d *schema.ResourceData) *api.ForemanAuthSourceLdap {
+	log.Tracef("resource_foreman_auth_source_ldap.go#buildForemanAuthSourceLdap")
+
+	a := api.ForemanAuthSourceLdap{}
+
+	obj := buildForemanObject(d)
+	a.ForemanObject = *obj
+
+	a.Host = d.Get("host").(string)
+	a.Port = d.Get("port").(int)
+	a.Tls = d.Get("tls").(bool)
+	a.ServerType = d.Get("server_type").(string)
+	a.BaseDn = d.Get("base_dn").(string)
+	a.GroupsBase = d.Get("groups_base").(string)
+	a.LdapFilter = d.Get("ldap_filter").(string)
+	a.UseNetgroups = d.Get("use_netgroups").(bool)
+	a.Account = d.Get("account").(string)
+	a.AccountPassword = d.Get("account_password").(string)
+	a.AttrLogin = d.Get("attr_login").(string)
+	a.AttrFirstname = d.Get("attr_firstname").(string)
+	a.AttrLastname = d.Get("attr_lastname").(string)
+	a.AttrMail = d.Get("attr_mail").(string)
+	a.AttrPhoto = d.Get("attr_photo").(string)
+	a.OntheflyRegister = d.Get("onthefly_register").(bool)
+	a.UsergroupSync = d.Get("usergroup_sync").(bool)
+
+	a.LocationIds = buildTaxonomyIds(d, "location_ids")
+	a.OrganizationIds = buildTaxonomyIds(d, "organization_ids")
+
+	return &a
+}
+
+// setResourceDataFromForemanAuthSourceLdap sets a ResourceData's attributes
+// from the attributes of the supplied ForemanAuthSourceLdap struct
+func setResourceDataFromForemanAuthSourceLdap(d *schema.ResourceData, fa *api.ForemanAuthSourceLdap) {
+	log.Tracef("resource_foreman_auth_source_ldap.go#setResourceDataFromForemanAuthSourceLdap")
+
+	d.SetId(strconv.Itoa(fa.Id))
+	d.Set("name", fa.Name)
+	d.Set("host", fa.Host)
+	d.Set("port", fa.Port)
+	d.Set("tls", fa.Tls)
+	d.Set("server_type", fa.ServerType)
+	d.Set("base_dn", fa.BaseDn)
+	d.Set("groups_base", fa.GroupsBase)
+	d.Set("ldap_filter", fa.LdapFilter)
+	d.Set("use_netgroups", fa.UseNetgroups)
+	d.Set("account", fa.Account)
+	// NOTE(ALL): the password is not returned by Foreman, the configured one
+	//   is kept
+	if fa.AccountPassword != "" {
+		d.Set("account_password", fa.AccountPassword)
+	}
+	d.Set("attr_login", fa.AttrLogin)
+	d.Set("attr_firstname", fa.AttrFirstname)
+	d.Set("attr_lastname", fa.AttrLastname)
+	d.Set("attr_mail", fa.AttrMail)
+	d.Set("attr_photo", fa.AttrPhoto)
+	d.Set("onthefly_register", fa.OntheflyRegister)
+	d.Set("usergroup_sync", fa.UsergroupSync)
+	d.Set("location_ids", fa.LocationIds)
+	d.Set("organization_ids", fa.OrganizationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanAuthSourceLdapCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Create")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLdap(d)
+
+	log.Debugf("ForemanAuthSourceLdap: [%+v]", a)
+
+	createdAuthSourceLdap, createErr := client.CreateAuthSourceLdap(ctx, a)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanAuthSourceLdap: [%+v]", createdAuthSourceLdap)
+
+	setResourceDataFromForemanAuthSourceLdap(d, createdAuthSourceLdap)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLdapRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Read")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLdap(d)
+
+	log.Debugf("ForemanAuthSourceLdap: [%+v]", a)
+
+	readAuthSourceLdap, readErr := client.ReadAuthSourceLdap(ctx, a.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanAuthSourceLdap: [%+v]", readAuthSourceLdap)
+
+	setResourceDataFromForemanAuthSourceLdap(d, readAuthSourceLdap)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLdapUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Update")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLdap(d)
+
+	log.Debugf("ForemanAuthSourceLdap: [%+v]", a)
+
+	updatedAuthSourceLdap, updateErr := client.UpdateAuthSourceLdap(ctx, a)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanAuthSourceLdap: [%+v]", updatedAuthSourceLdap)
+
+	setResourceDataFromForemanAuthSourceLdap(d, updatedAuthSourceLdap)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLdapDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Delete")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLdap(d)
+
+	log.Debugf("ForemanAuthSourceLdap: [%+v]", a)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteAuthSourceLdap(ctx, a.Id)))
+}
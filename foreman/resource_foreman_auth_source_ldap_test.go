@@ -0,0 +1,107 @@
+package foreman
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Ensures the groups of an LDAP auth source are mapped to a usergroup and
+// replaced when the configured external usergroups change
+func TestResourceForemanAuthSourceLdap_ExternalUsergroups(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	ar := resourceForemanAuthSourceLdap()
+	ldap := schema.TestResourceDataRaw(t, ar.Schema, map[string]interface{}{
+		"name":              "corp-ad",
+		"host":              "ad.example.com",
+		"port":              636,
+		"tls":               true,
+		"server_type":       "active_directory",
+		"account":           "CN=svc-foreman,OU=Services,DC=example,DC=com",
+		"account_password":  "secret",
+		"attr_login":        "sAMAccountName",
+		"onthefly_register": true,
+	})
+	if diags := ar.CreateContext(ctx, ldap, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	ldapId, _ := strconv.Atoi(ldap.Id())
+	if ldap.Get("usergroup_sync") != true || ldap.Get("account_password") != "secret" {
+		t.Fatalf("Create did not return the auth source, got [%v]", ldap.State().Attributes)
+	}
+
+	ur := resourceForemanUsergroup()
+	usergroup := schema.TestResourceDataRaw(t, ur.Schema, map[string]interface{}{
+		"name": "web-operators",
+		"external_usergroups": []interface{}{
+			map[string]interface{}{"name": "web-admins", "auth_source_id": ldapId},
+		},
+	})
+	if diags := ur.CreateContext(ctx, usergroup, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	groups := usergroup.Get("external_usergroups").(*schema.Set).List()
+	if len(groups) != 1 || groups[0].(map[string]interface{})["name"] != "web-admins" ||
+		groups[0].(map[string]interface{})["auth_source_id"] != ldapId {
+		t.Fatalf("Create did not map the external usergroup, got [%v]", usergroup.State().Attributes)
+	}
+
+	updated := schema.TestResourceDataRaw(t, ur.Schema, map[string]interface{}{
+		"name": "web-operators",
+		"external_usergroups": []interface{}{
+			map[string]interface{}{"name": "web-admins", "auth_source_id": ldapId},
+			map[string]interface{}{"name": "db-admins", "auth_source_id": ldapId},
+		},
+	})
+	updated.SetId(usergroup.Id())
+	if diags := ur.UpdateContext(ctx, updated, client); diags.HasError() {
+		t.Fatalf("Update returned error [%s]", diags[0].Summary)
+	}
+	if groups := server.List("external_usergroups"); len(groups) != 2 {
+		t.Fatalf("Update did not add the external usergroup, got [%v]", groups)
+	}
+
+	removed := schema.TestResourceDataRaw(t, ur.Schema, map[string]interface{}{
+		"name": "web-operators",
+		"external_usergroups": []interface{}{
+			map[string]interface{}{"name": "db-admins", "auth_source_id": ldapId},
+		},
+	})
+	removed.SetId(usergroup.Id())
+	if diags := ur.UpdateContext(ctx, removed, client); diags.HasError() {
+		t.Fatalf("Update returned error [%s]", diags[0].Summary)
+	}
+	groups = removed.Get("external_usergroups").(*schema.Set).List()
+	if len(groups) != 1 || groups[0].(map[string]interface{})["name"] != "db-admins" {
+		t.Fatalf("Update did not remove the external usergroup, got [%v]", removed.State().Attributes)
+	}
+	if groups := server.List("external_usergroups"); len(groups) != 1 {
+		t.Fatalf("Update did not delete the external usergroup, got [%v]", groups)
+	}
+}
+
+// Ensures emptying the locations and organizations of an LDAP auth source
+// removes them in Foreman
+func TestResourceForemanAuthSourceLdap_ClearTaxonomies(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	testResourceClearTaxonomies(t, server, "foreman_auth_source_ldap.test", "auth_source_ldaps", `
+resource "foreman_auth_source_ldap" "test" {
+  name = "corp-ad"
+  host = "ad.example.com"
+  %s
+}
+`)
+}
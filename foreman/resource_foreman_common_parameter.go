@@ -22,7 +22,9 @@ func resourceForemanCommonParameter() *schema.Resource {
 		DeleteContext: resourceForemanCommonParameterDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryCommonParameter(ctx, &api.ForemanCommonParameter{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -22,7 +22,9 @@ func resourceForemanComputeProfile() *schema.Resource {
 		DeleteContext: resourceForemanComputeprofileDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryComputeProfile(ctx, &api.ForemanComputeProfile{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -22,7 +22,9 @@ func resourceForemanComputeResource() *schema.Resource {
 		DeleteContext: resourceForemanComputeResourceDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryComputeResource(ctx, &api.ForemanComputeResource{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -0,0 +1,170 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceForemanConfigGroup() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanConfigGroupCreate,
+		ReadContext:   resourceForemanConfigGroupRead,
+		UpdateContext: resourceForemanConfigGroupUpdate,
+		DeleteContext: resourceForemanConfigGroupDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryConfigGroup(ctx, &api.ForemanConfigGroup{ForemanObject: api.ForemanObject{Name: name}})
+			}),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Config groups are named sets of puppet classes, which "+
+						"can be assigned to hosts and hostgroups with `config_group_ids`.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the config group. %s \"webserver\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"puppetclass_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Description: "IDs of the puppet classes of the config group.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanConfigGroup constructs a ForemanConfigGroup struct from a
+// resource data reference.  The struct's members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanConfigGroup(d *schema.ResourceData) *api.ForemanConfigGroup {
+	log.Tracef("resource_foreman_config_group.go#buildForemanConfigGroup")
+
+	g := api.ForemanConfigGroup{}
+
+	obj := buildForemanObject(d)
+	g.ForemanObject = *obj
+
+	// NOTE(ALL): an empty list is sent to remove all puppet classes
+	attrSet := d.Get("puppetclass_ids").(*schema.Set)
+	g.PuppetClassIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+
+	return &g
+}
+
+// setResourceDataFromForemanConfigGroup sets a ResourceData's attributes from
+// the attributes of the supplied ForemanConfigGroup struct
+func setResourceDataFromForemanConfigGroup(d *schema.ResourceData, fg *api.ForemanConfigGroup) {
+	log.Tracef("resource_foreman_config_group.go#setResourceDataFromForemanConfigGroup")
+
+	d.SetId(strconv.Itoa(fg.Id))
+	d.Set("name", fg.Name)
+	d.Set("puppetclass_ids", fg.PuppetClassIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanConfigGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_config_group.go#Create")
+
+	client := meta.(*api.Client)
+	g := buildForemanConfigGroup(d)
+
+	log.Debugf("ForemanConfigGroup: [%+v]", g)
+
+	createdConfigGroup, createErr := client.CreateConfigGroup(ctx, g)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanConfigGroup: [%+v]", createdConfigGroup)
+
+	setResourceDataFromForemanConfigGroup(d, createdConfigGroup)
+
+	return nil
+}
+
+func resourceForemanConfigGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_config_group.go#Read")
+
+	client := meta.(*api.Client)
+	g := buildForemanConfigGroup(d)
+
+	log.Debugf("ForemanConfigGroup: [%+v]", g)
+
+	readConfigGroup, readErr := client.ReadConfigGroup(ctx, g.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanConfigGroup: [%+v]", readConfigGroup)
+
+	setResourceDataFromForemanConfigGroup(d, readConfigGroup)
+
+	return nil
+}
+
+func resourceForemanConfigGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_config_group.go#Update")
+
+	client := meta.(*api.Client)
+	g := buildForemanConfigGroup(d)
+
+	log.Debugf("ForemanConfigGroup: [%+v]", g)
+
+	updatedConfigGroup, updateErr := client.UpdateConfigGroup(ctx, g)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanConfigGroup: [%+v]", updatedConfigGroup)
+
+	setResourceDataFromForemanConfigGroup(d, updatedConfigGroup)
+
+	return nil
+}
+
+func resourceForemanConfigGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_config_group.go#Delete")
+
+	client := meta.(*api.Client)
+	g := buildForemanConfigGroup(d)
+
+	log.Debugf("ForemanConfigGroup: [%+v]", g)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteConfigGroup(ctx, g.Id)))
+}
@@ -0,0 +1,95 @@
+package foreman
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Ensures a config group is created with its puppet classes and a smart class
+// parameter of one of the classes is overridden and reset on destroy
+func TestResourceForemanConfigGroup_SmartClassParameter(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	apacheId := server.Seed("puppetclasses", map[string]interface{}{"name": "apache"})
+	sslId := server.Seed("puppetclasses", map[string]interface{}{"name": "apache::mod::ssl"})
+	paramId := server.Seed("smart_class_parameters", map[string]interface{}{
+		"parameter":            "default_mods",
+		"puppetclass_id":       apacheId,
+		"parameter_type":       "string",
+		"override":             false,
+		"override_value_order": "fqdn\nhostgroup\nos\ndomain",
+	})
+
+	r := resourceForemanConfigGroup()
+	group := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":            "webserver",
+		"puppetclass_ids": []interface{}{apacheId, sslId},
+	})
+	if diags := r.CreateContext(ctx, group, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	if ids := group.Get("puppetclass_ids").(*schema.Set); ids.Len() != 2 || !ids.Contains(sslId) {
+		t.Fatalf("Create did not return the puppet classes, got [%v]", group.State().Attributes)
+	}
+
+	group = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "webserver",
+	})
+	groupId, _ := server.List("config_groups")[0]["id"].(float64)
+	group.SetId(strconv.Itoa(int(groupId)))
+	if diags := r.UpdateContext(ctx, group, client); diags.HasError() {
+		t.Fatalf("Update returned error [%s]", diags[0].Summary)
+	}
+	if group.Get("puppetclass_ids").(*schema.Set).Len() != 0 {
+		t.Fatalf("Update did not remove the puppet classes, got [%v]", group.State().Attributes)
+	}
+
+	pr := resourceForemanSmartClassParameter()
+	param := schema.TestResourceDataRaw(t, pr.Schema, map[string]interface{}{
+		"parameter":        "default_mods",
+		"puppetclass_id":   apacheId,
+		"parameter_type":   "array",
+		"default_value":    `["ssl","rewrite"]`,
+		"merge_overrides":  true,
+		"avoid_duplicates": true,
+	})
+	if diags := pr.CreateContext(ctx, param, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	if param.Id() != strconv.Itoa(paramId) || param.Get("override") != true ||
+		param.Get("override_value_order") != "fqdn\nhostgroup\nos\ndomain" {
+		t.Fatalf("Create did not update the parameter, got [%v]", param.State().Attributes)
+	}
+	if obj, _ := server.Get("smart_class_parameters", paramId); obj["parameter_type"] != "array" || obj["avoid_duplicates"] != true {
+		t.Fatalf("Create did not send the parameter, got [%v]", obj)
+	}
+
+	if diags := pr.DeleteContext(ctx, param, client); diags.HasError() {
+		t.Fatalf("Delete returned error [%s]", diags[0].Summary)
+	}
+	if obj, _ := server.Get("smart_class_parameters", paramId); obj["override"] != false || obj["merge_overrides"] != false {
+		t.Fatalf("Delete did not disable the override, got [%v]", obj)
+	}
+
+	// merge options not supported by the type are rejected at plan time
+	config := map[string]interface{}{
+		"parameter":       "default_mods",
+		"puppetclass_id":  apacheId,
+		"merge_overrides": true,
+	}
+	if _, diffErr := pr.Diff(ctx, nil, terraform.NewResourceConfigRaw(config), client); diffErr == nil {
+		t.Fatalf("Diff accepted merge_overrides for a string parameter")
+	}
+}
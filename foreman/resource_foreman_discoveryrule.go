@@ -24,7 +24,9 @@ func resourceForemanDiscoveryRule() *schema.Resource {
 		DeleteContext: resourceForemanDiscoveryRuleDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryDiscoveryRule(ctx, &api.ForemanDiscoveryRule{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -216,7 +218,7 @@ func buildForemanDiscoveryRuleResponse(d *schema.ResourceData) *api.ForemanDisco
 		discovery_rule_response.Enabled = attr.(bool)
 	}
 
-	if attr, ok = d.GetOk("locations"); ok {
+	if attr, ok = d.GetOk("location_ids"); ok {
 		attrSet := attr.(*schema.Set)
 		discovery_rule_response.Locations = make([]api.EntityResponse, attrSet.Len())
 		for i, v := range attrSet.List() {
@@ -224,7 +226,7 @@ func buildForemanDiscoveryRuleResponse(d *schema.ResourceData) *api.ForemanDisco
 		}
 	}
 
-	if attr, ok = d.GetOk("organizations"); ok {
+	if attr, ok = d.GetOk("organization_ids"); ok {
 		attrSet := attr.(*schema.Set)
 		discovery_rule_response.Organizations = make([]api.EntityResponse, attrSet.Len())
 		for i, v := range attrSet.List() {
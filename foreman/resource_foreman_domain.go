@@ -22,7 +22,9 @@ func resourceForemanDomain() *schema.Resource {
 		DeleteContext: resourceForemanDomainDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryDomain(ctx, &api.ForemanDomain{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
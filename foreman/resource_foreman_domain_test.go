@@ -10,7 +10,9 @@ import (
 
 	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -245,3 +247,38 @@ func ResourceForemanDomainMockResponseTestCases(t *testing.T) []TestCaseMockResp
 	}
 
 }
+
+// ----------------------------------------------------------------------------
+// Lifecycle
+// ----------------------------------------------------------------------------
+
+// Ensures the domain is created, updated, imported and deleted through
+// Terraform and changes made outside of Terraform are detected
+func TestResourceForemanDomain_Lifecycle(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	testResourceLifecycle(t, server, lifecycleTest{
+		Address:    "foreman_domain.test",
+		Collection: "domains",
+		Create: `
+resource "foreman_domain" "test" {
+  name     = "dev.example.com"
+  fullname = "Development"
+}
+`,
+		Update: `
+resource "foreman_domain" "test" {
+  name     = "dev.example.com"
+  fullname = "Development domain"
+}
+`,
+		CreateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_domain.test", "fullname", "Development"),
+		},
+		UpdateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_domain.test", "fullname", "Development domain"),
+		},
+		Drift: map[string]interface{}{"fullname": "Changed by hand"},
+	})
+}
@@ -22,7 +22,9 @@ func resourceForemanEnvironment() *schema.Resource {
 		DeleteContext: resourceForemanEnvironmentDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryEnvironment(ctx, &api.ForemanEnvironment{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -0,0 +1,249 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanFilter() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanFilterCreate,
+		ReadContext:   resourceForemanFilterRead,
+		UpdateContext: resourceForemanFilterUpdate,
+		DeleteContext: resourceForemanFilterDelete,
+
+		// NOTE(ALL): filters have no name, they are imported by ID
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Filters grant the permissions of a role on a single "+
+						"resource type, optionally limited to the objects "+
+						"matching a search.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"role_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the role the filter belongs to.",
+			},
+
+			"permission_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Required: true,
+				MinItems: 1,
+				Description: "IDs of the permissions granted by the filter.  All " +
+					"permissions have to be of the same resource type, use the " +
+					"`foreman_permission` data source to look them up by name.",
+			},
+
+			"resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"Resource type of the permissions. %s \"Host\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"search": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Scoped search limiting the objects the permissions apply to. "+
+						"Without a search, the permissions apply to all objects "+
+						"of the resource type. %s \"hostgroup_title ~ web/*\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"unlimited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the filter applies to all objects of the resource type.",
+			},
+
+			"override": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether the filter uses its own `location_ids` and " +
+					"`organization_ids` instead of the ones of the role.",
+			},
+
+			"location_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Optional: true,
+				Computed: true,
+				Description: "IDs of the locations the filter is limited to.  Only " +
+					"applied if `override` is set, otherwise the locations of " +
+					"the role are inherited.",
+			},
+
+			"organization_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Optional: true,
+				Computed: true,
+				Description: "IDs of the organizations the filter is limited to.  " +
+					"Only applied if `override` is set, otherwise the " +
+					"organizations of the role are inherited.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanFilter constructs a ForemanFilter struct from a resource data
+// reference.  The struct's members are populated from the data populated in
+// the resource data.  Missing members will be left to the zero value for that
+// member's type.
+func buildForemanFilter(d *schema.ResourceData) *api.ForemanFilter {
+	log.Tracef("resource_foreman_filter.go#buildForemanFilter")
+
+	filter := api.ForemanFilter{}
+
+	obj := buildForemanObject(d)
+	filter.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("role_id"); ok {
+		filter.RoleId = attr.(int)
+	}
+	if attr, ok = d.GetOk("permission_ids"); ok {
+		attrSet := attr.(*schema.Set)
+		filter.PermissionIds = conv.InterfaceSliceToIntSlice(attrSet.List())
+	}
+	if attr, ok = d.GetOk("resource_type"); ok {
+		filter.ResourceType = attr.(string)
+	}
+	if attr, ok = d.GetOk("search"); ok {
+		filter.Search = attr.(string)
+	}
+	filter.Unlimited = d.Get("unlimited").(bool)
+	filter.Override = d.Get("override").(bool)
+	filter.LocationIds = buildTaxonomyIds(d, "location_ids")
+	filter.OrganizationIds = buildTaxonomyIds(d, "organization_ids")
+
+	return &filter
+}
+
+// setResourceDataFromForemanFilter sets a ResourceData's attributes from the
+// attributes of the supplied ForemanFilter struct
+func setResourceDataFromForemanFilter(d *schema.ResourceData, ff *api.ForemanFilter) {
+	log.Tracef("resource_foreman_filter.go#setResourceDataFromForemanFilter")
+
+	d.SetId(strconv.Itoa(ff.Id))
+	d.Set("role_id", ff.RoleId)
+	d.Set("permission_ids", ff.PermissionIds)
+	d.Set("resource_type", ff.ResourceType)
+	d.Set("search", ff.Search)
+	d.Set("unlimited", ff.Unlimited)
+	d.Set("override", ff.Override)
+	d.Set("location_ids", ff.LocationIds)
+	d.Set("organization_ids", ff.OrganizationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanFilterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Create")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	createdFilter, createErr := client.CreateFilter(ctx, f)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanFilter: [%+v]", createdFilter)
+
+	setResourceDataFromForemanFilter(d, createdFilter)
+
+	return nil
+}
+
+func resourceForemanFilterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Read")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	readFilter, readErr := client.ReadFilter(ctx, f.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanFilter: [%+v]", readFilter)
+
+	setResourceDataFromForemanFilter(d, readFilter)
+
+	return nil
+}
+
+func resourceForemanFilterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Update")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	updatedFilter, updateErr := client.UpdateFilter(ctx, f)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanFilter: [%+v]", updatedFilter)
+
+	setResourceDataFromForemanFilter(d, updatedFilter)
+
+	return nil
+}
+
+func resourceForemanFilterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Delete")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	// NOTE(ALL): the filters of a role are deleted with it, a filter already
+	//   gone is not an error
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteFilter(ctx, f.Id)))
+}
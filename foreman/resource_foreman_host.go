@@ -25,8 +25,44 @@ import (
 
 const (
 	DEFAULT_RETRY_COUNT = 2
+	// Default create and update timeout, which includes the build of the
+	// host if wait_for_build is set
+	DEFAULT_BUILD_TIMEOUT = 60 * time.Minute
+
+	HOST_DESTROY_MODE_DELETE       = "delete"
+	HOST_DESTROY_MODE_DISASSOCIATE = "disassociate"
+	HOST_DESTROY_MODE_UNMANAGE     = "unmanage"
+	HOST_DESTROY_MODE_ABANDON      = "abandon"
 )
 
+// hostDestroyModes are the supported values of destroy_mode
+var hostDestroyModes = []string{
+	HOST_DESTROY_MODE_DELETE,
+	HOST_DESTROY_MODE_DISASSOCIATE,
+	HOST_DESTROY_MODE_UNMANAGE,
+	HOST_DESTROY_MODE_ABANDON,
+}
+
+// hostReplaceAttributes are the attributes whose change replaces the host
+// unless they are listed in rebuild_on_change
+var hostReplaceAttributes = []string{
+	"hostgroup_id",
+	"image_id",
+	"medium_id",
+	"operatingsystem_id",
+}
+
+// hostRebuildAttributes are the attributes which can be listed in
+// rebuild_on_change
+var hostRebuildAttributes = append([]string{"architecture_id", "ptable_id"}, hostReplaceAttributes...)
+
+// hostPowerCommandDelay is the delay between chained power commands
+var hostPowerCommandDelay = 3 * time.Second
+
+// hostBuildPollInterval is the interval the build status of a host is read
+// with while waiting for the build
+var hostBuildPollInterval = 30 * time.Second
+
 func resourceForemanHostV0() *schema.Resource {
 	return &schema.Resource{
 
@@ -304,10 +340,24 @@ func resourceForemanHost() *schema.Resource {
 
 		CustomizeDiff: customdiff.All(
 			resourceForemanHostCustomizeDiffComputeAttributes,
+			resourceForemanHostCustomizeDiffRebuild,
+			resourceForemanHostCustomizeDiffInterfaces,
 		),
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(DEFAULT_BUILD_TIMEOUT),
+			Update: schema.DefaultTimeout(DEFAULT_BUILD_TIMEOUT),
+		},
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByLookup("fqdn", map[string]importLookup{
+				"fqdn": func(ctx context.Context, client *api.Client, fqdn string) (api.QueryResponse, error) {
+					return api.SearchAll[api.ForemanHost](ctx, client, api.HostEndpointPrefix, hostFQDNSearch(fqdn), nil)
+				},
+				"name": func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+					return client.QueryHost(ctx, &api.ForemanHost{ForemanObject: api.ForemanObject{Name: name}})
+				},
+			}),
 		},
 
 		SchemaVersion: 1,
@@ -457,6 +507,82 @@ func resourceForemanHost() *schema.Resource {
 				Description: "Manage power operations, e.g. power on, if host's build flag will be enabled.",
 			},
 
+			"power_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.PowerOn, api.PowerOff}, false),
+				Description: "Power state of the host. Values include: `\"on\"`, `\"off\"`. " +
+					"The host is powered on or off through its BMC or compute resource " +
+					"if the state differs. The state is only read if it is managed.",
+			},
+
+			"rebuild_on_change": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(hostRebuildAttributes, false),
+				},
+				Description: "Attributes whose change rebuilds the host in place instead " +
+					"of replacing it. The build flag is set, the host is PXE booted " +
+					"and power cycled. Values include: `\"architecture_id\"`, " +
+					"`\"hostgroup_id\"`, `\"image_id\"`, `\"medium_id\"`, " +
+					"`\"operatingsystem_id\"`, `\"ptable_id\"`.",
+			},
+
+			"rebuild_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Arbitrary value whose change rebuilds the host like a " +
+					"change of an attribute in `rebuild_on_change`.",
+			},
+
+			"rebuild_config": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Rebuild the configuration of the host, ie: DHCP, DNS and " +
+					"TFTP, before the host is rebuilt.",
+			},
+
+			"wait_for_build": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Wait until the host is built after it was created or its " +
+					"build flag was set. The wait is limited by the `create` and " +
+					"`update` timeouts of the resource, 60 minutes by default. The " +
+					"apply fails if the build fails or the build token expires.",
+			},
+
+			"build_status": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Build status of the host. Values include: `0` (built), " +
+					"`1` (pending), `2` (token expired), `3` (build failed).",
+			},
+
+			"build_status_label": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Build status of the host as text, ie: \"Installed\".",
+			},
+
+			"destroy_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      HOST_DESTROY_MODE_DELETE,
+				ValidateFunc: validation.StringInSlice(hostDestroyModes, false),
+				Description: "How the host is destroyed. `delete` deletes the host and " +
+					"its VM. `disassociate` removes the link to the VM and deletes " +
+					"the host, the VM is kept on the compute resource. `unmanage` " +
+					"keeps the host and marks it as not managed by Foreman. " +
+					"`abandon` only removes the host from the Terraform state. The " +
+					"mode must be applied before the host is destroyed. " +
+					"Defaults to `delete`.",
+			},
+
 			"retry_count": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -539,6 +665,15 @@ func resourceForemanHost() *schema.Resource {
 				Description:  "ID of the subnet the host should be placed in",
 			},
 
+			"realm_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "ID of the realm the host is enrolled in, ie: a " +
+					"`foreman_realm`. Inherited from the hostgroup if omitted.",
+			},
+
 			"ptable_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -550,34 +685,34 @@ func resourceForemanHost() *schema.Resource {
 			"operatingsystem_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
 				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(0),
-				Description:  "ID of the operating system to put on the host.",
+				Description: "ID of the operating system to put on the host. " +
+					"Changing it replaces the host unless it is listed in `rebuild_on_change`.",
 			},
 			"medium_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
 				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(0),
-				Description:  "ID of the medium mounted on the host.",
+				Description: "ID of the medium mounted on the host. " +
+					"Changing it replaces the host unless it is listed in `rebuild_on_change`.",
 			},
 			"hostgroup_id": {
 				Type:         schema.TypeInt,
 				Computed:     true,
 				Optional:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.IntAtLeast(0),
-				Description:  "ID of the hostgroup to assign to the host.",
+				Description: "ID of the hostgroup to assign to the host. " +
+					"Changing it replaces the host unless it is listed in `rebuild_on_change`.",
 			},
 			"image_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ForceNew:     true,
 				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(0),
-				Description:  "ID of an image to be used as base for this host when cloning",
+				Description: "ID of an image to be used as base for this host when cloning. " +
+					"Changing it replaces the host unless it is listed in `rebuild_on_change`.",
 			},
 			"model_id": {
 				Type:         schema.TypeInt,
@@ -604,6 +739,32 @@ func resourceForemanHost() *schema.Resource {
 				},
 				Description: "IDs of the applied config groups.",
 			},
+
+			"content_facet_attributes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem:     resourceForemanHostContentFacet(),
+				Description: "Katello content settings of the host. Hosts without them inherit the settings of their hostgroup. The following subfields are supported:\n" +
+					"\t- `content_view_id` ID of the content view\n" +
+					"\t- `lifecycle_environment_id` ID of the lifecycle environment\n" +
+					"\t- `content_source_id` ID of the smart proxy the host receives its content from\n" +
+					"\t- `kickstart_repository_id` ID of the repository the host is installed from",
+			},
+
+			"subscription_facet_attributes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem:     resourceForemanHostSubscriptionFacet(),
+				Description: "Katello subscription settings of the host. The following subfields are supported:\n" +
+					"\t- `release_version` Release version the content of the host is locked to\n" +
+					"\t- `service_level` Service level of the host\n" +
+					"\t- `purpose_usage` System purpose usage of the host\n" +
+					"\t- `purpose_role` System purpose role of the host",
+			},
 			"compute_resource_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -640,7 +801,7 @@ func resourceForemanHost() *schema.Resource {
 				Optional:    true,
 				Computed:    true,
 				Elem:        resourceForemanInterfacesAttributes(),
-				Description: "Host interface information (ususally set by Foreman or the hypervisor). One 'interfaces_attributes' block for each interface. It's a map[string] representation with the following subfields supported:\n"+
+				Description: "Host interface information (ususally set by Foreman or the hypervisor). One 'interfaces_attributes' block for each interface. Interfaces are matched by `identifier`, by `mac` if no identifier is set and by position if neither is set. Exactly one interface must be `primary` and one must be used to `provision` the host. It's a map[string] representation with the following subfields supported:\n"+
                      "\t- `primary` Whether or not this is the primary interface\n"+
                      "\t- `ip` IP address associated with the interface\n"+
                      "\t- `name` Name of the interface\n"+
@@ -800,6 +961,68 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 	}
 }
 
+// resourceForemanHostContentFacet is a nested resource that represents the
+// Katello content settings of a host
+func resourceForemanHostContentFacet() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"content_view_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the content view of the host.",
+			},
+			"lifecycle_environment_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the lifecycle environment of the host.",
+			},
+			"content_source_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the smart proxy the host receives its content from.",
+			},
+			"kickstart_repository_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the repository the host is installed from.",
+			},
+		},
+	}
+}
+
+// resourceForemanHostSubscriptionFacet is a nested resource that represents
+// the Katello subscription settings of a host
+func resourceForemanHostSubscriptionFacet() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"release_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Release version the content of the host is locked to, ie: \"8.6\".",
+			},
+			"service_level": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Service level of the host, ie: \"Premium\".",
+			},
+			"purpose_usage": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "System purpose usage of the host, ie: \"Production\".",
+			},
+			"purpose_role": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "System purpose role of the host, ie: \"Red Hat Enterprise Linux Server\".",
+			},
+		},
+	}
+}
+
 // -----------------------------------------------------------------------------
 // Conversion Helpers
 // -----------------------------------------------------------------------------
@@ -840,6 +1063,8 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	host.ProvisionMethod = d.Get("provision_method").(string)
 	host.Managed = d.Get("managed").(bool)
 	host.Build = d.Get("set_build_flag").(bool)
+	host.BuildStatus = d.Get("build_status").(int)
+	host.BuildStatusLabel = d.Get("build_status_label").(string)
 	host.Token = d.Get("token").(string)
 
 	ownerId := d.Get("owner_id").(int)
@@ -866,6 +1091,10 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	if subnetId != 0 {
 		host.SubnetId = &subnetId
 	}
+	realmId := d.Get("realm_id").(int)
+	if realmId != 0 {
+		host.RealmId = &realmId
+	}
 	ptableId := d.Get("ptable_id").(int)
 	if ptableId != 0 {
 		host.PtableId = &ptableId
@@ -924,10 +1153,63 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	}
 
 	host.InterfacesAttributes = buildForemanInterfacesAttributes(d)
+	host.ContentFacetAttributes = buildForemanHostContentFacet(d)
+	host.SubscriptionFacetAttributes = buildForemanHostSubscriptionFacet(d)
 
 	return &host
 }
 
+// buildForemanHostContentFacet constructs the content settings of a host from
+// a resource data reference.  Nil is returned if they are not set.
+func buildForemanHostContentFacet(d *schema.ResourceData) *api.ForemanHostContentFacet {
+	facets := d.Get("content_facet_attributes").([]interface{})
+	if len(facets) == 0 || facets[0] == nil {
+		return nil
+	}
+	facet := facets[0].(map[string]interface{})
+
+	return &api.ForemanHostContentFacet{
+		ContentViewId:          facetId(facet, "content_view_id"),
+		LifecycleEnvironmentId: facetId(facet, "lifecycle_environment_id"),
+		ContentSourceId:        facetId(facet, "content_source_id"),
+		KickstartRepositoryId:  facetId(facet, "kickstart_repository_id"),
+	}
+}
+
+// facetId returns a reference to an ID of a facet, nil if it is not set
+func facetId(facet map[string]interface{}, key string) *int {
+	id := facet[key].(int)
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// facetIdValue returns the value of an ID of a facet, 0 if it is not set
+func facetIdValue(id *int) int {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+// buildForemanHostSubscriptionFacet constructs the subscription settings of a
+// host from a resource data reference.  Nil is returned if they are not set.
+func buildForemanHostSubscriptionFacet(d *schema.ResourceData) *api.ForemanHostSubscriptionFacet {
+	facets := d.Get("subscription_facet_attributes").([]interface{})
+	if len(facets) == 0 || facets[0] == nil {
+		return nil
+	}
+	facet := facets[0].(map[string]interface{})
+
+	return &api.ForemanHostSubscriptionFacet{
+		ReleaseVersion: facet["release_version"].(string),
+		ServiceLevel:   facet["service_level"].(string),
+		PurposeUsage:   facet["purpose_usage"].(string),
+		PurposeRole:    facet["purpose_role"].(string),
+	}
+}
+
 // buildForemanInterfacesAttributes constructs an array of
 // ForemanInterfacesAttribute structs from a resource data reference. The
 // struct's members are populated with the data populated in the resource data.
@@ -1107,6 +1389,8 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 	// See issue #115 for "Build" attribute
 	d.Set("managed", fh.Managed)
 	d.Set("provision_method", fh.ProvisionMethod)
+	d.Set("build_status", fh.BuildStatus)
+	d.Set("build_status_label", fh.BuildStatusLabel)
 
 	d.Set("domain_id", fh.DomainId)
 	d.Set("domain_name", fh.DomainName)
@@ -1117,6 +1401,7 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 	d.Set("architecture_id", fh.ArchitectureId)
 	d.Set("ptable_id", fh.PtableId)
 	d.Set("subnet_id", fh.SubnetId)
+	d.Set("realm_id", fh.RealmId)
 	d.Set("compute_resource_id", fh.ComputeResourceId)
 	d.Set("compute_profile_id", fh.ComputeProfileId)
 	d.Set("operatingsystem_id", fh.OperatingSystemId)
@@ -1127,6 +1412,28 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 	d.Set("config_group_ids", fh.ConfigGroupIds)
 	d.Set("token", fh.Token)
 
+	contentFacet := []interface{}{}
+	if cf := fh.ContentFacetAttributes; cf != nil {
+		contentFacet = append(contentFacet, map[string]interface{}{
+			"content_view_id":          facetIdValue(cf.ContentViewId),
+			"lifecycle_environment_id": facetIdValue(cf.LifecycleEnvironmentId),
+			"content_source_id":        facetIdValue(cf.ContentSourceId),
+			"kickstart_repository_id":  facetIdValue(cf.KickstartRepositoryId),
+		})
+	}
+	d.Set("content_facet_attributes", contentFacet)
+
+	subscriptionFacet := []interface{}{}
+	if sf := fh.SubscriptionFacetAttributes; sf != nil {
+		subscriptionFacet = append(subscriptionFacet, map[string]interface{}{
+			"release_version": sf.ReleaseVersion,
+			"service_level":   sf.ServiceLevel,
+			"purpose_usage":   sf.PurposeUsage,
+			"purpose_role":    sf.PurposeRole,
+		})
+	}
+	d.Set("subscription_facet_attributes", subscriptionFacet)
+
 	return setResourceDataFromForemanInterfacesAttributes(d, fh)
 }
 
@@ -1271,39 +1578,21 @@ func resourceForemanHostCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	// Manage power operations only if needed, default is true
 	if ManagePowerOperations {
-		var powerCmds []interface{}
-		// If enable_bmc is true, perform required power off, pxe boot and power on BMC functions
-		// Don't modify power state at all if we're not managing the build
-		if h.EnableBMC {
-			log.Debugf("Calling BMC Reboot/PXE Functions")
-			// List of BMC Actions to perform
-			powerCmds = []interface{}{
-				api.BMCBoot{
-					Device: api.BootPxe,
-				},
-				api.Power{
-					PowerAction: api.PowerCycle,
-				},
-			}
-		} else if h.Managed {
-			log.Debugf("Using default Foreman behaviour for startup")
-			powerCmds = []interface{}{
-				api.Power{
-					PowerAction: api.PowerOn,
-				},
-			}
+		sendErr := sendForemanHostBuildPowerCommands(ctx, client, createdHost, h.EnableBMC, api.PowerOn, hostRetryCount)
+		if sendErr != nil {
+			return diag.FromErr(sendErr)
 		}
+	}
 
-		// Loop through each of the above BMC Operations and execute.
-		// In the event fo any failure, exit with error
-		for _, cmd := range powerCmds {
-			sendErr := client.SendPowerCommand(ctx, createdHost, cmd, hostRetryCount)
-			if sendErr != nil {
-				return diag.FromErr(sendErr)
-			}
-			// Sleep for 3 seconds between chained BMC calls
-			duration := time.Duration(3) * time.Second
-			time.Sleep(duration)
+	if d.Get("wait_for_build").(bool) {
+		if waitDiags := waitForForemanHostBuild(ctx, d, client, createdHost.Id); waitDiags.HasError() {
+			return waitDiags
+		}
+	}
+
+	if _, ok := d.GetOk("power_state"); ok {
+		if powerDiags := setForemanHostPowerState(ctx, d, client, createdHost); powerDiags.HasError() {
+			return powerDiags
 		}
 	}
 
@@ -1313,6 +1602,117 @@ func resourceForemanHostCreate(ctx context.Context, d *schema.ResourceData, meta
 	return diags
 }
 
+// sendForemanHostBuildPowerCommands boots the host for its build.  If
+// enable_bmc is true, the host is PXE booted and power cycled through its
+// BMC, other managed hosts are sent the supplied power action.  The power
+// state is not modified at all if the host is not managed.
+func sendForemanHostBuildPowerCommands(ctx context.Context, client *api.Client, h *api.ForemanHost, enableBMC bool, powerAction string, retryCount int) error {
+	var powerCmds []interface{}
+	if enableBMC {
+		log.Debugf("Calling BMC Reboot/PXE Functions")
+		// List of BMC Actions to perform
+		powerCmds = []interface{}{
+			api.BMCBoot{
+				Device: api.BootPxe,
+			},
+			api.Power{
+				PowerAction: api.PowerCycle,
+			},
+		}
+	} else if h.Managed {
+		log.Debugf("Using default Foreman behaviour for startup")
+		powerCmds = []interface{}{
+			api.Power{
+				PowerAction: powerAction,
+			},
+		}
+	}
+
+	// Loop through each of the above BMC Operations and execute.
+	// In the event fo any failure, exit with error
+	for _, cmd := range powerCmds {
+		sendErr := client.SendPowerCommand(ctx, h, cmd, retryCount)
+		if sendErr != nil {
+			return sendErr
+		}
+		// Sleep between chained BMC calls
+		time.Sleep(hostPowerCommandDelay)
+	}
+	return nil
+}
+
+// rebuildForemanHost boots the host for its rebuild after its build flag was
+// set.  The configuration of the host is rebuilt first if rebuild_config is
+// true.
+func rebuildForemanHost(ctx context.Context, d *schema.ResourceData, client *api.Client, h *api.ForemanHost) diag.Diagnostics {
+	log.Tracef("resource_foreman_host.go#rebuildForemanHost")
+
+	if d.Get("rebuild_config").(bool) {
+		if rebuildErr := client.RebuildHostConfig(ctx, h.Id); rebuildErr != nil {
+			return diag.FromErr(rebuildErr)
+		}
+	}
+
+	if d.Get("manage_power_operations").(bool) {
+		sendErr := sendForemanHostBuildPowerCommands(ctx, client, h, h.EnableBMC, api.PowerCycle, d.Get("retry_count").(int))
+		if sendErr != nil {
+			return diag.FromErr(sendErr)
+		}
+	}
+	return nil
+}
+
+// waitForForemanHostBuild waits for the build of the host and sets the
+// resource data from the last read of the host.  The wait is limited by the
+// context, which carries the timeout of the operation.
+func waitForForemanHostBuild(ctx context.Context, d *schema.ResourceData, client *api.Client, id int) diag.Diagnostics {
+	log.Tracef("resource_foreman_host.go#waitForForemanHostBuild")
+
+	builtHost, waitErr := client.WaitForHostBuild(ctx, id, hostBuildPollInterval)
+	if builtHost != nil {
+		if err := setResourceDataFromForemanHost(d, builtHost); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return diag.FromErr(waitErr)
+}
+
+// setForemanHostPowerState powers the host on or off if its power state
+// differs from the configured state
+func setForemanHostPowerState(ctx context.Context, d *schema.ResourceData, client *api.Client, h *api.ForemanHost) diag.Diagnostics {
+	log.Tracef("resource_foreman_host.go#setForemanHostPowerState")
+
+	desiredState := d.Get("power_state").(string)
+
+	powerState, powerErr := client.ReadHostPowerState(ctx, h)
+	if powerErr != nil {
+		return diag.Diagnostics{hostPowerDiagnostic(diag.Error, h, powerErr)}
+	}
+
+	if powerState != desiredState {
+		log.Debugf("Changing power state of host [%s] from [%s] to [%s]", h.Name, powerState, desiredState)
+
+		cmd := api.Power{PowerAction: desiredState}
+		if powerErr := client.SendPowerCommand(ctx, h, cmd, d.Get("retry_count").(int)); powerErr != nil {
+			return diag.Diagnostics{hostPowerDiagnostic(diag.Error, h, powerErr)}
+		}
+	}
+
+	d.Set("power_state", desiredState)
+	return nil
+}
+
+// hostPowerDiagnostic returns the diagnostic of a failed power operation on
+// the host, which points at the power_state attribute
+func hostPowerDiagnostic(severity diag.Severity, h *api.ForemanHost, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      severity,
+		Summary:       fmt.Sprintf("Power operation on host [%s] failed", h.Name),
+		Detail:        err.Error(),
+		AttributePath: cty.GetAttrPath("power_state"),
+	}
+}
+
 func resourceForemanHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Tracef("resource_foreman_host.go#resourceForemanHostRead")
 
@@ -1341,6 +1741,20 @@ func resourceForemanHostRead(ctx context.Context, d *schema.ResourceData, meta i
 	if d.Get("retry_count").(int) == 0 {
 		d.Set("retry_count", DEFAULT_RETRY_COUNT)
 	}
+	if d.Get("destroy_mode").(string) == "" {
+		d.Set("destroy_mode", HOST_DESTROY_MODE_DELETE)
+	}
+
+	// NOTE(ALL): the power state is queried from the BMC or the compute
+	//   resource, which is only done for hosts managing their power state.
+	//   Failures are reported as warning to not block the refresh.
+	if _, ok := d.GetOk("power_state"); ok {
+		powerState, powerErr := client.ReadHostPowerState(ctx, readHost)
+		if powerErr != nil {
+			return diag.Diagnostics{hostPowerDiagnostic(diag.Warning, readHost, powerErr)}
+		}
+		d.Set("power_state", powerState)
+	}
 
 	return nil
 }
@@ -1367,17 +1781,24 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 		oldVal, newVal := d.GetChange("interfaces_attributes")
 		oldValList, newValList := oldVal.([]interface{}), newVal.([]interface{})
 
-		// iterate over the removed items, add them back to the interface's
-		// array, but tag them for removal.
-		for idx, rmVal := range oldValList {
-			if idx+1 > len(newValList) {
-				// construct, tag for deletion from list of interfaces
-				rmValMap := rmVal.(map[string]interface{})
-				rmInterface := mapToForemanInterfacesAttribute(rmValMap)
-				rmInterface.Destroy = true
-				// append back to interface's list
-				h.InterfacesAttributes = append(h.InterfacesAttributes, rmInterface)
-			}
+		// the interfaces are matched by their identifier or MAC address, the
+		// list position only identifies interfaces without either
+		interfaces, removed := matchHostInterfaces(
+			oldValList,
+			newValList,
+			configuredHostInterfaceAttributes(d.GetRawConfig()),
+		)
+		h.InterfacesAttributes = make([]api.ForemanInterfacesAttribute, 0, len(interfaces)+len(removed))
+		for _, ifaceMap := range interfaces {
+			h.InterfacesAttributes = append(h.InterfacesAttributes, mapToForemanInterfacesAttribute(ifaceMap))
+		}
+
+		// add the removed items back to the interface's array, but tag them
+		// for removal.
+		for _, rmValMap := range removed {
+			rmInterface := mapToForemanInterfacesAttribute(rmValMap)
+			rmInterface.Destroy = true
+			h.InterfacesAttributes = append(h.InterfacesAttributes, rmInterface)
 		}
 
 	} // end HasChange("interfaces_attributes")
@@ -1393,6 +1814,13 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	hostRetryCount := d.Get("retry_count").(int)
 
+	// NOTE(ALL): a rebuild sets the build flag, regardless of set_build_flag
+	rebuild := hostRebuildRequested(d)
+	if rebuild {
+		log.Debugf("Rebuilding host: %s", h.Name)
+		h.Build = true
+	}
+
 	// We need to test whether a call to update the host is necessary based on what has changed.
 	// Otherwise, a detected update caused by an unsuccessful BMC operation will cause a 422 on update.
 	if d.HasChange("name") ||
@@ -1401,6 +1829,7 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 		d.HasChange("parameters") ||
 		d.HasChange("compute_attributes") ||
 		d.HasChange("domain_id") ||
+		d.HasChange("realm_id") ||
 		d.HasChange("environment_id") ||
 		d.HasChange("owner_id") ||
 		d.HasChange("owner_type") ||
@@ -1408,11 +1837,17 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 		d.HasChange("compute_resource_id") ||
 		d.HasChange("compute_profile_id") ||
 		d.HasChange("operatingsystem_id") ||
+		d.HasChange("medium_id") ||
+		d.HasChange("image_id") ||
+		d.HasChange("ptable_id") ||
 		d.HasChange("interfaces_attributes") ||
 		d.HasChange("build") ||
 		d.HasChange("puppet_class_ids") ||
 		d.HasChange("config_group_ids") ||
+		d.HasChange("content_facet_attributes") ||
+		d.HasChange("subscription_facet_attributes") ||
 		d.HasChange("set_build_flag") ||
+		rebuild ||
 		d.Get("managed") == false {
 
 		log.Debugf("host: [%+v]", h)
@@ -1430,6 +1865,24 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	} // end HasChange("name")
 
+	if rebuild {
+		if rebuildDiags := rebuildForemanHost(ctx, d, client, h); rebuildDiags.HasError() {
+			return rebuildDiags
+		}
+	}
+
+	if d.Get("wait_for_build").(bool) && (rebuild || d.HasChange("set_build_flag") && h.Build) {
+		if waitDiags := waitForForemanHostBuild(ctx, d, client, h.Id); waitDiags.HasError() {
+			return waitDiags
+		}
+	}
+
+	if _, ok := d.GetOk("power_state"); ok && d.HasChange("power_state") {
+		if powerDiags := setForemanHostPowerState(ctx, d, client, h); powerDiags.HasError() {
+			return powerDiags
+		}
+	}
+
 	// Use partial state mode in the event of failure of one of API calls required for host creation
 	d.Partial(false)
 
@@ -1447,6 +1900,20 @@ func resourceForemanHostDelete(ctx context.Context, d *schema.ResourceData, meta
 
 	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
 	//   returns no errors
+	switch d.Get("destroy_mode").(string) {
+	case HOST_DESTROY_MODE_ABANDON:
+		log.Infof("ForemanHostDelete: Removing host [%s] from the state only", h.Name)
+		return nil
+	case HOST_DESTROY_MODE_UNMANAGE:
+		log.Infof("ForemanHostDelete: Marking host [%s] as not managed", h.Name)
+		return diag.FromErr(api.CheckDeleted(d, client.UnmanageHost(ctx, h.Id)))
+	case HOST_DESTROY_MODE_DISASSOCIATE:
+		log.Infof("ForemanHostDelete: Disassociating host [%s] from its VM", h.Name)
+		if disassociateErr := client.DisassociateHost(ctx, h.Id); disassociateErr != nil {
+			return diag.FromErr(api.CheckDeleted(d, disassociateErr))
+		}
+	}
+
 	returnDelete := client.DeleteHost(ctx, h.Id)
 	if returnDelete != nil {
 		return diag.FromErr(api.CheckDeleted(d, returnDelete))
@@ -1493,6 +1960,229 @@ func flattenComputeAttributes(attrs map[string]interface{}) string {
 	return string(json)
 }
 
+// hostInterfaceComputedAttributes are the attributes of interfaces which are
+// computed by Foreman if they are not configured
+var hostInterfaceComputedAttributes = []string{"ip", "mac", "name", "subnet_id"}
+
+// rawHostInterfaces returns the interfaces of the raw configuration.  Returns
+// false if the configuration is not available.
+func rawHostInterfaces(rawConfig cty.Value) (cty.Value, bool) {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() || !rawConfig.Type().IsObjectType() ||
+		!rawConfig.Type().HasAttribute("interfaces_attributes") {
+		return cty.NilVal, false
+	}
+	return rawConfig.GetAttr("interfaces_attributes"), true
+}
+
+// configuredHostInterfaceAttributes returns for every interface of the
+// configuration which of the computed attributes are configured.  Returns nil
+// if the configuration is not available or not known yet, in which case all
+// non-empty attributes are considered configured.
+func configuredHostInterfaceAttributes(rawConfig cty.Value) []map[string]bool {
+	rawInterfaces, ok := rawHostInterfaces(rawConfig)
+	if !ok || !rawInterfaces.IsWhollyKnown() {
+		return nil
+	}
+
+	configured := []map[string]bool{}
+	if rawInterfaces.IsNull() {
+		return configured
+	}
+	for it := rawInterfaces.ElementIterator(); it.Next(); {
+		_, rawInterface := it.Element()
+		attrs := map[string]bool{}
+		for _, attr := range hostInterfaceComputedAttributes {
+			if !rawInterface.IsNull() && rawInterface.Type().HasAttribute(attr) {
+				attrs[attr] = !rawInterface.GetAttr(attr).IsNull()
+			}
+		}
+		configured = append(configured, attrs)
+	}
+	return configured
+}
+
+// matchHostInterfaces matches the configured interfaces with the interfaces
+// of the state.  Interfaces are matched by their identifier, by their MAC
+// address if they have no identifier, and by their position in the list if
+// they have neither.  The configured interfaces are returned with the ID and
+// the unconfigured computed attributes of their match, new interfaces have no
+// ID.  The interfaces of the state without a match are returned as removed.
+func matchHostInterfaces(oldList []interface{}, newList []interface{}, configured []map[string]bool) ([]map[string]interface{}, []map[string]interface{}) {
+	// NOTE(ALL): the computed attributes of list entries are carried over by
+	//   position, ie: an interface moved to the front of the list receives the
+	//   ID of the interface it replaces there.  Only configured attributes
+	//   identify an interface.
+	isConfigured := func(idx int, attr string, m map[string]interface{}) bool {
+		if configured == nil || idx >= len(configured) {
+			return m[attr] != "" && m[attr] != 0
+		}
+		return configured[idx][attr]
+	}
+
+	matches := make([]int, len(newList))
+	matched := make([]bool, len(oldList))
+	for newIdx := range matches {
+		matches[newIdx] = -1
+	}
+
+	// match by identifier and MAC address first, so the positions of the
+	// interfaces matched this way are not taken by interfaces without either
+	for newIdx, newVal := range newList {
+		newMap := newVal.(map[string]interface{})
+		identifier, _ := newMap["identifier"].(string)
+		mac, _ := newMap["mac"].(string)
+		if !isConfigured(newIdx, "mac", newMap) {
+			mac = ""
+		}
+		if identifier == "" && mac == "" {
+			continue
+		}
+		for oldIdx, oldVal := range oldList {
+			oldMap := oldVal.(map[string]interface{})
+			if matched[oldIdx] {
+				continue
+			}
+			if (identifier != "" && oldMap["identifier"] == identifier) ||
+				(identifier == "" && strings.EqualFold(oldMap["mac"].(string), mac)) {
+				matches[newIdx] = oldIdx
+				matched[oldIdx] = true
+				break
+			}
+		}
+	}
+	for newIdx, newVal := range newList {
+		newMap := newVal.(map[string]interface{})
+		identifier, _ := newMap["identifier"].(string)
+		if identifier != "" || isConfigured(newIdx, "mac", newMap) {
+			continue
+		}
+		if newIdx < len(oldList) && !matched[newIdx] {
+			matches[newIdx] = newIdx
+			matched[newIdx] = true
+		}
+	}
+
+	interfaces := make([]map[string]interface{}, len(newList))
+	for newIdx, newVal := range newList {
+		ifaceMap := map[string]interface{}{}
+		for key, value := range newVal.(map[string]interface{}) {
+			ifaceMap[key] = value
+		}
+
+		var oldMap map[string]interface{}
+		if matches[newIdx] >= 0 {
+			oldMap = oldList[matches[newIdx]].(map[string]interface{})
+		}
+		ifaceMap["id"] = 0
+		if oldMap != nil {
+			ifaceMap["id"] = oldMap["id"]
+		}
+		for _, attr := range hostInterfaceComputedAttributes {
+			if configured == nil || isConfigured(newIdx, attr, ifaceMap) {
+				continue
+			}
+			if oldMap != nil {
+				ifaceMap[attr] = oldMap[attr]
+			} else if attr == "subnet_id" {
+				ifaceMap[attr] = 0
+			} else {
+				ifaceMap[attr] = ""
+			}
+		}
+		interfaces[newIdx] = ifaceMap
+	}
+
+	removed := []map[string]interface{}{}
+	for oldIdx, oldVal := range oldList {
+		if !matched[oldIdx] {
+			removed = append(removed, oldVal.(map[string]interface{}))
+		}
+	}
+	return interfaces, removed
+}
+
+// resourceForemanHostCustomizeDiffInterfaces requires exactly one primary and
+// one provision interface if interfaces are configured, and plans the changes
+// of the interfaces as matched by matchHostInterfaces
+func resourceForemanHostCustomizeDiffInterfaces(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
+	// NOTE(ALL): interfaces with unknown values are validated and planned
+	//   once the values are known
+	if rawInterfaces, ok := rawHostInterfaces(d.GetRawConfig()); ok && !rawInterfaces.IsWhollyKnown() {
+		return nil
+	}
+	if !d.NewValueKnown("interfaces_attributes") {
+		return nil
+	}
+	newList := d.Get("interfaces_attributes").([]interface{})
+	configured := configuredHostInterfaceAttributes(d.GetRawConfig())
+
+	// NOTE(ALL): without configured interfaces, the interfaces are inherited
+	//   from the compute profile or discovered
+	if len(newList) > 0 && (configured == nil || len(configured) > 0) {
+		primary, provision := 0, 0
+		for _, newVal := range newList {
+			newMap := newVal.(map[string]interface{})
+			if newMap["primary"] == true {
+				primary++
+			}
+			if newMap["provision"] == true {
+				provision++
+			}
+		}
+		if primary != 1 || provision != 1 {
+			return fmt.Errorf(
+				"interfaces_attributes requires exactly one primary and one provision interface, got [%d] primary and [%d] provision interfaces",
+				primary,
+				provision,
+			)
+		}
+	}
+
+	if d.Id() == "" || !d.HasChange("interfaces_attributes") || len(configured) != len(newList) {
+		return nil
+	}
+	oldVal, _ := d.GetChange("interfaces_attributes")
+	interfaces, _ := matchHostInterfaces(oldVal.([]interface{}), newList, configured)
+
+	planned := make([]interface{}, len(interfaces))
+	for idx, ifaceMap := range interfaces {
+		planned[idx] = ifaceMap
+	}
+	return d.SetNew("interfaces_attributes", planned)
+}
+
+// hostRebuildRequested returns true if the rebuild_trigger or an attribute
+// listed in rebuild_on_change changed
+func hostRebuildRequested(d *schema.ResourceData) bool {
+	if d.HasChange("rebuild_trigger") {
+		return true
+	}
+	for _, attr := range d.Get("rebuild_on_change").(*schema.Set).List() {
+		if d.HasChange(attr.(string)) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceForemanHostCustomizeDiffRebuild replaces the host on changes of the
+// attributes which can not be changed without a rebuild, unless they are
+// listed in rebuild_on_change
+func resourceForemanHostCustomizeDiffRebuild(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	rebuildOnChange := d.Get("rebuild_on_change").(*schema.Set)
+	for _, attr := range hostReplaceAttributes {
+		if d.HasChange(attr) && !rebuildOnChange.Contains(attr) {
+			if err := d.ForceNew(attr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func resourceForemanHostCustomizeDiffComputeAttributes(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
 	if d.HasChange("set_build_flag") {
 		desiredBuildFlag := d.Get("set_build_flag").(bool)
@@ -0,0 +1,454 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanHostInterface() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanHostInterfaceCreate,
+		ReadContext:   resourceForemanHostInterfaceRead,
+		UpdateContext: resourceForemanHostInterfaceUpdate,
+		DeleteContext: resourceForemanHostInterfaceDelete,
+
+		CustomizeDiff: customdiff.All(
+			resourceForemanHostInterfaceCustomizeDiffType,
+		),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceForemanHostInterfaceImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A single network interface of a host, ie: an additional "+
+						"NIC, a bond, a VLAN or a BMC.  Interfaces are imported "+
+						"with `<host_id>/<id>`.  The `interfaces_attributes` of "+
+						"a `foreman_host` also contain the interfaces of this "+
+						"resource, hosts configuring `interfaces_attributes` "+
+						"should ignore changes to them.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"host_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: fmt.Sprintf(
+					"ID of the host the interface belongs to. %s 1",
+					autodoc.MetaExample,
+				),
+			},
+
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "interface",
+				ValidateFunc: validation.StringInSlice(api.HostInterfaceTypes, false),
+				Description: "Type of the interface. Values include: `\"interface\"`, " +
+					"`\"bmc\"`, `\"bond\"`, `\"bridge\"`.",
+			},
+
+			"identifier": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Identifier of the interface local to the host. %s \"eth0.100\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "DNS name of the interface.",
+			},
+
+			"mac": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "MAC address of the interface.",
+			},
+
+			"ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				Description:  "IPv4 address of the interface.",
+			},
+
+			"ip6": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsIPv6Address,
+				Description:  "IPv6 address of the interface.",
+			},
+
+			"subnet_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the IPv4 subnet of the interface.",
+			},
+
+			"subnet6_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the IPv6 subnet of the interface.",
+			},
+
+			"domain_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "ID of the domain of the interface.",
+			},
+
+			"mtu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "MTU of the interface. Defaults to the MTU of the subnet.",
+			},
+
+			"managed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether DHCP and DNS records of the interface are managed by Foreman.",
+			},
+
+			"primary": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the interface is the primary interface of the host.",
+			},
+
+			"provision": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the interface is used to provision the host.",
+			},
+
+			"virtual": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether the interface is virtual, ie: a VLAN or an alias " +
+					"attached to another interface.",
+			},
+
+			"execution": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the interface is used for remote execution.",
+			},
+
+			"tag": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringMatch(hostInterfaceTagRegexp, "must be a VLAN ID"),
+				Description: fmt.Sprintf(
+					"VLAN tag of a virtual interface. %s \"100\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"attached_to": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Identifier of the interface a virtual interface is attached to. %s \"eth0\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(api.HostInterfaceBondModes, false),
+				Description: "Mode of a bond. Values include: `\"balance-rr\"`, " +
+					"`\"active-backup\"`, `\"balance-xor\"`, `\"broadcast\"`, " +
+					"`\"802.3ad\"`, `\"balance-tlb\"`, `\"balance-alb\"`.",
+			},
+
+			"attached_devices": {
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Identifiers of the interfaces of a bond or bridge.",
+			},
+
+			"bond_options": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Space separated options of a bond, ie: \"miimon=100\".",
+			},
+
+			"bmc_provider": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(api.HostInterfaceBMCProviders, false),
+				Description: "Provider of a BMC. Values include: `\"IPMI\"`, " +
+					"`\"Redfish\"`, `\"SSH\"`.",
+			},
+
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Username of a BMC.",
+			},
+
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of a BMC.",
+			},
+		},
+	}
+}
+
+// hostInterfaceTagRegexp matches VLAN IDs
+var hostInterfaceTagRegexp = regexp.MustCompile(`^[0-9]{1,4}$`)
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanHostInterface constructs a ForemanHostInterface struct from a
+// resource data reference.  The struct's members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanHostInterface(d *schema.ResourceData) *api.ForemanHostInterface {
+	log.Tracef("resource_foreman_host_interface.go#buildForemanHostInterface")
+
+	fi := api.ForemanHostInterface{}
+
+	obj := buildForemanObject(d)
+	fi.ForemanObject = *obj
+
+	fi.HostId = d.Get("host_id").(int)
+	fi.Type = d.Get("type").(string)
+	fi.Identifier = d.Get("identifier").(string)
+	fi.MAC = d.Get("mac").(string)
+	fi.IP = d.Get("ip").(string)
+	fi.IP6 = d.Get("ip6").(string)
+	fi.SubnetId = d.Get("subnet_id").(int)
+	fi.Subnet6Id = d.Get("subnet6_id").(int)
+	fi.DomainId = d.Get("domain_id").(int)
+	fi.MTU = d.Get("mtu").(int)
+	fi.Managed = d.Get("managed").(bool)
+	fi.Primary = d.Get("primary").(bool)
+	fi.Provision = d.Get("provision").(bool)
+	fi.Virtual = d.Get("virtual").(bool)
+	fi.Execution = d.Get("execution").(bool)
+	fi.Tag = d.Get("tag").(string)
+	fi.AttachedTo = d.Get("attached_to").(string)
+	fi.Mode = d.Get("mode").(string)
+	fi.BondOptions = d.Get("bond_options").(string)
+	fi.Provider = d.Get("bmc_provider").(string)
+	fi.Username = d.Get("username").(string)
+	fi.Password = d.Get("password").(string)
+
+	// NOTE(ALL): the API joins the identifiers with commas
+	devices := []string{}
+	for _, device := range d.Get("attached_devices").([]interface{}) {
+		devices = append(devices, device.(string))
+	}
+	fi.AttachedDevices = strings.Join(devices, ",")
+
+	return &fi
+}
+
+// setResourceDataFromForemanHostInterface sets a ResourceData's attributes
+// from the attributes of the supplied ForemanHostInterface struct.  The
+// password is not returned by the API and left as is.
+func setResourceDataFromForemanHostInterface(d *schema.ResourceData, fi *api.ForemanHostInterface) {
+	log.Tracef("resource_foreman_host_interface.go#setResourceDataFromForemanHostInterface")
+
+	d.SetId(strconv.Itoa(fi.Id))
+	d.Set("host_id", fi.HostId)
+	d.Set("type", fi.Type)
+	d.Set("identifier", fi.Identifier)
+	d.Set("name", fi.Name)
+	d.Set("mac", fi.MAC)
+	d.Set("ip", fi.IP)
+	d.Set("ip6", fi.IP6)
+	d.Set("subnet_id", fi.SubnetId)
+	d.Set("subnet6_id", fi.Subnet6Id)
+	d.Set("domain_id", fi.DomainId)
+	d.Set("mtu", fi.MTU)
+	d.Set("managed", fi.Managed)
+	d.Set("primary", fi.Primary)
+	d.Set("provision", fi.Provision)
+	d.Set("virtual", fi.Virtual)
+	d.Set("execution", fi.Execution)
+	d.Set("tag", fi.Tag)
+	d.Set("attached_to", fi.AttachedTo)
+	d.Set("mode", fi.Mode)
+	d.Set("bond_options", fi.BondOptions)
+	d.Set("bmc_provider", fi.Provider)
+	d.Set("username", fi.Username)
+
+	devices := []string{}
+	if fi.AttachedDevices != "" {
+		devices = strings.Split(fi.AttachedDevices, ",")
+	}
+	d.Set("attached_devices", devices)
+}
+
+// resourceForemanHostInterfaceCustomizeDiffType rejects attributes which are
+// not supported by the type of the interface at plan time
+func resourceForemanHostInterfaceCustomizeDiffType(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	interfaceType := d.Get("type").(string)
+
+	typeAttrs := map[string][]string{
+		"bond": {"bond_options"},
+		"bmc":  {"username", "password"},
+	}
+	for attrType, attrs := range typeAttrs {
+		for _, attr := range attrs {
+			if interfaceType != attrType && d.Get(attr).(string) != "" {
+				return fmt.Errorf("%s is only supported for interfaces of type [%s], got [%s]", attr, attrType, interfaceType)
+			}
+		}
+	}
+	if len(d.Get("attached_devices").([]interface{})) > 0 && interfaceType != "bond" && interfaceType != "bridge" {
+		return fmt.Errorf("attached_devices is only supported for interfaces of type [bond] and [bridge], got [%s]", interfaceType)
+	}
+	if d.Get("tag").(string) != "" && d.Get("attached_to").(string) == "" {
+		return fmt.Errorf("tag requires attached_to")
+	}
+	return nil
+}
+
+// resourceForemanHostInterfaceImport imports an interface with an ID of the
+// form "<host_id>/<id>"
+func resourceForemanHostInterfaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Tracef("resource_foreman_host_interface.go#Import")
+
+	hostId, id, found := strings.Cut(d.Id(), "/")
+	hostIdInt, hostIdErr := strconv.Atoi(hostId)
+	_, idErr := strconv.Atoi(id)
+	if !found || hostIdErr != nil || idErr != nil {
+		return nil, fmt.Errorf("Import ID [%s] is not of the form <host_id>/<id>", d.Id())
+	}
+
+	d.SetId(id)
+	d.Set("host_id", hostIdInt)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanHostInterfaceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_host_interface.go#Create")
+
+	client := meta.(*api.Client)
+	fi := buildForemanHostInterface(d)
+
+	log.Debugf("ForemanHostInterface: [%+v]", fi)
+
+	createdHostInterface, createErr := client.CreateHostInterface(ctx, fi)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanHostInterface: [%+v]", createdHostInterface)
+
+	setResourceDataFromForemanHostInterface(d, createdHostInterface)
+
+	return nil
+}
+
+func resourceForemanHostInterfaceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_host_interface.go#Read")
+
+	client := meta.(*api.Client)
+	fi := buildForemanHostInterface(d)
+
+	log.Debugf("ForemanHostInterface: [%+v]", fi)
+
+	readHostInterface, readErr := client.ReadHostInterface(ctx, fi.HostId, fi.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanHostInterface: [%+v]", readHostInterface)
+
+	setResourceDataFromForemanHostInterface(d, readHostInterface)
+
+	return nil
+}
+
+func resourceForemanHostInterfaceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_host_interface.go#Update")
+
+	client := meta.(*api.Client)
+	fi := buildForemanHostInterface(d)
+
+	log.Debugf("ForemanHostInterface: [%+v]", fi)
+
+	updatedHostInterface, updateErr := client.UpdateHostInterface(ctx, fi)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanHostInterface: [%+v]", updatedHostInterface)
+
+	setResourceDataFromForemanHostInterface(d, updatedHostInterface)
+
+	return nil
+}
+
+func resourceForemanHostInterfaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_host_interface.go#Delete")
+
+	client := meta.(*api.Client)
+	fi := buildForemanHostInterface(d)
+
+	log.Debugf("ForemanHostInterface: [%+v]", fi)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteHostInterface(ctx, fi.HostId, fi.Id)))
+}
@@ -0,0 +1,118 @@
+package foreman
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Ensures a bond interface is created on the interfaces endpoint of its host
+// with its attached devices, updated, imported and deleted
+func TestResourceForemanHostInterface_Bond(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	hostId := server.Seed("hosts", map[string]interface{}{"name": "bonded.example.com"})
+
+	r := resourceForemanHostInterface()
+	bond := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"host_id":          hostId,
+		"type":             "bond",
+		"identifier":       "bond0",
+		"mode":             "802.3ad",
+		"attached_devices": []interface{}{"eth1", "eth2"},
+		"bond_options":     "miimon=100",
+		"mtu":              9000,
+	})
+	if diags := r.CreateContext(ctx, bond, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	id, _ := strconv.Atoi(bond.Id())
+	obj, ok := server.Get("interfaces", id)
+	if !ok {
+		t.Fatalf("Create did not create the interface")
+	}
+	if obj["host_id"] != float64(hostId) || obj["attached_devices"] != "eth1,eth2" ||
+		obj["mode"] != "802.3ad" || obj["mtu"] != float64(9000) {
+		t.Fatalf("Create did not send the bond, got [%v]", obj)
+	}
+	if devices := bond.Get("attached_devices").([]interface{}); len(devices) != 2 || devices[1] != "eth2" {
+		t.Fatalf("Create did not read the attached devices, got [%v]", bond.State().Attributes)
+	}
+
+	bond.Set("attached_devices", []interface{}{"eth1", "eth2", "eth3"})
+	if diags := r.UpdateContext(ctx, bond, client); diags.HasError() {
+		t.Fatalf("Update returned error [%s]", diags[0].Summary)
+	}
+	if obj, _ := server.Get("interfaces", id); obj["attached_devices"] != "eth1,eth2,eth3" {
+		t.Fatalf("Update did not send the attached devices, got [%v]", obj)
+	}
+
+	imported := r.Data(nil)
+	imported.SetId(strconv.Itoa(hostId) + "/" + bond.Id())
+	states, importErr := r.Importer.StateContext(ctx, imported, client)
+	if importErr != nil {
+		t.Fatalf("Import returned error [%s]", importErr)
+	}
+	if diags := r.ReadContext(ctx, states[0], client); diags.HasError() {
+		t.Fatalf("Read returned error [%s]", diags[0].Summary)
+	}
+	if states[0].Id() != bond.Id() || states[0].Get("host_id") != hostId || states[0].Get("identifier") != "bond0" {
+		t.Fatalf("Import did not read the interface, got [%v]", states[0].State().Attributes)
+	}
+
+	imported.SetId(bond.Id())
+	if _, importErr := r.Importer.StateContext(ctx, imported, client); importErr == nil {
+		t.Fatalf("Import accepted an ID without the host ID")
+	}
+
+	if diags := r.DeleteContext(ctx, bond, client); diags.HasError() {
+		t.Fatalf("Delete returned error [%s]", diags[0].Summary)
+	}
+	if _, ok := server.Get("interfaces", id); ok {
+		t.Fatalf("Delete did not delete the interface")
+	}
+}
+
+// Ensures attributes not supported by the type of an interface are rejected
+// at plan time
+func TestResourceForemanHostInterface_CustomizeDiffType(t *testing.T) {
+	testCases := map[string]map[string]interface{}{
+		"bond_options on interface": {"bond_options": "miimon=100"},
+		"password on bond":          {"type": "bond", "password": "secret"},
+		"attached_devices on bmc":   {"type": "bmc", "attached_devices": []interface{}{"eth0"}},
+		"tag without attached_to":   {"tag": "100"},
+	}
+
+	r := resourceForemanHostInterface()
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config["host_id"] = 1
+			config["identifier"] = "eth0"
+			if _, diffErr := r.Diff(context.TODO(), nil, terraform.NewResourceConfigRaw(config), nil); diffErr == nil {
+				t.Fatalf("Diff accepted [%v]", config)
+			}
+		})
+	}
+
+	config := map[string]interface{}{
+		"host_id":     1,
+		"identifier":  "eth0.100",
+		"virtual":     true,
+		"tag":         "100",
+		"attached_to": "eth0",
+	}
+	if _, diffErr := r.Diff(context.TODO(), nil, terraform.NewResourceConfigRaw(config), nil); diffErr != nil {
+		t.Fatalf("Diff rejected a VLAN interface: [%s]", diffErr)
+	}
+}
@@ -6,13 +6,19 @@ import (
 	"fmt"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -37,6 +43,8 @@ func ForemanHostToInstanceState(obj api.ForemanHost) *terraform.InstanceState {
 	}
 	attr["domain_name"] = obj.DomainName
 	attr["build"] = strconv.FormatBool(obj.Build)
+	attr["build_status"] = strconv.Itoa(obj.BuildStatus)
+	attr["build_status_label"] = obj.BuildStatusLabel
 	attr["provision_method"] = obj.ProvisionMethod
 	attr["shortname"] = obj.Shortname
 
@@ -52,6 +60,9 @@ func ForemanHostToInstanceState(obj api.ForemanHost) *terraform.InstanceState {
 	if obj.SubnetId != nil {
 		attr["subnet_id"] = strconv.Itoa(*obj.SubnetId)
 	}
+	if obj.RealmId != nil {
+		attr["realm_id"] = strconv.Itoa(*obj.RealmId)
+	}
 	if obj.OperatingSystemId != nil {
 		attr["operatingsystem_id"] = strconv.Itoa(*obj.OperatingSystemId)
 	}
@@ -73,6 +84,7 @@ func ForemanHostToInstanceState(obj api.ForemanHost) *terraform.InstanceState {
 	attr["owner_type"] = obj.OwnerType
 	attr["interfaces_attributes.#"] = strconv.Itoa(len(obj.InterfacesAttributes))
 	attr["retry_count"] = "1"
+	attr["destroy_mode"] = "delete"
 	compute_attributes, _ := json.Marshal(obj.ComputeAttributes)
 	attr["compute_attributes"] = string(compute_attributes)
 	for idx, val := range obj.InterfacesAttributes {
@@ -137,6 +149,7 @@ func RandForemanHost() api.ForemanHost {
 	mediumId := rand.Intn(100)
 	imageId := rand.Intn(100)
 	ownerId := rand.Intn(100)
+	realmId := rand.Intn(100)
 
 	obj.OperatingSystemId = &operatingSystemId
 	obj.DomainId = &domainId
@@ -145,6 +158,7 @@ func RandForemanHost() api.ForemanHost {
 	obj.MediumId = &mediumId
 	obj.ImageId = &imageId
 	obj.OwnerId = &ownerId
+	obj.RealmId = &realmId
 	obj.OwnerType = "Usergroup"
 
 	hostCompAttr := make(map[string]interface{})
@@ -620,3 +634,481 @@ func TestResourceHostUpdate_SetBuildFlag(t *testing.T) {
 		t.Errorf("Expected set_build_flag to be true after update, got %v", v)
 	}
 }
+
+// serveHostBuildStatus serves a host whose build status advances through the
+// supplied statuses with every read.  The last status is served once all
+// statuses were read.
+func serveHostBuildStatus(server *foremantest.Server, statuses []int) {
+	labels := map[int]string{
+		api.HostBuildStatusBuilt:        "Installed",
+		api.HostBuildStatusPending:      "Pending installation",
+		api.HostBuildStatusTokenExpired: "Token expired",
+		api.HostBuildStatusBuildFailed:  "Installation error",
+	}
+	server.HandleFunc("/api/hosts/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/vm_compute_attributes") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":                 1,
+			"name":               "host01.example.com",
+			"build":              status != api.HostBuildStatusBuilt,
+			"build_status":       status,
+			"build_status_label": labels[status],
+		})
+	})
+}
+
+// Ensures the build is waited for until the host is built and failed builds
+// return the build status label
+func TestResourceForemanHost_WaitForBuild(t *testing.T) {
+	defaultInterval := hostBuildPollInterval
+	hostBuildPollInterval = time.Millisecond
+	defer func() { hostBuildPollInterval = defaultInterval }()
+
+	testCases := []struct {
+		Statuses []int
+		Timeout  time.Duration
+		Error    string
+	}{
+		{[]int{api.HostBuildStatusPending, api.HostBuildStatusPending, api.HostBuildStatusBuilt}, time.Minute, ""},
+		{[]int{api.HostBuildStatusPending, api.HostBuildStatusBuildFailed}, time.Minute, "Installation error"},
+		{[]int{api.HostBuildStatusTokenExpired}, time.Minute, "Token expired"},
+		{[]int{api.HostBuildStatusPending}, 10 * time.Millisecond, "Pending installation"},
+	}
+
+	for _, testCase := range testCases {
+		server := foremantest.NewServer()
+		serverURL, _ := url.Parse(server.URL)
+		client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+		serveHostBuildStatus(server, testCase.Statuses)
+
+		ctx, cancel := context.WithTimeout(context.TODO(), testCase.Timeout)
+		d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{})
+		diags := waitForForemanHostBuild(ctx, d, client, 1)
+		cancel()
+		server.Close()
+
+		expectedStatus := testCase.Statuses[len(testCase.Statuses)-1]
+		if d.Get("build_status") != expectedStatus {
+			t.Fatalf("Expected build status [%d], got [%v]", expectedStatus, d.Get("build_status"))
+		}
+		if testCase.Error == "" && diags.HasError() {
+			t.Fatalf("Wait returned error [%s]", diags[0].Summary)
+		}
+		if testCase.Error != "" && (!diags.HasError() || !strings.Contains(diags[0].Summary, testCase.Error)) {
+			t.Fatalf("Expected wait to fail with [%s], got [%v]", testCase.Error, diags)
+		}
+	}
+}
+
+// Ensures the power state is converged and read, and failed power operations
+// are reported on the power_state attribute
+func TestResourceForemanHost_PowerState(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	hostId := server.Seed("hosts", map[string]interface{}{"name": "host01.example.com", "power_state": "on"})
+	host := &api.ForemanHost{ForemanObject: api.ForemanObject{Id: hostId, Name: "host01.example.com"}}
+
+	r := resourceForemanHost()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"power_state": "off"})
+	d.SetId(strconv.Itoa(hostId))
+	if diags := setForemanHostPowerState(ctx, d, client, host); diags.HasError() {
+		t.Fatalf("Setting the power state returned error [%s]", diags[0].Detail)
+	}
+	if obj, _ := server.Get("hosts", hostId); obj["power_state"] != "off" {
+		t.Fatalf("Expected the host to be powered off, got [%v]", obj["power_state"])
+	}
+
+	// The state changed outside of terraform is read
+	if err := client.SendPowerCommand(ctx, host, api.Power{PowerAction: api.PowerOn}, 1); err != nil {
+		t.Fatalf("SendPowerCommand returned error [%s]", err)
+	}
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Read returned error [%s]", diags[0].Detail)
+	}
+	if d.Get("power_state") != "on" {
+		t.Fatalf("Expected power state [on], got [%v]", d.Get("power_state"))
+	}
+
+	// Hosts without power management return the error of Foreman
+	noPowerId := server.Seed("hosts", map[string]interface{}{"name": "host02.example.com"})
+	noPower := &api.ForemanHost{ForemanObject: api.ForemanObject{Id: noPowerId, Name: "host02.example.com"}}
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"power_state": "on"})
+	d.SetId(strconv.Itoa(noPowerId))
+	diags := setForemanHostPowerState(ctx, d, client, noPower)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "host02.example.com") ||
+		!strings.Contains(diags[0].Detail, "not enabled") {
+		t.Fatalf("Expected an error diagnostic of the power operation, got [%v]", diags)
+	}
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() || len(diags) != 1 {
+		t.Fatalf("Expected a warning diagnostic on read, got [%v]", diags)
+	}
+}
+
+// Ensures changes of attributes listed in rebuild_on_change do not replace
+// the host
+func TestResourceForemanHost_CustomizeDiffRebuild(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":               "host01.example.com",
+			"provision_method":   "build",
+			"operatingsystem_id": "1",
+			"ptable_id":          "1",
+		},
+	}
+
+	testCases := []struct {
+		Config          map[string]interface{}
+		RequiresNew     bool
+		RebuildOnChange []interface{}
+	}{
+		{map[string]interface{}{"operatingsystem_id": 2}, true, nil},
+		{map[string]interface{}{"operatingsystem_id": 2}, false, []interface{}{"operatingsystem_id"}},
+		{map[string]interface{}{"ptable_id": 2}, false, nil},
+	}
+
+	r := resourceForemanHost()
+	for _, testCase := range testCases {
+		config := map[string]interface{}{"name": "host01.example.com"}
+		for key, value := range testCase.Config {
+			config[key] = value
+		}
+		if testCase.RebuildOnChange != nil {
+			config["rebuild_on_change"] = testCase.RebuildOnChange
+		}
+		diff, diffErr := r.Diff(context.TODO(), state, terraform.NewResourceConfigRaw(config), nil)
+		if diffErr != nil {
+			t.Fatalf("Diff returned error [%s]", diffErr)
+		}
+		if diff.RequiresNew() != testCase.RequiresNew {
+			t.Fatalf("Expected the diff of [%v] to require a new host [%t], got [%t]", config, testCase.RequiresNew, diff.RequiresNew())
+		}
+	}
+}
+
+// Ensures a change of the rebuild_trigger sets the build flag, rebuilds the
+// configuration and power cycles the host
+func TestResourceForemanHost_RebuildTrigger(t *testing.T) {
+	defaultDelay := hostPowerCommandDelay
+	hostPowerCommandDelay = 0
+	defer func() { hostPowerCommandDelay = defaultDelay }()
+
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	hostId := server.Seed("hosts", map[string]interface{}{
+		"name":        "host01.example.com",
+		"managed":     true,
+		"build":       false,
+		"power_state": "off",
+	})
+	configRebuilt := false
+	server.HandleFunc(fmt.Sprintf("/api/hosts/%d/rebuild_config", hostId), func(w http.ResponseWriter, r *http.Request) {
+		configRebuilt = r.Method == http.MethodPut
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message": "Configuration successfully rebuilt"}`))
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name":            "host01.example.com",
+		"rebuild_trigger": "v2",
+		"rebuild_config":  true,
+	})
+	d.SetId(strconv.Itoa(hostId))
+	if diags := resourceForemanHostUpdate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Update returned error [%s]", diags[0].Summary)
+	}
+
+	obj, _ := server.Get("hosts", hostId)
+	if obj["build"] != true {
+		t.Fatalf("Expected the build flag to be set, got [%v]", obj["build"])
+	}
+	if !configRebuilt {
+		t.Fatalf("Expected the configuration to be rebuilt")
+	}
+	if obj["power_state"] != "on" {
+		t.Fatalf("Expected the host to be power cycled, got [%v]", obj["power_state"])
+	}
+}
+
+// Ensures the Katello facets are sent with the host, unset IDs are cleared
+// and changes made in Foreman are read back
+func TestResourceForemanHost_Facets(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	hostId := server.Seed("hosts", map[string]interface{}{
+		"name":    "host01.example.com",
+		"managed": true,
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+		"name": "host01.example.com",
+		"content_facet_attributes": []interface{}{map[string]interface{}{
+			"content_view_id":          6,
+			"lifecycle_environment_id": 3,
+			"content_source_id":        1,
+		}},
+		"subscription_facet_attributes": []interface{}{map[string]interface{}{
+			"release_version": "8.9",
+			"service_level":   "Premium",
+		}},
+	})
+	d.SetId(strconv.Itoa(hostId))
+	if diags := resourceForemanHostUpdate(ctx, d, client); diags.HasError() {
+		t.Fatalf("Update returned error [%s]", diags[0].Summary)
+	}
+
+	obj, _ := server.Get("hosts", hostId)
+	contentFacet, _ := obj["content_facet_attributes"].(map[string]interface{})
+	if contentFacet["content_view_id"] != float64(6) || contentFacet["lifecycle_environment_id"] != float64(3) {
+		t.Fatalf("Update did not send the content facet, got [%v]", obj)
+	}
+	if kickstart, ok := contentFacet["kickstart_repository_id"]; !ok || kickstart != nil {
+		t.Fatalf("Update did not clear the unset kickstart repository, got [%v]", contentFacet)
+	}
+	subscriptionFacet, _ := obj["subscription_facet_attributes"].(map[string]interface{})
+	if subscriptionFacet["release_version"] != "8.9" || subscriptionFacet["purpose_role"] != "" {
+		t.Fatalf("Update did not send the subscription facet, got [%v]", obj)
+	}
+
+	// a copy of the host moved to another lifecycle environment outside of
+	// Terraform
+	contentFacet["lifecycle_environment_id"] = 4
+	obj["content_facet_attributes"] = contentFacet
+	d.SetId(strconv.Itoa(server.Seed("hosts", obj)))
+	if diags := resourceForemanHostRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("Read returned error [%s]", diags[0].Summary)
+	}
+	if d.Get("content_facet_attributes.0.lifecycle_environment_id") != 4 ||
+		d.Get("subscription_facet_attributes.0.service_level") != "Premium" {
+		t.Fatalf("Read did not set the facets, got [%v]", d.State().Attributes)
+	}
+}
+
+// Ensures the destroy mode decides whether the host and its VM are deleted,
+// the host is disassociated from its VM, marked as not managed or kept
+func TestResourceForemanHost_DestroyMode(t *testing.T) {
+	testCases := []struct {
+		mode    string
+		deleted bool
+		managed bool
+		vm      bool
+	}{
+		{mode: "delete", deleted: true},
+		{mode: "disassociate", deleted: true},
+		{mode: "unmanage", managed: false, vm: true},
+		{mode: "abandon", managed: true, vm: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			server := foremantest.NewServer()
+			defer server.Close()
+			serverURL, _ := url.Parse(server.URL)
+			client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+			ctx := context.TODO()
+
+			hostId := server.Seed("hosts", map[string]interface{}{
+				"name":                "host01.example.com",
+				"managed":             true,
+				"compute_resource_id": 7,
+				"uuid":                "42",
+			})
+			disassociated := false
+			server.OnWrite("hosts", func(obj map[string]interface{}) {
+				_, hasVM := obj["uuid"]
+				disassociated = !hasVM
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceForemanHost().Schema, map[string]interface{}{
+				"name":         "host01.example.com",
+				"destroy_mode": tc.mode,
+			})
+			d.SetId(strconv.Itoa(hostId))
+			if diags := resourceForemanHostDelete(ctx, d, client); diags.HasError() {
+				t.Fatalf("Delete returned error [%s]", diags[0].Summary)
+			}
+
+			obj, ok := server.Get("hosts", hostId)
+			if ok == tc.deleted {
+				t.Fatalf("Expected the host to be deleted [%t], got [%v]", tc.deleted, obj)
+			}
+			if tc.mode == "disassociate" && !disassociated {
+				t.Fatalf("Expected the host to be disassociated before the delete")
+			}
+			if tc.mode == "delete" && disassociated {
+				t.Fatalf("Expected the host not to be disassociated")
+			}
+			if !tc.deleted && (obj["managed"] != tc.managed || (obj["uuid"] == "42") != tc.vm) {
+				t.Fatalf("Expected the host to be managed [%t] with its VM [%t], got [%v]", tc.managed, tc.vm, obj)
+			}
+		})
+	}
+}
+
+// Ensures interfaces are matched by identifier, MAC address and position
+// rather than by position only
+func TestMatchHostInterfaces(t *testing.T) {
+	oldList := []interface{}{
+		map[string]interface{}{"id": 1, "identifier": "eth0", "mac": "00:00:00:00:00:01", "ip": "10.0.0.1", "name": "host01", "subnet_id": 1},
+		map[string]interface{}{"id": 2, "identifier": "eth1", "mac": "00:00:00:00:00:02", "ip": "10.0.1.1", "name": "", "subnet_id": 2},
+		map[string]interface{}{"id": 3, "identifier": "", "mac": "00:00:00:00:00:03", "ip": "10.0.2.1", "name": "", "subnet_id": 3},
+		map[string]interface{}{"id": 4, "identifier": "", "mac": "00:00:00:00:00:04", "ip": "10.0.3.1", "name": "", "subnet_id": 4},
+	}
+	// the computed attributes of the new list are carried over by position
+	newList := []interface{}{
+		map[string]interface{}{"id": 1, "identifier": "eth1", "mac": "00:00:00:00:00:01", "ip": "10.0.0.1", "name": "host01", "subnet_id": 1},
+		map[string]interface{}{"id": 2, "identifier": "", "mac": "00:00:00:00:00:04", "ip": "10.0.1.1", "name": "", "subnet_id": 2},
+		map[string]interface{}{"id": 3, "identifier": "", "mac": "00:00:00:00:00:03", "ip": "10.0.2.1", "name": "", "subnet_id": 3},
+		map[string]interface{}{"id": 4, "identifier": "eth5", "mac": "00:00:00:00:00:04", "ip": "10.0.3.1", "name": "", "subnet_id": 4},
+	}
+	configured := []map[string]bool{
+		{},
+		{"mac": true},
+		{},
+		{"ip": true},
+	}
+
+	interfaces, removed := matchHostInterfaces(oldList, newList, configured)
+
+	expected := []map[string]interface{}{
+		{"id": 2, "identifier": "eth1", "mac": "00:00:00:00:00:02", "ip": "10.0.1.1", "name": "", "subnet_id": 2},
+		{"id": 4, "identifier": "", "mac": "00:00:00:00:00:04", "ip": "10.0.3.1", "name": "", "subnet_id": 4},
+		{"id": 3, "identifier": "", "mac": "00:00:00:00:00:03", "ip": "10.0.2.1", "name": "", "subnet_id": 3},
+		{"id": 0, "identifier": "eth5", "mac": "", "ip": "10.0.3.1", "name": "", "subnet_id": 0},
+	}
+	if !reflect.DeepEqual(interfaces, expected) {
+		t.Fatalf("\n\nexpected:\n\n%v\n\ngot:\n\n%v\n\n", expected, interfaces)
+	}
+	if len(removed) != 1 || removed[0]["id"] != 1 {
+		t.Fatalf("Expected interface [1] to be removed, got [%v]", removed)
+	}
+}
+
+// Ensures the plan of reordered interfaces keeps the IDs of the interfaces
+// and the primary and provision interfaces are validated
+func TestResourceForemanHost_CustomizeDiffInterfaces(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"name":                               "host01.example.com",
+			"provision_method":                   "build",
+			"interfaces_attributes.#":            "2",
+			"interfaces_attributes.0.id":         "1",
+			"interfaces_attributes.0.identifier": "eth0",
+			"interfaces_attributes.0.mac":        "00:00:00:00:00:01",
+			"interfaces_attributes.0.primary":    "true",
+			"interfaces_attributes.0.provision":  "true",
+			"interfaces_attributes.1.id":         "2",
+			"interfaces_attributes.1.identifier": "eth1",
+			"interfaces_attributes.1.mac":        "00:00:00:00:00:02",
+			"interfaces_attributes.1.primary":    "false",
+			"interfaces_attributes.1.provision":  "false",
+		},
+	}
+
+	configInterface := func(identifier string, primary bool) map[string]interface{} {
+		return map[string]interface{}{"identifier": identifier, "primary": primary, "provision": primary}
+	}
+	rawInterface := func(identifier string, primary bool) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"identifier": cty.StringVal(identifier),
+			"primary":    cty.BoolVal(primary),
+			"provision":  cty.BoolVal(primary),
+			"ip":         cty.NullVal(cty.String),
+			"mac":        cty.NullVal(cty.String),
+			"name":       cty.NullVal(cty.String),
+			"subnet_id":  cty.NullVal(cty.Number),
+		})
+	}
+
+	r := resourceForemanHost()
+
+	// the interfaces are swapped
+	state.RawConfig = cty.ObjectVal(map[string]cty.Value{
+		"interfaces_attributes": cty.ListVal([]cty.Value{rawInterface("eth1", false), rawInterface("eth0", true)}),
+	})
+	config := map[string]interface{}{
+		"name":                  "host01.example.com",
+		"interfaces_attributes": []interface{}{configInterface("eth1", false), configInterface("eth0", true)},
+	}
+	diff, diffErr := r.Diff(context.TODO(), state, terraform.NewResourceConfigRaw(config), nil)
+	if diffErr != nil {
+		t.Fatalf("Diff returned error [%s]", diffErr)
+	}
+	for idx, expected := range []struct{ Id, MAC string }{{"2", "00:00:00:00:00:02"}, {"1", "00:00:00:00:00:01"}} {
+		id := diff.Attributes[fmt.Sprintf("interfaces_attributes.%d.id", idx)]
+		mac := diff.Attributes[fmt.Sprintf("interfaces_attributes.%d.mac", idx)]
+		if id == nil || id.New != expected.Id || mac == nil || mac.New != expected.MAC {
+			t.Fatalf("Expected interface [%d] to be planned with ID [%s] and MAC [%s], got [%+v] [%+v]", idx, expected.Id, expected.MAC, id, mac)
+		}
+	}
+
+	// both interfaces are primary
+	state.RawConfig = cty.ObjectVal(map[string]cty.Value{
+		"interfaces_attributes": cty.ListVal([]cty.Value{rawInterface("eth0", true), rawInterface("eth1", true)}),
+	})
+	config["interfaces_attributes"] = []interface{}{configInterface("eth0", true), configInterface("eth1", true)}
+	_, diffErr = r.Diff(context.TODO(), state, terraform.NewResourceConfigRaw(config), nil)
+	if diffErr == nil || !strings.Contains(diffErr.Error(), "exactly one primary") {
+		t.Fatalf("Expected the diff to fail with two primary interfaces, got [%v]", diffErr)
+	}
+}
+
+// Ensures the host is created, updated, imported and deleted through
+// Terraform and changes made outside of Terraform are detected
+func TestResourceForemanHost_Lifecycle(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	testResourceLifecycle(t, server, lifecycleTest{
+		Address:    "foreman_host.test",
+		Collection: "hosts",
+		Create: `
+resource "foreman_host" "test" {
+  name    = "web01.example.com"
+  managed = false
+}
+`,
+		Update: `
+resource "foreman_host" "test" {
+  name    = "web01.example.com"
+  managed = false
+  comment = "Web server"
+
+  parameters = {
+    role = "web"
+  }
+}
+`,
+		CreateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_host.test", "name", "web01.example.com"),
+		},
+		UpdateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_host.test", "comment", "Web server"),
+			resource.TestCheckResourceAttr("foreman_host.test", "parameters.role", "web"),
+		},
+		Drift: map[string]interface{}{"comment": "Changed by hand"},
+		// behaviour flags of the resource, which are not stored in Foreman
+		ImportStateVerifyIgnore: []string{"enable_bmc", "manage_power_operations", "rebuild_config", "wait_for_build"},
+	})
+}
@@ -24,7 +24,11 @@ func resourceForemanHostgroup() *schema.Resource {
 		DeleteContext: resourceForemanHostgroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByLookup("", map[string]importLookup{
+				"title": func(ctx context.Context, client *api.Client, title string) (api.QueryResponse, error) {
+					return client.QueryHostgroup(ctx, &api.ForemanHostgroup{Title: title})
+				},
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -23,7 +23,9 @@ func resourceForemanHTTPProxy() *schema.Resource {
 		DeleteContext: resourceForemanHTTPProxyDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryHTTPProxy(ctx, &api.ForemanHTTPProxy{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -24,7 +24,9 @@ func resourceForemanJobTemplate() *schema.Resource {
 		DeleteContext: resourceForemanJobTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryJobTemplate(ctx, &api.ForemanJobTemplate{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -22,7 +22,9 @@ func resourceForemanKatelloContentCredential() *schema.Resource {
 		DeleteContext: resourceForemanKatelloContentCredentialDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryKatelloContentCredential(ctx, &api.ForemanKatelloContentCredential{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
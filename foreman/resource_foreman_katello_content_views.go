@@ -22,7 +22,9 @@ func resourceForemanKatelloContentView() *schema.Resource {
 		DeleteContext: resourceForemanKatelloContentViewDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryContentView(ctx, &api.ContentView{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
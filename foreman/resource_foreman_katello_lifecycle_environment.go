@@ -20,7 +20,9 @@ func resourceForemanKatelloLifecycleEnvironment() *schema.Resource {
 		DeleteContext: resourceForemanKatelloLifecycleEnvironmentDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryLifecycleEnvironment(ctx, &api.LifecycleEnvironment{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		/*
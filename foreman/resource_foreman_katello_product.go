@@ -22,7 +22,9 @@ func resourceForemanKatelloProduct() *schema.Resource {
 		DeleteContext: resourceForemanKatelloProductDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryKatelloProduct(ctx, &api.ForemanKatelloProduct{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
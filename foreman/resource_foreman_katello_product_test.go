@@ -0,0 +1,40 @@
+package foreman
+
+import (
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Ensures the product is created, updated, imported and deleted through
+// Terraform, including the asynchronous task of the deletion, and changes
+// made outside of Terraform are detected
+func TestResourceForemanKatelloProduct_Lifecycle(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	testResourceLifecycle(t, server, lifecycleTest{
+		Address:    "foreman_katello_product.test",
+		Collection: "products",
+		Create: `
+resource "foreman_katello_product" "test" {
+  name = "EPEL"
+}
+`,
+		Update: `
+resource "foreman_katello_product" "test" {
+  name        = "EPEL"
+  description = "Extra Packages for Enterprise Linux"
+}
+`,
+		CreateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_katello_product.test", "name", "EPEL"),
+		},
+		UpdateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_katello_product.test", "description", "Extra Packages for Enterprise Linux"),
+		},
+		Drift: map[string]interface{}{"description": "Changed by hand"},
+	})
+}
@@ -24,7 +24,14 @@ func resourceForemanKatelloRepository() *schema.Resource {
 		DeleteContext: resourceForemanKatelloRepositoryDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByLookup("path", map[string]importLookup{
+				"name": func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+					return client.QueryKatelloRepository(ctx, &api.ForemanKatelloRepository{ForemanObject: api.ForemanObject{Name: name}})
+				},
+				"path": func(ctx context.Context, client *api.Client, path string) (api.QueryResponse, error) {
+					return client.QueryKatelloRepositoryByPath(ctx, path)
+				},
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
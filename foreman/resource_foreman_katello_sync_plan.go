@@ -30,7 +30,9 @@ func resourceForemanKatelloSyncPlan() *schema.Resource {
 		DeleteContext: resourceForemanKatelloSyncPlanDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryKatelloSyncPlan(ctx, &api.ForemanKatelloSyncPlan{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
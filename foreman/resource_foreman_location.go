@@ -0,0 +1,160 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceForemanLocation() *schema.Resource {
+	s := resourceForemanTaxonomySchema("location")
+
+	s[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s Locations group the objects of a Foreman installation by "+
+				"site, ie: a country, city or datacenter.  Locations can be "+
+				"nested, a nested location inherits the parameters of its parents.",
+			autodoc.MetaSummary,
+		),
+	}
+
+	s["organization_ids"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+		Optional:    true,
+		Computed:    true,
+		Description: "IDs of the organizations associated with the location.",
+	}
+
+	return &schema.Resource{
+
+		CreateContext: resourceForemanLocationCreate,
+		ReadContext:   resourceForemanLocationRead,
+		UpdateContext: resourceForemanLocationUpdate,
+		DeleteContext: resourceForemanLocationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateByLookup("", map[string]importLookup{
+				"name": func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+					return client.QueryLocation(ctx, &api.ForemanLocation{ForemanTaxonomy: api.ForemanTaxonomy{ForemanObject: api.ForemanObject{Name: name}}})
+				},
+				"title": func(ctx context.Context, client *api.Client, title string) (api.QueryResponse, error) {
+					return client.QueryLocation(ctx, &api.ForemanLocation{ForemanTaxonomy: api.ForemanTaxonomy{Title: title}})
+				},
+			}),
+		},
+
+		Schema: s,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanLocation constructs a ForemanLocation reference from a resource
+// data reference.  The struct's members are populated from the data populated
+// in the resource data.  Missing members will be left to the zero value for
+// that member's type.
+func buildForemanLocation(d *schema.ResourceData) *api.ForemanLocation {
+	log.Tracef("resource_foreman_location.go#buildForemanLocation")
+
+	l := api.ForemanLocation{}
+	l.ForemanTaxonomy = *buildForemanTaxonomy(d)
+
+	l.OrganizationIds = buildTaxonomyIds(d, "organization_ids")
+
+	return &l
+}
+
+// setResourceDataFromForemanLocation sets a ResourceData's attributes from
+// the attributes of the supplied ForemanLocation reference
+func setResourceDataFromForemanLocation(d *schema.ResourceData, fl *api.ForemanLocation) {
+	log.Tracef("resource_foreman_location.go#setResourceDataFromForemanLocation")
+
+	setResourceDataFromForemanTaxonomy(d, &fl.ForemanTaxonomy)
+	d.Set("organization_ids", fl.OrganizationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanLocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Create")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	createdLocation, createErr := client.CreateLocation(ctx, l)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanLocation: [%+v]", createdLocation)
+
+	setResourceDataFromForemanLocation(d, createdLocation)
+
+	return nil
+}
+
+func resourceForemanLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Read")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	readLocation, readErr := client.ReadLocation(ctx, l.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanLocation: [%+v]", readLocation)
+
+	setResourceDataFromForemanLocation(d, readLocation)
+
+	return nil
+}
+
+func resourceForemanLocationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Update")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	updatedLocation, updateErr := client.UpdateLocation(ctx, l)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanLocation: [%+v]", updatedLocation)
+
+	setResourceDataFromForemanLocation(d, updatedLocation)
+
+	return nil
+}
+
+func resourceForemanLocationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Delete")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteLocation(ctx, l.Id)))
+}
@@ -0,0 +1,193 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Ensures nested locations are created below their parent and can be
+// imported and looked up by title
+func TestResourceForemanLocation_Nested(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	r := resourceForemanLocation()
+	parent := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "Europe",
+	})
+	if diags := r.CreateContext(ctx, parent, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	parentId, _ := strconv.Atoi(parent.Id())
+
+	child := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "Berlin",
+		"description": "Datacenter Berlin",
+		"parent_id":   parentId,
+	})
+	if diags := r.CreateContext(ctx, child, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	if child.Get("title") != "Europe/Berlin" {
+		t.Fatalf("Expected [%s], got [%s]", "Europe/Berlin", child.Get("title"))
+	}
+
+	imported := r.Data(nil)
+	imported.SetId("title:Europe/Berlin")
+	if _, err := r.Importer.StateContext(ctx, imported, client); err != nil {
+		t.Fatalf("Import returned error [%s]", err)
+	}
+	if imported.Id() != child.Id() {
+		t.Fatalf("Expected [%s], got [%s]", child.Id(), imported.Id())
+	}
+
+	ds := dataSourceForemanLocation()
+	dsData := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"title": "Europe/Berlin",
+	})
+	if diags := ds.ReadContext(ctx, dsData, client); diags.HasError() {
+		t.Fatalf("Data source returned error [%s]", diags[0].Summary)
+	}
+	if dsData.Id() != child.Id() || dsData.Get("description") != "Datacenter Berlin" ||
+		dsData.Get("parent_id") != parentId {
+		t.Fatalf("Data source did not return the location, got [%v]", dsData.State().Attributes)
+	}
+
+	if diags := r.DeleteContext(ctx, child, client); diags.HasError() {
+		t.Fatalf("Delete returned error [%s]", diags[0].Summary)
+	}
+	childId, _ := strconv.Atoi(child.Id())
+	if _, ok := server.Get("locations", childId); ok {
+		t.Fatalf("Delete did not delete the location")
+	}
+}
+
+// Ensures the location is created, updated, imported and deleted through
+// Terraform and changes made outside of Terraform are detected
+func TestResourceForemanLocation_Lifecycle(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	testResourceLifecycle(t, server, lifecycleTest{
+		Address:    "foreman_location.test",
+		Collection: "locations",
+		Create: `
+resource "foreman_location" "parent" {
+  name = "Europe"
+}
+
+resource "foreman_location" "test" {
+  name      = "Berlin"
+  parent_id = foreman_location.parent.id
+}
+`,
+		Update: `
+resource "foreman_location" "parent" {
+  name = "Europe"
+}
+
+resource "foreman_location" "test" {
+  name        = "Berlin"
+  description = "Datacenter Berlin"
+  parent_id   = foreman_location.parent.id
+
+  parameters = {
+    ntp_server = "ntp.berlin.example.com"
+  }
+}
+`,
+		CreateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_location.test", "title", "Europe/Berlin"),
+		},
+		UpdateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_location.test", "description", "Datacenter Berlin"),
+			resource.TestCheckResourceAttr("foreman_location.test", "parameters.ntp_server", "ntp.berlin.example.com"),
+		},
+		Drift: map[string]interface{}{"description": "Changed by hand"},
+	})
+}
+
+// Ensures removing the last parameter and emptying an association set
+// removes them in Foreman
+func TestResourceForemanLocation_RemoveAll(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	config := func(attrs string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "foreman_organization" "acme" {
+  name = "ACME"
+}
+
+resource "foreman_location" "test" {
+  name = "Berlin"
+  %s
+}
+`, attrs)
+	}
+	checkServer := func(params int, organizations int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			id, _ := strconv.Atoi(s.RootModule().Resources["foreman_location.test"].Primary.ID)
+			obj, ok := server.Get("locations", id)
+			if !ok {
+				return fmt.Errorf("Location [%d] not found", id)
+			}
+			p, _ := obj["parameters"].([]interface{})
+			o, _ := obj["organization_ids"].([]interface{})
+			if len(p) != params || len(o) != organizations {
+				return fmt.Errorf(
+					"Expected [%d] parameters and [%d] organizations on the server, got [%v] and [%v]",
+					params,
+					organizations,
+					obj["parameters"],
+					obj["organization_ids"],
+				)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+  organization_ids = [foreman_organization.acme.id]
+  parameters = {
+    ntp_server = "ntp.berlin.example.com"
+  }
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("foreman_location.test", "parameters.%", "1"),
+					resource.TestCheckResourceAttr("foreman_location.test", "organization_ids.#", "1"),
+					checkServer(1, 1),
+				),
+			},
+			{
+				Config: config("organization_ids = []"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("foreman_location.test", "parameters.%", "0"),
+					resource.TestCheckResourceAttr("foreman_location.test", "organization_ids.#", "0"),
+					checkServer(0, 0),
+				),
+			},
+			{
+				Config:   config("organization_ids = []"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
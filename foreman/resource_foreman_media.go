@@ -24,7 +24,9 @@ func resourceForemanMedia() *schema.Resource {
 		DeleteContext: resourceForemanMediaDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryMedia(ctx, &api.ForemanMedia{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
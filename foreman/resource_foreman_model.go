@@ -22,7 +22,9 @@ func resourceForemanModel() *schema.Resource {
 		DeleteContext: resourceForemanModelDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryModel(ctx, &api.ForemanModel{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -24,7 +24,11 @@ func resourceForemanOperatingSystem() *schema.Resource {
 		DeleteContext: resourceForemanOperatingSystemDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByLookup("", map[string]importLookup{
+				"title": func(ctx context.Context, client *api.Client, title string) (api.QueryResponse, error) {
+					return client.QueryOperatingSystem(ctx, &api.ForemanOperatingSystem{Title: title})
+				},
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
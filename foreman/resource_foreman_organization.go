@@ -0,0 +1,176 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceForemanOrganization() *schema.Resource {
+	s := resourceForemanTaxonomySchema("organization")
+
+	s[autodoc.MetaAttribute] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"%s Organizations group the objects of a Foreman installation, ie: "+
+				"for different departments or tenants.  Hosts, hostgroups and "+
+				"most other objects belong to one or more organizations.",
+			autodoc.MetaSummary,
+		),
+	}
+
+	s["label"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+		Description: "Unique label of the organization.  Only supported with " +
+			"Katello, which derives it from the name if not set.",
+	}
+
+	s["location_ids"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Elem:        &schema.Schema{Type: schema.TypeInt},
+		Optional:    true,
+		Computed:    true,
+		Description: "IDs of the locations associated with the organization.",
+	}
+
+	return &schema.Resource{
+
+		CreateContext: resourceForemanOrganizationCreate,
+		ReadContext:   resourceForemanOrganizationRead,
+		UpdateContext: resourceForemanOrganizationUpdate,
+		DeleteContext: resourceForemanOrganizationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importStateByLookup("", map[string]importLookup{
+				"name": func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+					return client.QueryOrganization(ctx, &api.ForemanOrganization{ForemanTaxonomy: api.ForemanTaxonomy{ForemanObject: api.ForemanObject{Name: name}}})
+				},
+				"title": func(ctx context.Context, client *api.Client, title string) (api.QueryResponse, error) {
+					return client.QueryOrganization(ctx, &api.ForemanOrganization{ForemanTaxonomy: api.ForemanTaxonomy{Title: title}})
+				},
+			}),
+		},
+
+		Schema: s,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanOrganization constructs a ForemanOrganization reference from a
+// resource data reference.  The struct's members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanOrganization(d *schema.ResourceData) *api.ForemanOrganization {
+	log.Tracef("resource_foreman_organization.go#buildForemanOrganization")
+
+	o := api.ForemanOrganization{}
+	o.ForemanTaxonomy = *buildForemanTaxonomy(d)
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("label"); ok {
+		o.Label = attr.(string)
+	}
+	o.LocationIds = buildTaxonomyIds(d, "location_ids")
+
+	return &o
+}
+
+// setResourceDataFromForemanOrganization sets a ResourceData's attributes from
+// the attributes of the supplied ForemanOrganization reference
+func setResourceDataFromForemanOrganization(d *schema.ResourceData, fo *api.ForemanOrganization) {
+	log.Tracef("resource_foreman_organization.go#setResourceDataFromForemanOrganization")
+
+	setResourceDataFromForemanTaxonomy(d, &fo.ForemanTaxonomy)
+	d.Set("label", fo.Label)
+	d.Set("location_ids", fo.LocationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanOrganizationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Create")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	createdOrganization, createErr := client.CreateOrganization(ctx, o)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanOrganization: [%+v]", createdOrganization)
+
+	setResourceDataFromForemanOrganization(d, createdOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Read")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	readOrganization, readErr := client.ReadOrganization(ctx, o.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanOrganization: [%+v]", readOrganization)
+
+	setResourceDataFromForemanOrganization(d, readOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Update")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	updatedOrganization, updateErr := client.UpdateOrganization(ctx, o)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanOrganization: [%+v]", updatedOrganization)
+
+	setResourceDataFromForemanOrganization(d, updatedOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Delete")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteOrganization(ctx, o.Id)))
+}
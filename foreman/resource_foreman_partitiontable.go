@@ -24,7 +24,9 @@ func resourceForemanPartitionTable() *schema.Resource {
 		DeleteContext: resourceForemanPartitionTableDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryPartitionTable(ctx, &api.ForemanPartitionTable{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		// NOTE(ALL): See the note in setResourceDataFromForemanPartitionTable -
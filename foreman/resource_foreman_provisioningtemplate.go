@@ -24,7 +24,9 @@ func resourceForemanProvisioningTemplate() *schema.Resource {
 		DeleteContext: resourceForemanProvisioningTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryProvisioningTemplate(ctx, &api.ForemanProvisioningTemplate{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
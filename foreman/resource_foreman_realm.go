@@ -0,0 +1,201 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanRealm() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanRealmCreate,
+		ReadContext:   resourceForemanRealmRead,
+		UpdateContext: resourceForemanRealmUpdate,
+		DeleteContext: resourceForemanRealmDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryRealm(ctx, &api.ForemanRealm{ForemanObject: api.ForemanObject{Name: name}})
+			}),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Realms are Kerberos domains, ie: FreeIPA or Active "+
+						"Directory.  Hosts in a realm are enrolled through the "+
+						"realm proxy when they are provisioned.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the realm. %s \"EXAMPLE.COM\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"realm_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "FreeIPA",
+				ValidateFunc: validation.StringInSlice(api.RealmTypes, false),
+				Description: "Type of the realm. Values include: `\"FreeIPA\"`, " +
+					"`\"Active Directory\"`.",
+			},
+
+			"realm_proxy_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the smart proxy with the realm feature managing the realm.",
+			},
+
+			"location_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Computed:    true,
+				Description: "IDs of the locations of the realm.",
+			},
+
+			"organization_ids": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Optional:    true,
+				Computed:    true,
+				Description: "IDs of the organizations of the realm.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanRealm constructs a ForemanRealm struct from a resource data
+// reference.  The struct's members are populated from the data populated in
+// the resource data.  Missing members will be left to the zero value for that
+// member's type.
+func buildForemanRealm(d *schema.ResourceData) *api.ForemanRealm {
+	log.Tracef("resource_foreman_realm.go#buildForemanRealm")
+
+	r := api.ForemanRealm{}
+
+	obj := buildForemanObject(d)
+	r.ForemanObject = *obj
+
+	r.RealmType = d.Get("realm_type").(string)
+	r.RealmProxyId = d.Get("realm_proxy_id").(int)
+
+	r.LocationIds = buildTaxonomyIds(d, "location_ids")
+	r.OrganizationIds = buildTaxonomyIds(d, "organization_ids")
+
+	return &r
+}
+
+// setResourceDataFromForemanRealm sets a ResourceData's attributes from the
+// attributes of the supplied ForemanRealm struct
+func setResourceDataFromForemanRealm(d *schema.ResourceData, fr *api.ForemanRealm) {
+	log.Tracef("resource_foreman_realm.go#setResourceDataFromForemanRealm")
+
+	d.SetId(strconv.Itoa(fr.Id))
+	d.Set("name", fr.Name)
+	d.Set("realm_type", fr.RealmType)
+	d.Set("realm_proxy_id", fr.RealmProxyId)
+	d.Set("location_ids", fr.LocationIds)
+	d.Set("organization_ids", fr.OrganizationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanRealmCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_realm.go#Create")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	createdRealm, createErr := client.CreateRealm(ctx, r)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanRealm: [%+v]", createdRealm)
+
+	setResourceDataFromForemanRealm(d, createdRealm)
+
+	return nil
+}
+
+func resourceForemanRealmRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_realm.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	readRealm, readErr := client.ReadRealm(ctx, r.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanRealm: [%+v]", readRealm)
+
+	setResourceDataFromForemanRealm(d, readRealm)
+
+	return nil
+}
+
+func resourceForemanRealmUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_realm.go#Update")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	updatedRealm, updateErr := client.UpdateRealm(ctx, r)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanRealm: [%+v]", updatedRealm)
+
+	setResourceDataFromForemanRealm(d, updatedRealm)
+
+	return nil
+}
+
+func resourceForemanRealmDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_realm.go#Delete")
+
+	client := meta.(*api.Client)
+	r := buildForemanRealm(d)
+
+	log.Debugf("ForemanRealm: [%+v]", r)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteRealm(ctx, r.Id)))
+}
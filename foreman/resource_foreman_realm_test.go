@@ -0,0 +1,99 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Ensures a realm can be created, looked up by the data source and assigned
+// to a hostgroup
+func TestResourceForemanRealm_Hostgroup(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	proxyId := server.Seed("smart_proxies", map[string]interface{}{"name": "ipa01.example.com"})
+
+	r := resourceForemanRealm()
+	realm := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":           "EXAMPLE.COM",
+		"realm_proxy_id": proxyId,
+	})
+	if diags := r.CreateContext(ctx, realm, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	realmId, _ := strconv.Atoi(realm.Id())
+	if realm.Get("realm_type") != "FreeIPA" || realm.Get("realm_proxy_id") != proxyId {
+		t.Fatalf("Create did not return the realm, got [%v]", realm.State().Attributes)
+	}
+
+	ds := dataSourceForemanRealm()
+	realmData := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"name": "EXAMPLE.COM",
+	})
+	if diags := ds.ReadContext(ctx, realmData, client); diags.HasError() {
+		t.Fatalf("Data source returned error [%s]", diags[0].Summary)
+	}
+	if realmData.Id() != realm.Id() {
+		t.Fatalf("Data source did not return the realm, got [%v]", realmData.State().Attributes)
+	}
+
+	hr := resourceForemanHostgroup()
+	hostgroup := schema.TestResourceDataRaw(t, hr.Schema, map[string]interface{}{
+		"name":     "ipa-clients",
+		"realm_id": realmId,
+	})
+	if diags := hr.CreateContext(ctx, hostgroup, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	hostgroupId, _ := strconv.Atoi(hostgroup.Id())
+	if obj, _ := server.Get("hostgroups", hostgroupId); obj["realm_id"] != float64(realmId) {
+		t.Fatalf("Create did not send the realm, got [%v]", obj)
+	}
+
+	realm = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":           "EXAMPLE.COM",
+		"realm_type":     "Active Directory",
+		"realm_proxy_id": proxyId,
+	})
+	realm.SetId(strconv.Itoa(realmId))
+	if diags := r.UpdateContext(ctx, realm, client); diags.HasError() {
+		t.Fatalf("Update returned error [%s]", diags[0].Summary)
+	}
+	if realm.Get("realm_type") != "Active Directory" {
+		t.Fatalf("Update did not change the realm, got [%v]", realm.State().Attributes)
+	}
+
+	if diags := r.DeleteContext(ctx, realm, client); diags.HasError() {
+		t.Fatalf("Delete returned error [%s]", diags[0].Summary)
+	}
+	if _, ok := server.Get("realms", realmId); ok {
+		t.Fatalf("Delete did not delete the realm")
+	}
+}
+
+// Ensures emptying the locations and organizations of a realm removes them
+// in Foreman
+func TestResourceForemanRealm_ClearTaxonomies(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	proxyId := server.Seed("smart_proxies", map[string]interface{}{"name": "ipa01.example.com"})
+
+	testResourceClearTaxonomies(t, server, "foreman_realm.test", "realms", fmt.Sprintf(`
+resource "foreman_realm" "test" {
+  name           = "EXAMPLE.COM"
+  realm_proxy_id = %d
+  %%s
+}
+`, proxyId))
+}
@@ -0,0 +1,233 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanRole() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanRoleCreate,
+		ReadContext:   resourceForemanRoleRead,
+		UpdateContext: resourceForemanRoleUpdate,
+		DeleteContext: resourceForemanRoleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryRole(ctx, &api.ForemanRole{ForemanObject: api.ForemanObject{Name: name}})
+			}),
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Roles are sets of permissions, which are granted by the "+
+						"filters of the role.  Roles are assigned to users and "+
+						"usergroups.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the role. %s \"Host operator\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the role.",
+			},
+
+			"cloned_from_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the role to clone, ie: a built-in role.  The " +
+					"clone is created with copies of all filters of the role, " +
+					"further filters can be added with `foreman_filter`.",
+			},
+
+			"builtin": {
+				Type:     schema.TypeInt,
+				Computed: true,
+				Description: "Whether the role is built into Foreman: 0 for roles " +
+					"created by users, 1 for the \"Default role\" and 2 for the " +
+					"roles shipped with Foreman and its plugins.",
+			},
+
+			"origin": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Plugin a built-in role originates from.",
+			},
+
+			"location_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Optional: true,
+				Computed: true,
+				Description: "IDs of the locations the role is limited to.  Filters " +
+					"of the role inherit the taxonomies unless they override them.",
+			},
+
+			"organization_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+				Optional: true,
+				Computed: true,
+				Description: "IDs of the organizations the role is limited to.  " +
+					"Filters of the role inherit the taxonomies unless they " +
+					"override them.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanRole constructs a ForemanRole struct from a resource data
+// reference.  The struct's members are populated from the data populated in
+// the resource data.  Missing members will be left to the zero value for that
+// member's type.
+func buildForemanRole(d *schema.ResourceData) *api.ForemanRole {
+	log.Tracef("resource_foreman_role.go#buildForemanRole")
+
+	role := api.ForemanRole{}
+
+	obj := buildForemanObject(d)
+	role.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("description"); ok {
+		role.Description = attr.(string)
+	}
+	if attr, ok = d.GetOk("cloned_from_id"); ok {
+		role.ClonedFromId = attr.(int)
+	}
+	if attr, ok = d.GetOk("builtin"); ok {
+		role.Builtin = attr.(int)
+	}
+	if attr, ok = d.GetOk("origin"); ok {
+		role.Origin = attr.(string)
+	}
+	role.LocationIds = buildTaxonomyIds(d, "location_ids")
+	role.OrganizationIds = buildTaxonomyIds(d, "organization_ids")
+
+	return &role
+}
+
+// setResourceDataFromForemanRole sets a ResourceData's attributes from the
+// attributes of the supplied ForemanRole struct
+func setResourceDataFromForemanRole(d *schema.ResourceData, fr *api.ForemanRole) {
+	log.Tracef("resource_foreman_role.go#setResourceDataFromForemanRole")
+
+	d.SetId(strconv.Itoa(fr.Id))
+	d.Set("name", fr.Name)
+	d.Set("description", fr.Description)
+	d.Set("cloned_from_id", fr.ClonedFromId)
+	d.Set("builtin", fr.Builtin)
+	d.Set("origin", fr.Origin)
+	d.Set("location_ids", fr.LocationIds)
+	d.Set("organization_ids", fr.OrganizationIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_role.go#Create")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	createdRole, createErr := client.CreateRole(ctx, r)
+	if createErr != nil {
+		return diag.FromErr(createErr)
+	}
+
+	log.Debugf("Created ForemanRole: [%+v]", createdRole)
+
+	setResourceDataFromForemanRole(d, createdRole)
+
+	return nil
+}
+
+func resourceForemanRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_role.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	readRole, readErr := client.ReadRole(ctx, r.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanRole: [%+v]", readRole)
+
+	setResourceDataFromForemanRole(d, readRole)
+
+	return nil
+}
+
+func resourceForemanRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_role.go#Update")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	updatedRole, updateErr := client.UpdateRole(ctx, r)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanRole: [%+v]", updatedRole)
+
+	setResourceDataFromForemanRole(d, updatedRole)
+
+	return nil
+}
+
+func resourceForemanRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_role.go#Delete")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteRole(ctx, r.Id)))
+}
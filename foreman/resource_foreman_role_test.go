@@ -0,0 +1,157 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Ensures a role can be cloned from a built-in role, extended with a filter
+// granting permissions looked up by name and assigned to a user
+func TestResourceForemanRole_CloneWithFilter(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	viewHosts := server.Seed("permissions", map[string]interface{}{"name": "view_hosts", "resource_type": "Host"})
+	server.Seed("permissions", map[string]interface{}{"name": "power_hosts", "resource_type": "Host"})
+	viewerId := server.Seed("roles", map[string]interface{}{"name": "Viewer", "builtin": 2})
+	server.Seed("filters", map[string]interface{}{"role_id": viewerId, "permission_ids": []interface{}{viewHosts}})
+
+	viewer := dataSourceForemanRole()
+	viewerData := schema.TestResourceDataRaw(t, viewer.Schema, map[string]interface{}{
+		"name": "Viewer",
+	})
+	if diags := viewer.ReadContext(ctx, viewerData, client); diags.HasError() {
+		t.Fatalf("Data source returned error [%s]", diags[0].Summary)
+	}
+	if viewerData.Id() != strconv.Itoa(viewerId) || viewerData.Get("builtin") != 2 {
+		t.Fatalf("Data source did not return the built-in role, got [%v]", viewerData.State().Attributes)
+	}
+
+	r := resourceForemanRole()
+	role := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":           "Web host operator",
+		"cloned_from_id": viewerId,
+	})
+	if diags := r.CreateContext(ctx, role, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	roleId, _ := strconv.Atoi(role.Id())
+	if role.Get("cloned_from_id") != viewerId || role.Get("builtin") != 0 {
+		t.Fatalf("Create did not clone the role, got [%v]", role.State().Attributes)
+	}
+	if filters := server.List("filters"); len(filters) != 2 || filters[1]["role_id"] != float64(roleId) {
+		t.Fatalf("Create did not clone the filters of the role, got [%v]", filters)
+	}
+
+	perm := dataSourceForemanPermission()
+	permData := schema.TestResourceDataRaw(t, perm.Schema, map[string]interface{}{
+		"name": "power_hosts",
+	})
+	if diags := perm.ReadContext(ctx, permData, client); diags.HasError() {
+		t.Fatalf("Data source returned error [%s]", diags[0].Summary)
+	}
+	powerHosts, _ := strconv.Atoi(permData.Id())
+
+	fr := resourceForemanFilter()
+	filter := schema.TestResourceDataRaw(t, fr.Schema, map[string]interface{}{
+		"role_id":        roleId,
+		"permission_ids": []interface{}{powerHosts},
+		"search":         "hostgroup_title ~ web/*",
+	})
+	if diags := fr.CreateContext(ctx, filter, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	if filter.Get("role_id") != roleId || filter.Get("resource_type") != "Host" ||
+		filter.Get("unlimited") != false || filter.Get("override") != false {
+		t.Fatalf("Create did not return the filter, got [%v]", filter.State().Attributes)
+	}
+
+	ur := resourceForemanUser()
+	user := schema.TestResourceDataRaw(t, ur.Schema, map[string]interface{}{
+		"login":    "jdoe",
+		"role_ids": []interface{}{roleId},
+	})
+	if diags := ur.CreateContext(ctx, user, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	if roleIds := user.Get("role_ids").(*schema.Set); roleIds.Len() != 1 || !roleIds.Contains(roleId) {
+		t.Fatalf("Create did not assign the role, got [%v]", user.State().Attributes)
+	}
+}
+
+// Ensures the role is created, updated, imported and deleted through
+// Terraform and changes made outside of Terraform are detected
+func TestResourceForemanRole_Lifecycle(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	testResourceLifecycle(t, server, lifecycleTest{
+		Address:    "foreman_role.test",
+		Collection: "roles",
+		Create: `
+resource "foreman_role" "test" {
+  name = "Web host operator"
+}
+`,
+		Update: `
+resource "foreman_role" "test" {
+  name        = "Web host operator"
+  description = "Operates the web servers"
+}
+`,
+		CreateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_role.test", "builtin", "0"),
+		},
+		UpdateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_role.test", "description", "Operates the web servers"),
+		},
+		Drift: map[string]interface{}{"name": "Renamed by hand"},
+	})
+}
+
+// Ensures emptying the locations and organizations of a role removes them
+// in Foreman
+func TestResourceForemanRole_ClearTaxonomies(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	testResourceClearTaxonomies(t, server, "foreman_role.test", "roles", `
+resource "foreman_role" "test" {
+  name = "Web host operator"
+  %s
+}
+`)
+}
+
+// Ensures emptying the locations and organizations of an overriding filter
+// removes them in Foreman
+func TestResourceForemanFilter_ClearTaxonomies(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	viewHosts := server.Seed("permissions", map[string]interface{}{"name": "view_hosts", "resource_type": "Host"})
+
+	testResourceClearTaxonomies(t, server, "foreman_filter.test", "filters", fmt.Sprintf(`
+resource "foreman_role" "test" {
+  name = "Web host operator"
+}
+
+resource "foreman_filter" "test" {
+  role_id        = foreman_role.test.id
+  permission_ids = [%d]
+  override       = true
+  %%s
+}
+`, viewHosts))
+}
@@ -0,0 +1,272 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceForemanSetting() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanSettingCreate,
+		ReadContext:   resourceForemanSettingRead,
+		UpdateContext: resourceForemanSettingUpdate,
+		DeleteContext: resourceForemanSettingDelete,
+
+		CustomizeDiff: customdiff.All(
+			resourceForemanSettingCustomizeDiffValue,
+		),
+
+		// Settings use their name as ID
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Setting manages the value of a global Foreman setting. "+
+						"Settings always exist, creating the resource sets the value "+
+						"and destroying it restores the default value.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: fmt.Sprintf(
+					"Name of the setting. "+
+						"%s \"append_domain_name_for_hosts\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			// Value is a string for all types of settings, the value is
+			// converted to the type of the setting when it is sent.
+			"value": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: resourceForemanSettingValueDiffSuppressFunc,
+				Description: fmt.Sprintf(
+					"Value of the setting. Values of boolean settings are true or "+
+						"false, values of array and hash settings are written in JSON "+
+						"notation, ie: with jsonencode(). "+
+						"%s \"false\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"restore_default_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether the default value of the setting is restored " +
+					"when the resource is destroyed. If false, the value is left as is.",
+			},
+
+			"default": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Default value of the setting",
+			},
+
+			"readonly": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the setting is read-only or not.",
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the setting",
+			},
+
+			"category_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the category the setting is in.",
+			},
+
+			"settings_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Data type of this setting (boolean, integer, array, hash, string, ..)",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// setResourceDataFromForemanSetting sets a ResourceData's attributes from the
+// attributes of the supplied ForemanSetting struct
+func setResourceDataFromForemanSetting(d *schema.ResourceData, fs *api.ForemanSetting) {
+	log.Tracef("resource_foreman_setting.go#setResourceDataFromForemanSetting")
+
+	d.SetId(fs.Id)
+	d.Set("name", fs.Name)
+	d.Set("value", api.FormatSettingValue(fs.Value))
+	d.Set("default", api.FormatSettingValue(fs.Default))
+	d.Set("readonly", fs.ReadOnly)
+	d.Set("description", fs.Description)
+	d.Set("category_name", fs.CategoryName)
+	d.Set("settings_type", fs.SettingsType)
+}
+
+// resourceForemanSettingValueDiffSuppressFunc compares the values of array and
+// hash settings by their content, since Foreman does not keep the formatting
+// of the JSON value
+func resourceForemanSettingValueDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	settingsType := d.Get("settings_type").(string)
+	if settingsType != "array" && settingsType != "hash" {
+		return false
+	}
+
+	var oldContent, newContent interface{}
+	if json.Unmarshal([]byte(oldValue), &oldContent) != nil {
+		return false
+	}
+	if json.Unmarshal([]byte(newValue), &newContent) != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldContent, newContent)
+}
+
+// resourceForemanSettingCustomizeDiffValue rejects read-only settings and
+// values which do not match the type of the setting at plan time
+func resourceForemanSettingCustomizeDiffValue(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("name") {
+		return nil
+	}
+
+	client, ok := meta.(*api.Client)
+	if !ok {
+		return nil
+	}
+
+	name := d.Get("name").(string)
+	setting, readErr := client.ReadSetting(ctx, name)
+	if readErr != nil {
+		return fmt.Errorf("Reading setting [%s] failed: %w", name, readErr)
+	}
+
+	if setting.ReadOnly {
+		return fmt.Errorf(
+			"Setting [%s] is read-only, it is usually set in the Foreman configuration files",
+			name,
+		)
+	}
+
+	if !d.NewValueKnown("value") {
+		return nil
+	}
+	_, parseErr := api.ParseSettingValue(setting.SettingsType, d.Get("value").(string))
+	return parseErr
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanSettingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_setting.go#Create")
+
+	// NOTE(ALL): Settings can not be created, creating the resource reads the
+	//   setting and updates its value
+	d.SetId(d.Get("name").(string))
+
+	return resourceForemanSettingUpdate(ctx, d, meta)
+}
+
+func resourceForemanSettingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_setting.go#Read")
+
+	client := meta.(*api.Client)
+
+	readSetting, readErr := client.ReadSetting(ctx, d.Id())
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanSetting: [%+v]", readSetting)
+
+	setResourceDataFromForemanSetting(d, readSetting)
+
+	return nil
+}
+
+func resourceForemanSettingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_setting.go#Update")
+
+	client := meta.(*api.Client)
+
+	// The type of the value depends on the setting, the setting is read
+	// again in case it changed since the plan
+	readSetting, readErr := client.ReadSetting(ctx, d.Id())
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	if readSetting.ReadOnly {
+		return diag.Errorf("Setting [%s] is read-only", readSetting.Name)
+	}
+
+	value, parseErr := api.ParseSettingValue(readSetting.SettingsType, d.Get("value").(string))
+	if parseErr != nil {
+		return diag.FromErr(parseErr)
+	}
+
+	log.Debugf("ForemanSetting: [%s], value: [%+v]", d.Id(), value)
+
+	updatedSetting, updateErr := client.UpdateSetting(ctx, d.Id(), value)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanSetting: [%+v]", updatedSetting)
+
+	setResourceDataFromForemanSetting(d, updatedSetting)
+
+	return nil
+}
+
+func resourceForemanSettingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_setting.go#Delete")
+
+	if !d.Get("restore_default_on_destroy").(bool) {
+		log.Debugf("Leaving the value of setting [%s] as is", d.Id())
+		return nil
+	}
+
+	client := meta.(*api.Client)
+
+	readSetting, readErr := client.ReadSetting(ctx, d.Id())
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	// The default is returned with the type of the setting and can be sent
+	// as is
+	_, updateErr := client.UpdateSetting(ctx, d.Id(), readSetting.Default)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(updateErr)
+}
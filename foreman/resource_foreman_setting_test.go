@@ -0,0 +1,161 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// serveSettings serves the settings API from a map of settings by name.
+// Settings use their name as ID, which the generic fake does not support.
+func serveSettings(t *testing.T, server *foremantest.Server, settings map[string]map[string]interface{}) {
+	server.HandleFunc("/api/settings/", func(w http.ResponseWriter, r *http.Request) {
+		setting, ok := settings[strings.TrimPrefix(r.URL.Path, "/api/settings/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodPut {
+			var body struct {
+				Setting struct {
+					Value interface{} `json:"value"`
+				} `json:"setting"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("Decoding the request failed: [%s]", err)
+			}
+			setting["value"] = body.Setting.Value
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(setting)
+	})
+}
+
+// Ensures values are sent with the type of the setting and the default is
+// restored on destroy
+func TestResourceForemanSetting_TypedValue(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	settings := map[string]map[string]interface{}{
+		"append_domain_name_for_hosts": {
+			"id": "append_domain_name_for_hosts", "name": "append_domain_name_for_hosts",
+			"settings_type": "boolean", "value": true, "default": true,
+		},
+		"token_duration": {
+			"id": "token_duration", "name": "token_duration",
+			"settings_type": "integer", "value": float64(360), "default": float64(360),
+		},
+		"trusted_hosts": {
+			"id": "trusted_hosts", "name": "trusted_hosts",
+			"settings_type": "array", "value": []interface{}{}, "default": []interface{}{},
+		},
+	}
+	serveSettings(t, server, settings)
+
+	testCases := []struct {
+		Name     string
+		Value    string
+		Expected interface{}
+	}{
+		{"append_domain_name_for_hosts", "false", false},
+		{"token_duration", "60", float64(60)},
+		{"trusted_hosts", `["proxy.example.com"]`, []interface{}{"proxy.example.com"}},
+	}
+
+	r := resourceForemanSetting()
+	for _, testCase := range testCases {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"name":  testCase.Name,
+			"value": testCase.Value,
+		})
+		if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+			t.Fatalf("Create returned error [%s]", diags[0].Summary)
+		}
+		if !reflect.DeepEqual(settings[testCase.Name]["value"], testCase.Expected) {
+			t.Fatalf("Expected [%#v] to be sent, got [%#v]", testCase.Expected, settings[testCase.Name]["value"])
+		}
+		if d.Id() != testCase.Name || d.Get("value") != testCase.Value {
+			t.Fatalf("Create did not set the state, got [%v]", d.State().Attributes)
+		}
+
+		if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+			t.Fatalf("Delete returned error [%s]", diags[0].Summary)
+		}
+		if !reflect.DeepEqual(settings[testCase.Name]["value"], settings[testCase.Name]["default"]) {
+			t.Fatalf("Delete did not restore the default, got [%#v]", settings[testCase.Name]["value"])
+		}
+	}
+
+	// The value is left as is if requested
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                       "token_duration",
+		"value":                      "60",
+		"restore_default_on_destroy": false,
+	})
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Create returned error [%s]", diags[0].Summary)
+	}
+	if diags := r.DeleteContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("Delete returned error [%s]", diags[0].Summary)
+	}
+	if settings["token_duration"]["value"] != float64(60) {
+		t.Fatalf("Delete changed the value, got [%#v]", settings["token_duration"]["value"])
+	}
+}
+
+// Ensures read-only settings and values of the wrong type are rejected when
+// planning
+func TestResourceForemanSetting_CustomizeDiff(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	client := api.NewClient(api.Server{URL: *serverURL}, api.ClientCredentials{}, api.ClientConfig{})
+	ctx := context.TODO()
+
+	serveSettings(t, server, map[string]map[string]interface{}{
+		"foreman_url": {
+			"id": "foreman_url", "name": "foreman_url",
+			"settings_type": "string", "value": "https://foreman.example.com", "readonly": true,
+		},
+		"token_duration": {
+			"id": "token_duration", "name": "token_duration",
+			"settings_type": "integer", "value": float64(360), "default": float64(360),
+		},
+	})
+
+	testCases := []struct {
+		Name  string
+		Value string
+		Error string
+	}{
+		{"foreman_url", "https://other.example.com", "is read-only"},
+		{"token_duration", "one hour", "is not an integer"},
+		{"token_duration", "60", ""},
+	}
+
+	r := resourceForemanSetting()
+	for _, testCase := range testCases {
+		config := map[string]interface{}{"name": testCase.Name, "value": testCase.Value}
+		_, diffErr := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(config), client)
+		if testCase.Error == "" && diffErr != nil {
+			t.Fatalf("Diff of [%s] returned error [%s]", testCase.Name, diffErr)
+		}
+		if testCase.Error != "" && (diffErr == nil || !strings.Contains(diffErr.Error(), testCase.Error)) {
+			t.Fatalf("Expected diff of [%s] to fail with [%s], got [%v]", testCase.Name, testCase.Error, diffErr)
+		}
+	}
+}
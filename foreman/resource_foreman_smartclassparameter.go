@@ -0,0 +1,332 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanSmartClassParameter() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanSmartClassParameterCreate,
+		ReadContext:   resourceForemanSmartClassParameterRead,
+		UpdateContext: resourceForemanSmartClassParameterUpdate,
+		DeleteContext: resourceForemanSmartClassParameterDelete,
+
+		CustomizeDiff: customdiff.All(
+			resourceForemanSmartClassParameterCustomizeDiffMerge,
+		),
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Smart class parameters are the parameters of puppet "+
+						"classes.  They are imported with the puppet class and "+
+						"can not be created, the resource manages the settings "+
+						"of an existing parameter.  Destroying the resource "+
+						"disables the override.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"parameter": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: fmt.Sprintf(
+					"Smart class parameter name."+
+						"%s \"example_param\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"puppetclass_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+				Description: fmt.Sprintf(
+					"ID of the puppet class containing this parameter."+
+						"%s 1",
+					autodoc.MetaExample,
+				),
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Description of the parameter.",
+			},
+
+			"override": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether the value is managed by Foreman. If false, the " +
+					"default of the puppet class is used and override values are ignored.",
+			},
+
+			"parameter_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "string",
+				ValidateFunc: validation.StringInSlice(api.SmartClassParameterTypes, false),
+				Description: "Type of the parameter. Values include: `\"string\"`, " +
+					"`\"boolean\"`, `\"integer\"`, `\"real\"`, `\"array\"`, `\"hash\"`, " +
+					"`\"yaml\"`, `\"json\"`.",
+			},
+
+			"default_value": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Default value of the parameter. Values of array and hash " +
+					"parameters are written in JSON notation, ie: with jsonencode().",
+			},
+
+			"hidden_value": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the value is hidden in the UI.",
+			},
+
+			"omit": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the parameter is omitted from the classification output.",
+			},
+
+			"required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether a value is required.",
+			},
+
+			"validator_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"", "regexp", "list"}, false),
+				Description:  "Type of the validator. Values include: `\"regexp\"`, `\"list\"`.",
+			},
+
+			"validator_rule": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Rule of the validator, ie: a regular expression or a " +
+					"comma separated list of valid values.",
+			},
+
+			"override_value_order": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"Order of the matchers the override values are looked up with, "+
+						"one per line. %s \"fqdn\\nhostgroup\\nos\\ndomain\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"merge_overrides": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Whether the values of all matching override values are " +
+					"merged. Only supported for array and hash parameters.",
+			},
+
+			"merge_default": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Whether the default value is merged as well. Requires `merge_overrides`.",
+			},
+
+			"avoid_duplicates": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Whether duplicates are removed from merged values. " +
+					"Only supported for array parameters and requires `merge_overrides`.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanSmartClassParameter constructs a ForemanSmartClassParameter reference from a
+// resource data reference.  The struct's  members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanSmartClassParameter(d *schema.ResourceData) *api.ForemanSmartClassParameter {
+	t := api.ForemanSmartClassParameter{}
+	obj := buildForemanObject(d)
+	t.ForemanObject = *obj
+
+	t.Parameter = d.Get("parameter").(string)
+	t.PuppetClassId = d.Get("puppetclass_id").(int)
+
+	t.Description = d.Get("description").(string)
+	t.Override = d.Get("override").(bool)
+	t.ParameterType = d.Get("parameter_type").(string)
+	t.DefaultValue = d.Get("default_value").(string)
+	t.HiddenValue = d.Get("hidden_value").(bool)
+	t.Omit = d.Get("omit").(bool)
+	t.Required = d.Get("required").(bool)
+	t.ValidatorType = d.Get("validator_type").(string)
+	t.ValidatorRule = d.Get("validator_rule").(string)
+	t.OverrideValueOrder = d.Get("override_value_order").(string)
+	t.MergeOverrides = d.Get("merge_overrides").(bool)
+	t.MergeDefault = d.Get("merge_default").(bool)
+	t.AvoidDuplicates = d.Get("avoid_duplicates").(bool)
+
+	return &t
+}
+
+// setResourceDataFromForemanSmartClassParameter sets a ResourceData's attributes from
+// the attributes of the supplied ForemanSmartClassParameter reference
+func setResourceDataFromForemanSmartClassParameter(d *schema.ResourceData, fk *api.ForemanSmartClassParameter) {
+	d.SetId(strconv.Itoa(fk.Id))
+	d.Set("parameter", fk.Parameter)
+	d.Set("puppetclass_id", fk.PuppetClassId)
+	d.Set("description", fk.Description)
+	d.Set("override", fk.Override)
+	d.Set("parameter_type", fk.ParameterType)
+	d.Set("default_value", fk.DefaultValue)
+	d.Set("hidden_value", fk.HiddenValue)
+	d.Set("omit", fk.Omit)
+	d.Set("required", fk.Required)
+	d.Set("validator_type", fk.ValidatorType)
+	d.Set("validator_rule", fk.ValidatorRule)
+	d.Set("override_value_order", fk.OverrideValueOrder)
+	d.Set("merge_overrides", fk.MergeOverrides)
+	d.Set("merge_default", fk.MergeDefault)
+	d.Set("avoid_duplicates", fk.AvoidDuplicates)
+}
+
+// resourceForemanSmartClassParameterCustomizeDiffMerge rejects merge options
+// which Foreman does not support for the type of the parameter at plan time
+func resourceForemanSmartClassParameterCustomizeDiffMerge(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	parameterType := d.Get("parameter_type").(string)
+	mergeOverrides := d.Get("merge_overrides").(bool)
+
+	if mergeOverrides && parameterType != "array" && parameterType != "hash" {
+		return fmt.Errorf("merge_overrides is only supported for array and hash parameters, got [%s]", parameterType)
+	}
+	if d.Get("merge_default").(bool) && !mergeOverrides {
+		return fmt.Errorf("merge_default requires merge_overrides")
+	}
+	if d.Get("avoid_duplicates").(bool) && (!mergeOverrides || parameterType != "array") {
+		return fmt.Errorf("avoid_duplicates requires merge_overrides and an array parameter")
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanSmartClassParameterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_smartclassparameter.go#Create")
+
+	client := meta.(*api.Client)
+	t := buildForemanSmartClassParameter(d)
+
+	// NOTE(ALL): smart class parameters can not be created, the parameter is
+	//   looked up in its puppet class and updated
+	queryResponse, queryErr := client.QuerySmartClassParameter(ctx, t)
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+	if queryResponse.Subtotal != 1 {
+		return diag.Errorf(
+			"Smart class parameter [%s] not found in puppet class [%d], "+
+				"the puppet class may need to be imported",
+			t.Parameter,
+			t.PuppetClassId,
+		)
+	}
+	t.Id = queryResponse.Results[0].(api.ForemanSmartClassParameter).Id
+
+	d.SetId(strconv.Itoa(t.Id))
+
+	return resourceForemanSmartClassParameterUpdate(ctx, d, meta)
+}
+
+func resourceForemanSmartClassParameterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_smartclassparameter.go#Read")
+
+	client := meta.(*api.Client)
+	t := buildForemanSmartClassParameter(d)
+
+	log.Debugf("ForemanSmartClassParameter: [%+v]", t)
+
+	readSmartClassParameter, readErr := client.ReadSmartClassParameter(ctx, t.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanSmartClassParameter: [%+v]", readSmartClassParameter)
+
+	setResourceDataFromForemanSmartClassParameter(d, readSmartClassParameter)
+
+	return nil
+}
+
+func resourceForemanSmartClassParameterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_smartclassparameter.go#Update")
+
+	client := meta.(*api.Client)
+	t := buildForemanSmartClassParameter(d)
+
+	log.Debugf("ForemanSmartClassParameter: [%+v]", t)
+
+	updatedSmartClassParameter, updateErr := client.UpdateSmartClassParameter(ctx, t)
+	if updateErr != nil {
+		return diag.FromErr(updateErr)
+	}
+
+	log.Debugf("Updated ForemanSmartClassParameter: [%+v]", updatedSmartClassParameter)
+
+	setResourceDataFromForemanSmartClassParameter(d, updatedSmartClassParameter)
+
+	return nil
+}
+
+func resourceForemanSmartClassParameterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_smartclassparameter.go#Delete")
+
+	client := meta.(*api.Client)
+	t := buildForemanSmartClassParameter(d)
+
+	log.Debugf("ForemanSmartClassParameter: [%+v]", t)
+
+	// NOTE(ALL): the parameter belongs to the puppet class and can not be
+	//   deleted, the override is disabled instead
+	t.Override = false
+	t.MergeOverrides = false
+	t.MergeDefault = false
+	t.AvoidDuplicates = false
+
+	_, updateErr := client.UpdateSmartClassParameter(ctx, t)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, updateErr))
+}
@@ -23,7 +23,9 @@ func resourceForemanSmartProxy() *schema.Resource {
 		DeleteContext: resourceForemanSmartProxyDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QuerySmartProxy(ctx, &api.ForemanSmartProxy{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -23,7 +23,14 @@ func resourceForemanSubnet() *schema.Resource {
 		DeleteContext: resourceForemanSubnetDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByLookup("", map[string]importLookup{
+				"name": func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+					return client.QuerySubnet(ctx, &api.ForemanSubnet{ForemanObject: api.ForemanObject{Name: name}})
+				},
+				"network": func(ctx context.Context, client *api.Client, network string) (api.QueryResponse, error) {
+					return client.QuerySubnet(ctx, &api.ForemanSubnet{Network: network})
+				},
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -23,7 +23,11 @@ func resourceForemanUser() *schema.Resource {
 		DeleteContext: resourceForemanUserDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByLookup("", map[string]importLookup{
+				"login": func(ctx context.Context, client *api.Client, login string) (api.QueryResponse, error) {
+					return client.QueryUser(ctx, &api.ForemanUser{Login: login})
+				},
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -96,8 +100,9 @@ func resourceForemanUser() *schema.Resource {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      1,
-				ValidateFunc: validation.IntBetween(1, 2),
-				Description:  "Set the authentication source, i.e internal (1,default) or external (2)",
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Set the authentication source, i.e internal (1,default), " +
+					"external (2) or the ID of a `foreman_auth_source_ldap`",
 			},
 
 			"locale": {
@@ -123,6 +128,19 @@ func resourceForemanUser() *schema.Resource {
 				Optional:    true,
 				Description: "List of all organizations a user has access to",
 			},
+
+			"role_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Optional: true,
+				Computed: true,
+				Description: "IDs of the roles assigned to the user. The roles are " +
+					"left untouched if omitted, an empty list revokes all roles. " +
+					"The \"Default role\" is assigned " +
+					"implicitly and is not listed.",
+			},
 		},
 	}
 }
@@ -187,6 +205,9 @@ func buildForemanUser(d *schema.ResourceData) *api.ForemanUser {
 		attrSet := attr.(*schema.Set)
 		u.OrganizationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
 	}
+	// NOTE(ALL): the roles are always sent, an empty set revokes all roles.
+	//   If omitted, the set keeps the roles of the state.
+	u.RoleIds = conv.InterfaceSliceToIntSlice(d.Get("role_ids").(*schema.Set).List())
 	return &u
 }
 
@@ -209,6 +230,7 @@ func setResourceDataFromForemanUser(d *schema.ResourceData, fu *api.ForemanUser)
 	d.Set("locale", fu.Locale)
 	d.Set("location_ids", fu.LocationIds)
 	d.Set("organization_ids", fu.OrganizationIds)
+	d.Set("role_ids", fu.RoleIds)
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,131 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/foremantest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Ensures the user is created, updated, imported and deleted through
+// Terraform and changes made outside of Terraform are detected
+func TestResourceForemanUser_Lifecycle(t *testing.T) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	testResourceLifecycle(t, server, lifecycleTest{
+		Address:    "foreman_user.test",
+		Collection: "users",
+		Create: `
+resource "foreman_role" "operator" {
+  name = "Web host operator"
+}
+
+resource "foreman_user" "test" {
+  login    = "jdoe"
+  password = "changeme123"
+  role_ids = [foreman_role.operator.id]
+}
+`,
+		Update: `
+resource "foreman_role" "operator" {
+  name = "Web host operator"
+}
+
+resource "foreman_user" "test" {
+  login     = "jdoe"
+  password  = "changeme123"
+  firstname = "John"
+  mail      = "jdoe@example.com"
+  role_ids  = [foreman_role.operator.id]
+}
+`,
+		CreateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_user.test", "role_ids.#", "1"),
+			resource.TestCheckResourceAttrPair("foreman_user.test", "role_ids.0", "foreman_role.operator", "id"),
+		},
+		UpdateChecks: []resource.TestCheckFunc{
+			resource.TestCheckResourceAttr("foreman_user.test", "firstname", "John"),
+			resource.TestCheckResourceAttr("foreman_user.test", "mail", "jdoe@example.com"),
+		},
+		Drift: map[string]interface{}{"mail": "john.doe@example.com"},
+		// the password is not returned by Foreman
+		ImportStateVerifyIgnore: []string{"password"},
+	})
+}
+
+// Ensures omitted roles are left untouched and an empty set revokes all
+// roles of the user
+func TestResourceForemanUser_RevokeRoles(t *testing.T) {
+	testResourceRevokeRoles(t, "foreman_user", "users", `login = "jdoe"`)
+}
+
+// testResourceRevokeRoles runs a resource with role_ids through Terraform
+// against the fake Foreman server: the roles are assigned, kept if role_ids
+// is omitted and revoked with an empty role_ids
+func testResourceRevokeRoles(t *testing.T, resourceType string, collection string, attrs string) {
+	server := foremantest.NewServer()
+	defer server.Close()
+
+	address := resourceType + ".test"
+	config := func(roleIds string) string {
+		return server.ProviderConfig() + fmt.Sprintf(`
+resource "foreman_role" "operator" {
+  name = "Web host operator"
+}
+
+resource "%s" "test" {
+  %s
+  %s
+}
+`, resourceType, attrs, roleIds)
+	}
+	checkServerRoles := func(expected int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			id, _ := strconv.Atoi(s.RootModule().Resources[address].Primary.ID)
+			obj, ok := server.Get(collection, id)
+			if !ok {
+				return fmt.Errorf("Object [%d] of [%s] not found", id, collection)
+			}
+			if roleIds, _ := obj["role_ids"].([]interface{}); len(roleIds) != expected {
+				return fmt.Errorf("Expected [%d] roles on the server, got [%v]", expected, obj["role_ids"])
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providerFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("role_ids = [foreman_role.operator.id]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "role_ids.#", "1"),
+					checkServerRoles(1),
+				),
+			},
+			{
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "role_ids.#", "1"),
+					checkServerRoles(1),
+				),
+			},
+			{
+				Config: config("role_ids = []"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(address, "role_ids.#", "0"),
+					checkServerRoles(0),
+				),
+			},
+			{
+				Config:   config("role_ids = []"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
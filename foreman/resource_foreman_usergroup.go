@@ -6,11 +6,13 @@ import (
 	"strconv"
 
 	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanUsergroup() *schema.Resource {
@@ -22,7 +24,9 @@ func resourceForemanUsergroup() *schema.Resource {
 		DeleteContext: resourceForemanUsergroupDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryUsergroup(ctx, &api.ForemanUsergroup{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -55,6 +59,44 @@ func resourceForemanUsergroup() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"role_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Optional: true,
+				Computed: true,
+				Description: "IDs of the roles assigned to the members of the " +
+					"usergroup. The roles are left untouched if omitted, an empty " +
+					"list revokes all roles.",
+			},
+
+			"external_usergroups": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: fmt.Sprintf(
+								"Name of the group in the auth source. %s \"web-admins\"",
+								autodoc.MetaExample,
+							),
+						},
+						"auth_source_id": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "ID of the auth source of the group, ie: an LDAP auth source.",
+						},
+					},
+				},
+				Description: "Groups of external auth sources whose members are " +
+					"synced into the usergroup.  The members of all groups are " +
+					"refreshed whenever the groups change.",
+			},
 		},
 	}
 }
@@ -84,9 +126,31 @@ func buildForemanUsergroup(d *schema.ResourceData) *api.ForemanUsergroup {
 
 	usergroup.Admin = d.Get("admin").(bool)
 
+	// NOTE(ALL): the roles are always sent, an empty set revokes all roles.
+	//   If omitted, the set keeps the roles of the state.
+	usergroup.RoleIds = conv.InterfaceSliceToIntSlice(d.Get("role_ids").(*schema.Set).List())
+
+	usergroup.ExternalUsergroups = buildForemanExternalUsergroups(d)
+
 	return &usergroup
 }
 
+// buildForemanExternalUsergroups constructs the external usergroups of a
+// usergroup from a resource data reference
+func buildForemanExternalUsergroups(d *schema.ResourceData) []api.ForemanExternalUsergroup {
+	attrSet := d.Get("external_usergroups").(*schema.Set)
+	groups := make([]api.ForemanExternalUsergroup, 0, attrSet.Len())
+	for _, item := range attrSet.List() {
+		itemMap := item.(map[string]interface{})
+		group := api.ForemanExternalUsergroup{
+			AuthSourceId: itemMap["auth_source_id"].(int),
+		}
+		group.Name = itemMap["name"].(string)
+		groups = append(groups, group)
+	}
+	return groups
+}
+
 // setResourceDataFromForemanUsergroup sets a ResourceData's attributes from
 // the attributes of the supplied ForemanUsergroup struct
 func setResourceDataFromForemanUsergroup(d *schema.ResourceData, fh *api.ForemanUsergroup) {
@@ -95,6 +159,60 @@ func setResourceDataFromForemanUsergroup(d *schema.ResourceData, fh *api.Foreman
 	d.SetId(strconv.Itoa(fh.Id))
 	d.Set("name", fh.Name)
 	d.Set("admin", fh.Admin)
+	d.Set("role_ids", fh.RoleIds)
+
+	groups := make([]interface{}, len(fh.ExternalUsergroups))
+	for idx, group := range fh.ExternalUsergroups {
+		groups[idx] = map[string]interface{}{
+			"name":           group.Name,
+			"auth_source_id": group.AuthSourceId,
+		}
+	}
+	d.Set("external_usergroups", groups)
+}
+
+// syncForemanExternalUsergroups maps the desired external usergroups to the
+// current usergroup.  Groups which are no longer desired are removed, new
+// ones are added and all desired groups are refreshed, which syncs their
+// members into the usergroup.
+func syncForemanExternalUsergroups(ctx context.Context, client *api.Client, current *api.ForemanUsergroup, desiredGroups []api.ForemanExternalUsergroup) error {
+	log.Tracef("resource_foreman_usergroup.go#syncForemanExternalUsergroups")
+
+	groupKey := func(group api.ForemanExternalUsergroup) string {
+		return fmt.Sprintf("%d/%s", group.AuthSourceId, group.Name)
+	}
+
+	desired := map[string]bool{}
+	for _, group := range desiredGroups {
+		desired[groupKey(group)] = true
+	}
+
+	groupIds := map[string]int{}
+	for _, group := range current.ExternalUsergroups {
+		if desired[groupKey(group)] {
+			groupIds[groupKey(group)] = group.Id
+			continue
+		}
+		if deleteErr := client.DeleteExternalUsergroup(ctx, current.Id, group.Id); deleteErr != nil {
+			return deleteErr
+		}
+	}
+
+	for _, group := range desiredGroups {
+		id, ok := groupIds[groupKey(group)]
+		if !ok {
+			createdGroup, createErr := client.CreateExternalUsergroup(ctx, current.Id, &group)
+			if createErr != nil {
+				return createErr
+			}
+			id = createdGroup.Id
+		}
+		if refreshErr := client.RefreshExternalUsergroup(ctx, current.Id, id); refreshErr != nil {
+			return refreshErr
+		}
+	}
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -118,6 +236,20 @@ func resourceForemanUsergroupCreate(ctx context.Context, d *schema.ResourceData,
 
 	setResourceDataFromForemanUsergroup(d, createdUsergroup)
 
+	// NOTE(ALL): the usergroup exists at this point, if the sync fails the
+	//   external usergroups are synced with the next apply
+	if len(h.ExternalUsergroups) > 0 {
+		syncErr := syncForemanExternalUsergroups(ctx, client, createdUsergroup, h.ExternalUsergroups)
+		if syncErr != nil {
+			return diag.FromErr(syncErr)
+		}
+		readUsergroup, readErr := client.ReadUsergroup(ctx, createdUsergroup.Id)
+		if readErr != nil {
+			return diag.FromErr(readErr)
+		}
+		setResourceDataFromForemanUsergroup(d, readUsergroup)
+	}
+
 	return nil
 }
 
@@ -161,6 +293,18 @@ func resourceForemanUsergroupUpdate(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("Updated ForemanUsergroup: [%+v]", updatedUsergroup)
 
+	if d.HasChange("external_usergroups") {
+		syncErr := syncForemanExternalUsergroups(ctx, client, updatedUsergroup, h.ExternalUsergroups)
+		if syncErr != nil {
+			return diag.FromErr(syncErr)
+		}
+		var readErr error
+		updatedUsergroup, readErr = client.ReadUsergroup(ctx, updatedUsergroup.Id)
+		if readErr != nil {
+			return diag.FromErr(readErr)
+		}
+	}
+
 	setResourceDataFromForemanUsergroup(d, updatedUsergroup)
 
 	return nil
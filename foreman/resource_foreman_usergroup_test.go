@@ -400,3 +400,9 @@ func ResourceForemanUsergroupMockResponseTestCases(t *testing.T) []TestCaseMockR
 	}
 
 }
+
+// Ensures omitted roles are left untouched and an empty set revokes all
+// roles of the usergroup
+func TestResourceForemanUsergroup_RevokeRoles(t *testing.T) {
+	testResourceRevokeRoles(t, "foreman_usergroup", "usergroups", `name = "operators"`)
+}
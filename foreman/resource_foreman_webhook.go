@@ -23,7 +23,9 @@ func resourceForemanWebhook() *schema.Resource {
 		DeleteContext: resourceForemanWebhookDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryWebhook(ctx, &api.ForemanWebhook{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -24,7 +24,9 @@ func resourceForemanWebhookTemplate() *schema.Resource {
 		DeleteContext: resourceForemanWebhookTemplateDelete,
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByName(func(ctx context.Context, client *api.Client, name string) (api.QueryResponse, error) {
+				return client.QueryWebhookTemplate(ctx, &api.ForemanWebhookTemplate{ForemanObject: api.ForemanObject{Name: name}})
+			}),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -208,7 +210,7 @@ func buildForemanWebhookTemplateResponse(d *schema.ResourceData) *api.ForemanWeb
 		webhookTemplateResponse.Description = attr.(string)
 	}
 
-	if attr, ok = d.GetOk("locations"); ok {
+	if attr, ok = d.GetOk("location_ids"); ok {
 		attrSet := attr.(*schema.Set)
 		webhookTemplateResponse.Locations = make([]api.EntityResponse, attrSet.Len())
 		for i, v := range attrSet.List() {
@@ -216,7 +218,7 @@ func buildForemanWebhookTemplateResponse(d *schema.ResourceData) *api.ForemanWeb
 		}
 	}
 
-	if attr, ok = d.GetOk("organizations"); ok {
+	if attr, ok = d.GetOk("organization_ids"); ok {
 		attrSet := attr.(*schema.Set)
 		webhookTemplateResponse.Organizations = make([]api.EntityResponse, attrSet.Len())
 		for i, v := range attrSet.List() {
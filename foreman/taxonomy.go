@@ -0,0 +1,211 @@
+package foreman
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// -----------------------------------------------------------------------------
+// Taxonomy Helpers
+// -----------------------------------------------------------------------------
+
+// taxonomyAssociations maps the association attributes of organizations and
+// locations to the object types they hold
+var taxonomyAssociations = map[string]string{
+	"user_ids":                  "users",
+	"smart_proxy_ids":           "smart proxies",
+	"compute_resource_ids":      "compute resources",
+	"medium_ids":                "installation media",
+	"provisioning_template_ids": "provisioning templates",
+	"ptable_ids":                "partition tables",
+	"domain_ids":                "domains",
+	"realm_ids":                 "realms",
+	"environment_ids":           "Puppet environments",
+	"hostgroup_ids":             "hostgroups",
+	"subnet_ids":                "subnets",
+}
+
+// resourceForemanTaxonomySchema returns the schema attributes shared by the
+// organization and location resources.  kind is either "organization" or
+// "location".
+func resourceForemanTaxonomySchema(kind string) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			Description: fmt.Sprintf(
+				"Name of the %s. %s \"Europe\"",
+				kind,
+				autodoc.MetaExample,
+			),
+		},
+
+		"title": {
+			Type:     schema.TypeString,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"The title is the path-like name of the %s, from the head of "+
+					"the tree down to this %s, in the form of: "+
+					"\"<parent 1>/<parent 2>/.../<name>\".",
+				kind,
+				kind,
+			),
+		},
+
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("Description of the %s.", kind),
+		},
+
+		"parent_id": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  fmt.Sprintf("ID of the parent %s.", kind),
+		},
+
+		"parameters": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: fmt.Sprintf(
+				"A map of parameters that will be saved as %s parameters. "+
+					"Hosts of the %s inherit them.",
+				kind,
+				kind,
+			),
+		},
+
+		"ignore_types": {
+			Type: schema.TypeSet,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(api.TaxonomyIgnoreTypes, false),
+			},
+			Optional: true,
+			Description: fmt.Sprintf(
+				"Object types which are associated with the %s as a whole, "+
+					"including objects created later. One of: %q.",
+				kind,
+				api.TaxonomyIgnoreTypes,
+			),
+		},
+	}
+
+	for attr, objects := range taxonomyAssociations {
+		s[attr] = &schema.Schema{
+			Type:     schema.TypeSet,
+			Elem:     &schema.Schema{Type: schema.TypeInt},
+			Optional: true,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"IDs of the %s associated with the %s. Objects of an ignored "+
+					"type are always associated.",
+				objects,
+				kind,
+			),
+		}
+	}
+
+	return s
+}
+
+// buildForemanTaxonomy constructs a ForemanTaxonomy reference from a resource
+// data reference of an organization or location
+func buildForemanTaxonomy(d *schema.ResourceData) *api.ForemanTaxonomy {
+	t := api.ForemanTaxonomy{}
+
+	obj := buildForemanObject(d)
+	t.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("title"); ok {
+		t.Title = attr.(string)
+	}
+	if attr, ok = d.GetOk("description"); ok {
+		t.Description = attr.(string)
+	}
+	if attr, ok = d.GetOk("parent_id"); ok {
+		t.ParentId = attr.(int)
+	}
+	if attr, ok = d.GetOk("parameters"); ok {
+		t.Parameters = api.ToKV(attr.(map[string]interface{}))
+	} else if d.HasChange("parameters") {
+		// removes the last parameters
+		t.Parameters = []api.ForemanKVParameter{}
+	}
+	if attr, ok = d.GetOk("ignore_types"); ok {
+		attrSet := attr.(*schema.Set)
+		t.IgnoreTypes = conv.InterfaceSliceToStringSlice(attrSet.List())
+	}
+
+	associations := map[string]*[]int{
+		"user_ids":                  &t.UserIds,
+		"smart_proxy_ids":           &t.SmartProxyIds,
+		"compute_resource_ids":      &t.ComputeResourceIds,
+		"medium_ids":                &t.MediumIds,
+		"provisioning_template_ids": &t.ProvisioningTemplateIds,
+		"ptable_ids":                &t.PtableIds,
+		"domain_ids":                &t.DomainIds,
+		"realm_ids":                 &t.RealmIds,
+		"environment_ids":           &t.EnvironmentIds,
+		"hostgroup_ids":             &t.HostgroupIds,
+		"subnet_ids":                &t.SubnetIds,
+	}
+	for key, ids := range associations {
+		*ids = buildTaxonomyIds(d, key)
+	}
+
+	return &t
+}
+
+// buildTaxonomyIds returns the IDs of a set of taxonomy or association IDs,
+// ie: the location_ids of a role or the domain_ids of an organization.
+// Returns an empty list if the set changed to empty, which removes all
+// associations, and nil if it is neither set nor changed, which leaves the
+// associations untouched.
+func buildTaxonomyIds(d *schema.ResourceData, key string) []int {
+	if attr, ok := d.GetOk(key); ok {
+		return conv.InterfaceSliceToIntSlice(attr.(*schema.Set).List())
+	}
+	if d.HasChange(key) {
+		return []int{}
+	}
+	return nil
+}
+
+// setResourceDataFromForemanTaxonomy sets the attributes shared by
+// organizations and locations from the supplied ForemanTaxonomy reference
+func setResourceDataFromForemanTaxonomy(d *schema.ResourceData, t *api.ForemanTaxonomy) {
+	d.SetId(strconv.Itoa(t.Id))
+	d.Set("name", t.Name)
+	d.Set("title", t.Title)
+	d.Set("description", t.Description)
+	d.Set("parent_id", t.ParentId)
+	d.Set("parameters", api.FromKV(t.Parameters))
+	d.Set("ignore_types", t.IgnoreTypes)
+	d.Set("user_ids", t.UserIds)
+	d.Set("smart_proxy_ids", t.SmartProxyIds)
+	d.Set("compute_resource_ids", t.ComputeResourceIds)
+	d.Set("medium_ids", t.MediumIds)
+	d.Set("provisioning_template_ids", t.ProvisioningTemplateIds)
+	d.Set("ptable_ids", t.PtableIds)
+	d.Set("domain_ids", t.DomainIds)
+	d.Set("realm_ids", t.RealmIds)
+	d.Set("environment_ids", t.EnvironmentIds)
+	d.Set("hostgroup_ids", t.HostgroupIds)
+	d.Set("subnet_ids", t.SubnetIds)
+}